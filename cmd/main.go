@@ -4,13 +4,16 @@ import (
 	"log"
 	"os"
 	"srs-automation/internal/api/router"
+	"srs-automation/internal/core/ports"
 	"srs-automation/internal/infra/database"
 	"srs-automation/internal/infra/external"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/joho/godotenv"
 )
 
@@ -32,19 +35,34 @@ func main() {
 	}
 
 	// Initialize external services
+	// AI providers; each project picks one in its settings, and tenants may
+	// use their own keys through the same factory
+	aiFactory := external.NewAIFactory("google-credentials.json")
+	aiProviders := map[string]ports.AIService{}
+
 	//Groq
-	apiKey := os.Getenv("GROQ_API_KEY")
-	if apiKey == "" {
-		log.Fatal("GROQ_API_KEY is required in .env")
+	if apiKey := os.Getenv("GROQ_API_KEY"); apiKey != "" {
+		groqClient, _ := aiFactory.NewClient("groq", apiKey, "")
+		aiProviders["groq"] = groqClient
 	}
 
-	aiClient := external.NewGroqClient(apiKey)
-
 	//Gemini
-	// aiClient, err := external.NewGeminiClient(os.Getenv("GEMINI_API_KEY"), "google-credentials.json")
-	// if err != nil {
-	// 	log.Fatalf("Gagal inisialisasi Gemini/Google Client: %v", err)
-	// }
+	if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
+		geminiClient, err := aiFactory.NewClient("gemini", apiKey, "")
+		if err != nil {
+			log.Printf("Gemini tidak tersedia: %v", err)
+		} else {
+			aiProviders["gemini"] = geminiClient
+		}
+	}
+
+	defaultProvider := os.Getenv("AI_PROVIDER")
+	if defaultProvider == "" {
+		defaultProvider = "groq"
+	}
+	if _, ok := aiProviders[defaultProvider]; !ok {
+		log.Fatalf("AI_PROVIDER %q is not configured; set its API key (GROQ_API_KEY or GEMINI_API_KEY) in .env", defaultProvider)
+	}
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -53,11 +71,13 @@ func main() {
 
 	// Middleware
 	app.Use(recover.New())
+	// Request IDs tie audit events to the request that caused them
+	app.Use(requestid.New())
 	app.Use(logger.New())
-	app.Use(cors.New())
+	app.Use(cors.New(corsConfig()))
 
 	// Setup routes
-	router.SetupRoutes(app, db, aiClient)
+	router.SetupRoutes(app, db, aiFactory, aiProviders, defaultProvider)
 
 	// Start server
 	port := os.Getenv("APP_PORT")
@@ -70,3 +90,23 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// corsConfig reads CORS_ALLOWED_ORIGINS, a comma-separated list of origins
+// allowed to call the API from a browser ("*" allows any origin). When it is
+// not set, cross-origin requests are refused.
+func corsConfig() cors.Config {
+	config := cors.Config{
+		AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-API-Key",
+		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
+	}
+
+	origins := strings.TrimSpace(os.Getenv("CORS_ALLOWED_ORIGINS"))
+	if origins == "" {
+		log.Println("CORS_ALLOWED_ORIGINS is not set, cross-origin requests are refused")
+		config.AllowOriginsFunc = func(string) bool { return false }
+		return config
+	}
+
+	config.AllowOrigins = origins
+	return config
+}
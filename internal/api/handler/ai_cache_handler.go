@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AICacheHandler struct {
+	cache *service.AICache
+}
+
+func NewAICacheHandler(cache *service.AICache) *AICacheHandler {
+	return &AICacheHandler{cache: cache}
+}
+
+// Endpoint: GET /api/v1/ai-cache
+// Reports the cached responses and hit rate per AI method.
+func (h *AICacheHandler) Stats(c *fiber.Ctx) error {
+	stats, err := h.cache.Stats()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": stats,
+	})
+}
+
+// Endpoint: DELETE /api/v1/ai-cache
+func (h *AICacheHandler) Clear(c *fiber.Ctx) error {
+	if err := h.cache.Clear(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "AI cache cleared",
+	})
+}
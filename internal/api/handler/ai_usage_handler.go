@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AIUsageHandler struct {
+	service *service.AIUsageService
+}
+
+func NewAIUsageHandler(service *service.AIUsageService) *AIUsageHandler {
+	return &AIUsageHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/ai-usage?group=day|project|document|model&project_id=&document_id=&from=&to=
+// Adds up the AI calls of the whole tenant.
+func (h *AIUsageHandler) Tenant(c *fiber.Ctx) error {
+	filter, err := aiUsageFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	filter.ProjectID = uint(c.QueryInt("project_id"))
+	return h.report(c, filter, c.Query("group", string(domain.AIUsageByDay)))
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/ai-usage?group=&document_id=&from=&to=
+// Also reports the project's budget for the current month.
+func (h *AIUsageHandler) Project(c *fiber.Ctx) error {
+	filter, err := aiUsageFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	filter.ProjectID = projectID(c)
+	return h.report(c, filter, c.Query("group", string(domain.AIUsageByDay)))
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/ai-usage?group=model|day&from=&to=
+func (h *AIUsageHandler) Document(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	filter, err := aiUsageFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	filter.ProjectID = projectID(c)
+	filter.DocumentID = uint(id)
+	return h.report(c, filter, c.Query("group", string(domain.AIUsageByModel)))
+}
+
+func (h *AIUsageHandler) report(c *fiber.Ctx, filter domain.AIUsageFilter, group string) error {
+	report, err := h.service.Report(filter, domain.AIUsageGroup(group))
+	if err != nil {
+		return aiUsageError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": report,
+	})
+}
+
+// aiUsageFilter reads document_id, from and to; dates work as in the audit trail
+func aiUsageFilter(c *fiber.Ctx) (domain.AIUsageFilter, error) {
+	filter := domain.AIUsageFilter{DocumentID: uint(c.QueryInt("document_id"))}
+
+	if raw := c.Query("from"); raw != "" {
+		from, _, err := parseAuditTime(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, dateOnly, err := parseAuditTime(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+func aiUsageError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidUsageFilter):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrProjectNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
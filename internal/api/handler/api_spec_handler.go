@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type APISpecHandler struct {
+	service *service.APISpecService
+}
+
+func NewAPISpecHandler(service *service.APISpecService) *APISpecHandler {
+	return &APISpecHandler{service: service}
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/openapi
+func (h *APISpecHandler) Generate(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	spec, err := h.service.GenerateSpec(uint(id))
+	if err != nil {
+		return apiSpecError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "OpenAPI draft generated successfully",
+		"data":    spec,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/openapi
+func (h *APISpecHandler) Download(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	result, err := h.service.ExportSpec(uint(id))
+	if err != nil {
+		return apiSpecError(c, err)
+	}
+
+	return sendAttachment(c, result)
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/openapi/operations
+func (h *APISpecHandler) GetOperations(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	spec, err := h.service.GetSpec(uint(id))
+	if err != nil {
+		return apiSpecError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": spec,
+	})
+}
+
+func apiSpecError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSRSNotFound), errors.Is(err, service.ErrAPISpecNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrNoRequirements):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIQuotaExceeded), errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidAIResponse), errors.Is(err, service.ErrInvalidAPISpec):
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
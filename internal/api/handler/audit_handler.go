@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(service *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/audit-events?actor=&actor_id=&action=&entity_type=&entity_id=&request_id=&from=&to=&limit=
+func (h *AuditHandler) GetEvents(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	events, err := h.service.GetEvents(filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": events,
+	})
+}
+
+// Endpoint: GET /api/v1/audit-events/export (same filters as GetEvents)
+func (h *AuditHandler) Export(c *fiber.Ctx) error {
+	filter, err := auditFilter(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	result, err := h.service.ExportCSV(filter)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, result.Filename))
+	return c.Send(result.Data)
+}
+
+// Endpoint: GET /api/v1/audit-events/verify
+func (h *AuditHandler) Verify(c *fiber.Ctx) error {
+	result, err := h.service.Verify()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": result,
+	})
+}
+
+// auditActor describes the caller of a request for the audit trail. It is
+// read before any background work starts, while the request is still valid.
+func auditActor(c *fiber.Ctx) domain.AuditActor {
+	actor := domain.AuditActor{
+		IP:        c.IP(),
+		RequestID: c.GetRespHeader(fiber.HeaderXRequestID),
+	}
+	if p := principal(c); p != nil {
+		actor.Type = string(p.Method)
+		actor.Name = p.Name
+		actor.ID = p.UserID
+		if p.Method == domain.AuthMethodAPIKey {
+			actor.ID = p.APIKeyID
+		}
+	}
+	return actor
+}
+
+func auditFilter(c *fiber.Ctx) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		ActorID:    uint(c.QueryInt("actor_id")),
+		ActorName:  c.Query("actor"),
+		Action:     domain.AuditAction(c.Query("action")),
+		EntityType: c.Query("entity_type"),
+		EntityID:   uint(c.QueryInt("entity_id")),
+		RequestID:  c.Query("request_id"),
+		Limit:      c.QueryInt("limit"),
+	}
+
+	if raw := c.Query("from"); raw != "" {
+		from, _, err := parseAuditTime(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+		filter.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, dateOnly, err := parseAuditTime(raw)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+		// A date includes the whole day
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+	return filter, nil
+}
+
+// parseAuditTime accepts a date (2006-01-02) or an RFC 3339 timestamp
+func parseAuditTime(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t, false, err
+}
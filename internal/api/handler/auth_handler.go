@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+	"strings"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+// principalKey is the fiber.Ctx local holding the authenticated caller
+const principalKey = "principal"
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Authenticate accepts "Authorization: Bearer <jwt|api key>" or
+// "X-API-Key: <api key>" and rejects requests without valid credentials
+func (h *AuthHandler) Authenticate(c *fiber.Ctx) error {
+	credential := c.Get("X-API-Key")
+	if auth := c.Get(fiber.HeaderAuthorization); credential == "" && len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		credential = auth[7:]
+	}
+	// Browsers can't set headers on EventSource and WebSocket connections
+	if credential == "" && isStreamRequest(c) {
+		credential = c.Query("access_token")
+	}
+
+	principal, err := h.service.Authenticate(credential)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	c.Locals(principalKey, principal)
+	return c.Next()
+}
+
+func isStreamRequest(c *fiber.Ctx) bool {
+	return websocket.IsWebSocketUpgrade(c) || strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
+}
+
+// Require rejects callers whose role lacks the permission
+func Require(permission domain.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !can(c, permission) {
+			return authError(c, service.ErrForbidden)
+		}
+		return c.Next()
+	}
+}
+
+// Endpoint: POST /api/v1/auth/login
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.service.Login(req.Email, req.Password)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": result,
+	})
+}
+
+// Endpoint: GET /api/v1/auth/me
+func (h *AuthHandler) Me(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"data": principal(c),
+	})
+}
+
+// Endpoint: GET /api/v1/users
+func (h *AuthHandler) GetUsers(c *fiber.Ctx) error {
+	users, err := h.service.GetUsers()
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": users,
+	})
+}
+
+// Endpoint: POST /api/v1/users
+func (h *AuthHandler) CreateUser(c *fiber.Ctx) error {
+	var req service.UserInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := h.service.CreateUser(req)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "User created successfully",
+		"data":    user,
+	})
+}
+
+// Endpoint: PUT /api/v1/users/:id
+func (h *AuthHandler) UpdateUser(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user ID",
+		})
+	}
+
+	var req service.UserInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	user, err := h.service.UpdateUser(uint(id), req)
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User updated successfully",
+		"data":    user,
+	})
+}
+
+// Endpoint: GET /api/v1/api-keys
+func (h *AuthHandler) GetAPIKeys(c *fiber.Ctx) error {
+	keys, err := h.service.GetAPIKeys()
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": keys,
+	})
+}
+
+// Endpoint: POST /api/v1/api-keys
+func (h *AuthHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req service.APIKeyInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	created, err := h.service.CreateAPIKey(req, principal(c))
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "API key created; store it now, it will not be shown again",
+		"data":    created,
+	})
+}
+
+// Endpoint: DELETE /api/v1/api-keys/:id
+func (h *AuthHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid API key ID",
+		})
+	}
+
+	key, err := h.service.RevokeAPIKey(uint(id))
+	if err != nil {
+		return authError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "API key revoked successfully",
+		"data":    key,
+	})
+}
+
+// principal returns the caller resolved by AuthHandler.Authenticate
+func principal(c *fiber.Ctx) *domain.Principal {
+	p, _ := c.Locals(principalKey).(*domain.Principal)
+	return p
+}
+
+func can(c *fiber.Ctx, permission domain.Permission) bool {
+	p := principal(c)
+	return p != nil && p.Can(permission)
+}
+
+func authError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthenticated), errors.Is(err, service.ErrInvalidCredentials):
+		c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="srs-automation"`)
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrForbidden):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrAPIKeyNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidUser), errors.Is(err, service.ErrInvalidAPIKey):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrDuplicateUser):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
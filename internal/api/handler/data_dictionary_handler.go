@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DataDictionaryHandler struct {
+	service *service.DataDictionaryService
+}
+
+func NewDataDictionaryHandler(service *service.DataDictionaryService) *DataDictionaryHandler {
+	return &DataDictionaryHandler{service: service}
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/data-dictionary/extract
+func (h *DataDictionaryHandler) Extract(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	entities, err := h.service.Extract(uint(id))
+	if err != nil {
+		return dataDictionaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": fmt.Sprintf("Data dictionary has %d entities", len(entities)),
+		"data":    entities,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/data-dictionary
+func (h *DataDictionaryHandler) GetBySRS(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	entities, err := h.service.GetEntities(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": entities,
+	})
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/data-dictionary
+func (h *DataDictionaryHandler) Create(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	var req service.DataEntityInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	entity, err := h.service.CreateEntity(uint(id), req)
+	if err != nil {
+		return dataDictionaryError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Data entity created successfully",
+		"data":    entity,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/data-entities/:id
+func (h *DataDictionaryHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid data entity ID",
+		})
+	}
+
+	entity, err := h.service.GetEntity(uint(id))
+	if err != nil {
+		return dataDictionaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": entity,
+	})
+}
+
+// Endpoint: PUT /api/v1/projects/:projectId/data-entities/:id
+func (h *DataDictionaryHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid data entity ID",
+		})
+	}
+
+	var req service.DataEntityInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	entity, err := h.service.UpdateEntity(uint(id), req)
+	if err != nil {
+		return dataDictionaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Data entity updated successfully",
+		"data":    entity,
+	})
+}
+
+// Endpoint: DELETE /api/v1/projects/:projectId/data-entities/:id
+func (h *DataDictionaryHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid data entity ID",
+		})
+	}
+
+	if err := h.service.DeleteEntity(uint(id)); err != nil {
+		return dataDictionaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Data entity deleted successfully",
+	})
+}
+
+func dataDictionaryError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSRSNotFound), errors.Is(err, service.ErrDataEntityNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidDataEntity):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrDuplicateEntity):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIQuotaExceeded), errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidAIResponse):
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
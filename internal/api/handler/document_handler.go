@@ -1,14 +1,25 @@
 package handler
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"srs-automation/internal/core/domain"
 	"srs-automation/internal/core/service"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 )
 
+// progressKeepAlive is how often an idle progress stream is pinged so
+// proxies don't close it
+const progressKeepAlive = 15 * time.Second
+
 type DocumentHandler struct {
 	service *service.DocumentService
 }
@@ -18,6 +29,13 @@ func NewDocumentHandler(service *service.DocumentService) *DocumentHandler {
 }
 
 func (h *DocumentHandler) Upload(c *fiber.Ctx) error {
+	// The upload is processed right away, which the budget would refuse
+	if err := h.service.CheckAIBudget(projectID(c)); err != nil {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -25,7 +43,7 @@ func (h *DocumentHandler) Upload(c *fiber.Ctx) error {
 		})
 	}
 
-	docType := c.FormValue("type")
+	docType := strings.ToUpper(c.FormValue("type"))
 	if docType == "" {
 		docType = string(domain.DocumentTypeBRD)
 	}
@@ -46,24 +64,27 @@ func (h *DocumentHandler) Upload(c *fiber.Ctx) error {
 		})
 	}
 
+	// no_cache asks the AI for a fresh SRS instead of a cached one
+	noCache := c.FormValue("no_cache") == "true"
+
 	// Upload document
-	doc, err := h.service.UploadDocument(file.Filename, domain.DocumentType(docType), fileData)
+	actor := auditActor(c)
+	doc, err := h.service.UploadDocument(actor, projectID(c), file.Filename, domain.DocumentType(docType), fileData)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidDocumentType) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	go func(id uint) {
-		// Logika ini berjalan TERPISAH dari request user
-		fmt.Printf("🔄 [Background] Memulai analisis AI untuk ID: %d...\n", id)
-
-		err := h.service.ProcessDocument(id)
-		if err != nil {
-			// Jika error, kita hanya bisa log di terminal server karena user sudah pergi
-			fmt.Printf("❌ [Background] Gagal memproses ID %d: %v\n", id, err)
-		} else {
-			fmt.Printf("✅ [Background] Sukses! Google Doc dibuat untuk ID %d\n", id)
+		// Progress and the outcome are published on the document's progress stream
+		if err := h.service.ProcessDocument(actor, id, noCache); err != nil {
+			log.Printf("processing document %d failed: %v", id, err)
 		}
 	}(doc.ID) // Kita kirim ID dokumen yang baru saja dibuat
 
@@ -88,7 +109,17 @@ func (h *DocumentHandler) Process(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.ProcessDocument(uint(id)); err != nil {
+	if err := h.service.ProcessDocument(auditActor(c), uint(id), c.QueryBool("no_cache")); err != nil {
+		if errors.Is(err, service.ErrAIQuotaExceeded) || errors.Is(err, service.ErrAIBudgetExceeded) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, service.ErrInvalidSRSOutput) {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -119,16 +150,40 @@ func (h *DocumentHandler) GetByID(c *fiber.Ctx) error {
 	})
 }
 
+// Endpoint: GET /api/v1/projects/:projectId/documents?status=&type=&author=&q=&from=&to=&sort=&limit=&offset=&cursor=
+// Endpoint: GET /api/v1/documents?project_id= (same filters, across projects)
 func (h *DocumentHandler) GetAll(c *fiber.Ctx) error {
-	docs, err := h.service.GetAllDocuments()
+	filter := domain.DocumentFilter{
+		ProjectID: listProjectID(c),
+		Status:    domain.DocumentStatus(strings.ToUpper(c.Query("status"))),
+		Type:      domain.DocumentType(strings.ToUpper(c.Query("type"))),
+		Author:    c.Query("author"),
+		Search:    c.Query("q"),
+		Page:      pageRequest(c),
+	}
+	from, to, err := createdRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	filter.From, filter.To = from, to
+
+	docs, page, err := h.service.ListDocuments(filter)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidListQuery) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	return c.JSON(fiber.Map{
-		"data": docs,
+		"data":       docs,
+		"pagination": page,
 	})
 }
 
@@ -149,7 +204,7 @@ func (h *DocumentHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.DeleteDocument(uint(id)); err != nil {
+	if err := h.service.DeleteDocument(auditActor(c), uint(id)); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -160,19 +215,138 @@ func (h *DocumentHandler) Delete(c *fiber.Ctx) error {
 	})
 }
 
-// Endpoint: GET /api/v1/documents/:id/download
-func (h *DocumentHandler) DownloadResult(c *fiber.Ctx) error {
-	id, _ := c.ParamsInt("id")
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/events
+// Streams the processing progress as server-sent events. The stream starts
+// with the current state and ends after the completed or failed event.
+func (h *DocumentHandler) Events(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
 
-	doc, err := h.service.GetDocument(uint(id)) // Use the correct service method
+	current, events, cancel, err := h.service.FollowProgress(uint(id))
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Dokumen tidak ditemukan"})
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Document not found",
+		})
 	}
 
-	if doc.Status != "COMPLETED" {
-		return c.Status(400).JSON(fiber.Map{"error": "Dokumen belum selesai diproses"})
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after the handler returns, so it must not touch c
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		if err := writeProgressEvent(w, current); err != nil || current.Final() {
+			return
+		}
+
+		keepAlive := time.NewTicker(progressKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case event := <-events:
+				if err := writeProgressEvent(w, event); err != nil || event.Final() {
+					return
+				}
+			case <-keepAlive.C:
+				// A failed write means the client has gone away
+				fmt.Fprint(w, ": keep-alive\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	})
+	return nil
+}
+
+func writeProgressEvent(w *bufio.Writer, event domain.ProgressEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
 	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Stage, data)
+	return w.Flush()
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/ws
+// WebSocket alternative to Events: each progress event is sent as a JSON
+// text message, and the server closes the connection after the final event.
+func (h *DocumentHandler) Socket() fiber.Handler {
+	upgrade := websocket.New(h.streamProgress)
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return c.Status(fiber.StatusUpgradeRequired).JSON(fiber.Map{
+				"error": "WebSocket upgrade required",
+			})
+		}
+		return upgrade(c)
+	}
+}
+
+func (h *DocumentHandler) streamProgress(conn *websocket.Conn) {
+	id, err := strconv.Atoi(conn.Params("id"))
+	if err != nil {
+		conn.WriteJSON(fiber.Map{"error": "Invalid document ID"})
+		return
+	}
+
+	current, events, cancel, err := h.service.FollowProgress(uint(id))
+	if err != nil {
+		conn.WriteJSON(fiber.Map{"error": "Document not found"})
+		return
+	}
+	defer cancel()
+
+	// Clients don't send anything; reading notices when they disconnect
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := conn.WriteJSON(current); err != nil {
+		return
+	}
+	if current.Final() {
+		closeSocket(conn)
+		return
+	}
+
+	keepAlive := time.NewTicker(progressKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case event := <-events:
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+			if event.Final() {
+				closeSocket(conn)
+				return
+			}
+		case <-closed:
+			return
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(progressKeepAlive)); err != nil {
+				return
+			}
+		}
+	}
+}
 
-	// doc.GoogleDocLink sekarang berisi path file lokal "./outputs/SRS-namafile.docx"
-	return c.Download(doc.GoogleDocLink)
+// closeSocket tells the client the stream ended normally
+func closeSocket(conn *websocket.Conn) {
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
 }
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DownloadHandler struct {
+	service *service.DownloadService
+}
+
+func NewDownloadHandler(service *service.DownloadService) *DownloadHandler {
+	return &DownloadHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/download-link?kind=result|source
+func (h *DownloadHandler) CreateLink(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	kind := domain.FileKind(c.Query("kind", string(domain.FileKindResult)))
+
+	link, err := h.service.CreateLink(uint(id), kind, c.BaseURL())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidFileKind), errors.Is(err, service.ErrFileNotReady):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrFilePurged):
+			return c.Status(fiber.StatusGone).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Document not found",
+			})
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"data": link,
+	})
+}
+
+// Endpoint: GET /api/v1/downloads/:id/:kind?expires=...&signature=...
+func (h *DownloadHandler) Download(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	kind := domain.FileKind(c.Params("kind"))
+	expires := int64(c.QueryInt("expires"))
+
+	path, filename, err := h.service.ResolveDownload(uint(id), kind, expires, c.Query("signature"), c.IP(), c.Get(fiber.HeaderUserAgent))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidSignature), errors.Is(err, service.ErrLinkExpired):
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrInvalidFileKind), errors.Is(err, service.ErrFileNotReady):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrFilePurged):
+			return c.Status(fiber.StatusGone).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "File not found",
+			})
+		}
+	}
+
+	return c.Download(path, filename)
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/access-logs
+func (h *DownloadHandler) AccessLogs(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	logs, err := h.service.GetAccessLogs(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": logs,
+	})
+}
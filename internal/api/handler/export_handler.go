@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExportHandler struct {
+	service *service.ExportService
+}
+
+func NewExportHandler(service *service.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/export?format=docx|pdf|md|html|json|reqif&revision=...&template_id=...
+// Without a format parameter the format is negotiated from the Accept header.
+func (h *ExportHandler) Export(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		mediaType := c.Accepts(h.service.MediaTypes()...)
+		if mediaType == "" {
+			return c.Status(fiber.StatusNotAcceptable).JSON(fiber.Map{
+				"error":     "None of the accepted media types can be exported",
+				"available": h.service.MediaTypes(),
+			})
+		}
+		format = h.service.FormatForMediaType(mediaType)
+	}
+
+	opts := service.ExportOptions{
+		Format:     format,
+		Revision:   c.QueryInt("revision"),
+		TemplateID: uint(c.QueryInt("template_id")),
+	}
+
+	result, err := h.service.Export(uint(id), opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSRSNotFound), errors.Is(err, service.ErrRevisionNotFound),
+			errors.Is(err, service.ErrTemplateNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrUnsupportedFormat), errors.Is(err, service.ErrTemplateNotAllowed):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, result.Filename))
+	return c.Send(result.Data)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GlossaryHandler struct {
+	service *service.GlossaryService
+}
+
+func NewGlossaryHandler(service *service.GlossaryService) *GlossaryHandler {
+	return &GlossaryHandler{service: service}
+}
+
+type ExtractGlossaryRequest struct {
+	DocumentID uint `json:"document_id"`
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/glossary?status=PROPOSED|APPROVED|REJECTED
+func (h *GlossaryHandler) GetAll(c *fiber.Ctx) error {
+	status := domain.GlossaryTermStatus(strings.ToUpper(c.Query("status")))
+	terms, err := h.service.GetTerms(projectID(c), status)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": terms,
+	})
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/glossary
+func (h *GlossaryHandler) Create(c *fiber.Ctx) error {
+	var req service.GlossaryTermInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Terms added without review rights wait for a reviewer's approval
+	if !can(c, domain.PermissionReview) {
+		if req.Status != "" && req.Status != domain.TermProposed {
+			return authError(c, service.ErrForbidden)
+		}
+		req.Status = domain.TermProposed
+	}
+
+	term, err := h.service.CreateTerm(projectID(c), req)
+	if err != nil {
+		return glossaryError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Glossary term created successfully",
+		"data":    term,
+	})
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/glossary/extract
+func (h *GlossaryHandler) Extract(c *fiber.Ctx) error {
+	var req ExtractGlossaryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	terms, err := h.service.ExtractFromDocument(projectID(c), req.DocumentID)
+	if err != nil {
+		return glossaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": fmt.Sprintf("%d terms proposed", len(terms)),
+		"data":    terms,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/glossary/:id
+func (h *GlossaryHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid glossary term ID",
+		})
+	}
+
+	term, err := h.service.GetTerm(uint(id))
+	if err != nil {
+		return glossaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": term,
+	})
+}
+
+// Endpoint: PUT /api/v1/projects/:projectId/glossary/:id
+func (h *GlossaryHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid glossary term ID",
+		})
+	}
+
+	var req service.GlossaryTermInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	// Approving or rejecting a term is a review decision
+	if req.Status != "" && req.Status != domain.TermProposed && !can(c, domain.PermissionReview) {
+		return authError(c, service.ErrForbidden)
+	}
+
+	term, err := h.service.UpdateTerm(uint(id), req)
+	if err != nil {
+		return glossaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Glossary term updated successfully",
+		"data":    term,
+	})
+}
+
+// Endpoint: DELETE /api/v1/projects/:projectId/glossary/:id
+func (h *GlossaryHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid glossary term ID",
+		})
+	}
+
+	if err := h.service.DeleteTerm(uint(id)); err != nil {
+		return glossaryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Glossary term deleted successfully",
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/glossary-check
+func (h *GlossaryHandler) CheckSRS(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	issues, err := h.service.CheckSRS(uint(id))
+	if err != nil {
+		return glossaryError(c, err)
+	}
+
+	synonyms := 0
+	for _, issue := range issues {
+		if issue.Kind == domain.TermIssueSynonym {
+			synonyms++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"message": fmt.Sprintf("%d synonyms, %d undefined terms", synonyms, len(issues)-synonyms),
+		"data":    issues,
+	})
+}
+
+func glossaryError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrGlossaryTermNotFound), errors.Is(err, service.ErrDocumentNotFound),
+		errors.Is(err, service.ErrSRSNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidGlossaryTerm):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrDuplicateTerm):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIQuotaExceeded), errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidAIResponse):
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	service *service.NotificationService
+}
+
+func NewNotificationHandler(service *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/notifications/preferences
+// Returns the caller's preference, or the default of their role
+func (h *NotificationHandler) GetPreference(c *fiber.Ctx) error {
+	userID, ok := callerUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Notification preferences belong to user accounts, not API keys",
+		})
+	}
+
+	pref, err := h.service.GetPreference(userID)
+	if err != nil {
+		return notificationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": pref,
+	})
+}
+
+// Endpoint: PUT /api/v1/notifications/preferences
+func (h *NotificationHandler) SetPreference(c *fiber.Ctx) error {
+	userID, ok := callerUserID(c)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Notification preferences belong to user accounts, not API keys",
+		})
+	}
+
+	var req service.PreferenceInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	pref, err := h.service.SetPreference(userID, req)
+	if err != nil {
+		return notificationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Notification preferences updated successfully",
+		"data":    pref,
+	})
+}
+
+// Endpoint: GET /api/v1/notifications?limit=
+func (h *NotificationHandler) GetAll(c *fiber.Ctx) error {
+	notifications, err := h.service.GetNotifications(c.QueryInt("limit"))
+	if err != nil {
+		return notificationError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": notifications,
+	})
+}
+
+// callerUserID returns the user account behind the request, if any
+func callerUserID(c *fiber.Ctx) (uint, bool) {
+	p := principal(c)
+	if p == nil || p.UserID == 0 {
+		return 0, false
+	}
+	return p.UserID, true
+}
+
+func notificationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidPreference):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// pageRequest reads limit, offset, cursor and sort; a sort field prefixed
+// with "-" sorts descending
+func pageRequest(c *fiber.Ctx) domain.PageRequest {
+	page := domain.PageRequest{
+		Limit:  c.QueryInt("limit"),
+		Offset: c.QueryInt("offset"),
+		Cursor: c.Query("cursor"),
+	}
+	page.Sort, page.Desc = strings.CutPrefix(c.Query("sort"), "-")
+	return page
+}
+
+// listProjectID is the project of a project-scoped route, otherwise the
+// project_id query parameter; 0 lists every project
+func listProjectID(c *fiber.Ctx) uint {
+	if id := projectID(c); id != 0 {
+		return id
+	}
+	return uint(c.QueryInt("project_id"))
+}
+
+// createdRange reads from and to; dates work as in the audit trail
+func createdRange(c *fiber.Ctx) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+	if raw := c.Query("from"); raw != "" {
+		t, _, err := parseAuditTime(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid from: %w", err)
+		}
+		from = &t
+	}
+	if raw := c.Query("to"); raw != "" {
+		t, dateOnly, err := parseAuditTime(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid to: %w", err)
+		}
+		// A date includes the whole day
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		to = &t
+	}
+	return from, to, nil
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// projectIDKey is the fiber.Ctx local holding the project of a scoped route
+const projectIDKey = "projectID"
+
+type ProjectHandler struct {
+	service *service.ProjectService
+}
+
+func NewProjectHandler(service *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{service: service}
+}
+
+// Scope resolves :projectId for every route under /api/v1/projects/:projectId
+func (h *ProjectHandler) Scope(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("projectId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid project ID",
+		})
+	}
+
+	if _, err := h.service.GetProject(uint(id)); err != nil {
+		return projectError(c, err)
+	}
+
+	c.Locals(projectIDKey, uint(id))
+	return c.Next()
+}
+
+// Owns rejects requests whose route param names a resource of another project
+func (h *ProjectHandler) Owns(resource service.ProjectResource, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := c.ParamsInt(param)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid " + string(resource) + " ID",
+			})
+		}
+
+		if err := h.service.CheckOwnership(projectID(c), resource, uint(id)); err != nil {
+			return projectError(c, err)
+		}
+		return c.Next()
+	}
+}
+
+// Endpoint: POST /api/v1/projects
+func (h *ProjectHandler) Create(c *fiber.Ctx) error {
+	var req service.ProjectInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	project, err := h.service.CreateProject(req)
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Project created successfully",
+		"data":    project,
+	})
+}
+
+// Endpoint: GET /api/v1/projects
+func (h *ProjectHandler) GetAll(c *fiber.Ctx) error {
+	projects, err := h.service.GetProjects()
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": projects,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId
+func (h *ProjectHandler) GetByID(c *fiber.Ctx) error {
+	project, err := h.service.GetProject(projectID(c))
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": project,
+	})
+}
+
+// Endpoint: PUT /api/v1/projects/:projectId
+func (h *ProjectHandler) Update(c *fiber.Ctx) error {
+	var req service.ProjectInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	project, err := h.service.UpdateProject(projectID(c), req)
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project updated successfully",
+		"data":    project,
+	})
+}
+
+// Endpoint: PUT /api/v1/projects/:projectId/settings
+func (h *ProjectHandler) UpdateSettings(c *fiber.Ctx) error {
+	var req service.ProjectSettings
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	project, err := h.service.UpdateSettings(projectID(c), req)
+	if err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project settings updated successfully",
+		"data":    project,
+	})
+}
+
+// Endpoint: DELETE /api/v1/projects/:projectId
+func (h *ProjectHandler) Delete(c *fiber.Ctx) error {
+	if err := h.service.DeleteProject(projectID(c)); err != nil {
+		return projectError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Project deleted successfully",
+	})
+}
+
+// projectID returns the project resolved by ProjectHandler.Scope
+func projectID(c *fiber.Ctx) uint {
+	id, _ := c.Locals(projectIDKey).(uint)
+	return id
+}
+
+func projectError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrProjectNotFound), errors.Is(err, service.ErrNotInProject),
+		errors.Is(err, service.ErrTemplateNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidProject):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrDuplicateProject), errors.Is(err, service.ErrProjectNotEmpty):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter gives every API key, or user for JWT callers, its own token
+// bucket on the routes it guards. It outlives the per-tenant apps, so a
+// rebuilt route table doesn't reset the buckets.
+type RateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	buckets   map[string]*rate.Limiter
+	nextSweep time.Time
+}
+
+// rateLimitSweepInterval is how often idle buckets are dropped
+const rateLimitSweepInterval = time.Minute
+
+// NewRateLimiter allows perMinute requests a minute with bursts of up to burst
+// requests. A perMinute of 0 disables the limit.
+func NewRateLimiter(perMinute int, burst int) *RateLimiter {
+	return &RateLimiter{
+		limit:   rate.Limit(float64(perMinute) / 60),
+		burst:   max(burst, 1),
+		buckets: map[string]*rate.Limiter{},
+	}
+}
+
+// Limit rejects callers that used up their bucket with 429 and a Retry-After
+// header saying when the next request is allowed
+func (l *RateLimiter) Limit(c *fiber.Ctx) error {
+	if l.limit <= 0 {
+		return c.Next()
+	}
+
+	reservation := l.bucket(rateLimitKey(c)).Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": fmt.Sprintf("Rate limit exceeded, retry in %s", delay.Round(time.Second)),
+		})
+	}
+	return c.Next()
+}
+
+func (l *RateLimiter) bucket(key string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := time.Now(); !now.Before(l.nextSweep) {
+		l.sweep(now)
+		l.nextSweep = now.Add(rateLimitSweepInterval)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = rate.NewLimiter(l.limit, l.burst)
+		l.buckets[key] = bucket
+	}
+	return bucket
+}
+
+// sweep drops the buckets that have refilled completely. A full bucket
+// behaves like a new one, so only callers that stopped sending are forgotten.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		if bucket.TokensAt(now) >= float64(l.burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// rateLimitKey names the caller's bucket
+func rateLimitKey(c *fiber.Ctx) string {
+	p := principal(c)
+	if p == nil {
+		return "ip:" + c.IP()
+	}
+	if p.APIKeyID != 0 {
+		return fmt.Sprintf("key:%d", p.APIKeyID)
+	}
+	return fmt.Sprintf("user:%d", p.UserID)
+}
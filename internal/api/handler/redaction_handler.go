@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RedactionHandler struct {
+	service *service.RedactionService
+}
+
+func NewRedactionHandler(service *service.RedactionService) *RedactionHandler {
+	return &RedactionHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/documents/:id/redactions
+// Lists the masked values hidden from the AI provider when the document's
+// SRS was last generated.
+func (h *RedactionHandler) GetReport(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid document ID",
+		})
+	}
+
+	report, err := h.service.GetReport(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": report,
+	})
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RequirementHandler struct {
+	requirements *service.RequirementService
+	issues       *service.IssueService
+}
+
+func NewRequirementHandler(requirements *service.RequirementService, issues *service.IssueService) *RequirementHandler {
+	return &RequirementHandler{requirements: requirements, issues: issues}
+}
+
+type PushIssuesRequest struct {
+	Types []string `json:"types"`
+	Codes []string `json:"codes"`
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/requirements
+func (h *RequirementHandler) GetBySRS(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	requirements, err := h.requirements.GetRequirements(uint(id))
+	if err != nil {
+		if errors.Is(err, service.ErrSRSNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": requirements,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/issues/export?format=jira|github&type=FUNCTIONAL,...|all&codes=FR-001,...
+func (h *RequirementHandler) ExportIssues(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	filter := issueFilter(splitList(c.Query("type")), splitList(c.Query("codes")))
+	result, err := h.issues.ExportIssues(uint(id), c.Query("format", "jira"), filter)
+	if err != nil {
+		return issueError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, result.Filename))
+	return c.Send(result.Data)
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/issues/push
+func (h *RequirementHandler) PushIssues(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	var req PushIssuesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	report, err := h.issues.PushIssues(uint(id), issueFilter(req.Types, req.Codes))
+	if err != nil {
+		return issueError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": fmt.Sprintf("%d issues created, %d skipped, %d failed",
+			len(report.Created), len(report.Skipped), len(report.Failed)),
+		"data": report,
+	})
+}
+
+// issueFilter defaults to functional requirements; "all" selects every type
+func issueFilter(types []string, codes []string) service.IssueFilter {
+	filter := service.IssueFilter{Codes: codes}
+	if len(types) == 0 {
+		filter.Types = []domain.RequirementType{domain.RequirementFunctional}
+	}
+	for _, t := range types {
+		if strings.EqualFold(t, "all") {
+			filter.Types = nil
+			break
+		}
+		filter.Types = append(filter.Types, domain.RequirementType(strings.ToUpper(t)))
+	}
+	return filter
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func issueError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSRSNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrUnsupportedFormat):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrIssueTrackerNotConfigured):
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RetentionHandler struct {
+	service *service.RetentionService
+}
+
+func NewRetentionHandler(service *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/retention/report
+// Shows what the sweeper would purge without deleting anything.
+func (h *RetentionHandler) Report(c *fiber.Ctx) error {
+	report, err := h.service.Sweep(true)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": report,
+	})
+}
+
+// Endpoint: POST /api/v1/retention/sweep
+func (h *RetentionHandler) Sweep(c *fiber.Ctx) error {
+	report, err := h.service.Sweep(false)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Retention sweep completed",
+		"data":    report,
+	})
+}
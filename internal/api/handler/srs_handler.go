@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"srs-automation/internal/core/domain"
 	"srs-automation/internal/core/service"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,6 +19,7 @@ func NewSRSHandler(service *service.SRSService) *SRSHandler {
 type GenerateSRSRequest struct {
 	DocumentID uint   `json:"document_id"`
 	Title      string `json:"title"`
+	Author     string `json:"author"`
 }
 
 func (h *SRSHandler) Generate(c *fiber.Ctx) error {
@@ -27,8 +30,23 @@ func (h *SRSHandler) Generate(c *fiber.Ctx) error {
 		})
 	}
 
-	srs, err := h.service.GenerateSRS(req.DocumentID, req.Title)
+	srs, err := h.service.GenerateSRS(auditActor(c), projectID(c), req.DocumentID, req.Title, req.Author)
 	if err != nil {
+		if errors.Is(err, service.ErrNotInProject) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, service.ErrAIQuotaExceeded) || errors.Is(err, service.ErrAIBudgetExceeded) {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, service.ErrInvalidSRSOutput) {
+			return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -80,22 +98,47 @@ func (h *SRSHandler) GetByDocument(c *fiber.Ctx) error {
 	})
 }
 
+// Endpoint: GET /api/v1/projects/:projectId/srs?status=&author=&q=&from=&to=&sort=&limit=&offset=&cursor=
+// Endpoint: GET /api/v1/srs?project_id= (same filters, across projects)
 func (h *SRSHandler) GetAll(c *fiber.Ctx) error {
-	srsList, err := h.service.GetAllSRS()
+	filter := domain.SRSFilter{
+		ProjectID: listProjectID(c),
+		Status:    c.Query("status"),
+		Author:    c.Query("author"),
+		Search:    c.Query("q"),
+		Page:      pageRequest(c),
+	}
+	from, to, err := createdRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+	filter.From, filter.To = from, to
+
+	srsList, page, err := h.service.ListSRS(filter)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidListQuery) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	return c.JSON(fiber.Map{
-		"data": srsList,
+		"data":       srsList,
+		"pagination": page,
 	})
 }
 
 type UpdateSRSRequest struct {
-	Content string `json:"content"`
-	Status  string `json:"status"`
+	Content    string `json:"content"`
+	Status     string `json:"status"`
+	Author     string `json:"author"`
+	ApprovedBy string `json:"approved_by"`
 }
 
 func (h *SRSHandler) Update(c *fiber.Ctx) error {
@@ -113,7 +156,22 @@ func (h *SRSHandler) Update(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.UpdateSRS(uint(id), req.Content, req.Status); err != nil {
+	// Changing the status is a review decision; editing content only needs write access
+	if req.Status != "" && !can(c, domain.PermissionReview) {
+		return authError(c, service.ErrForbidden)
+	}
+	if req.ApprovedBy == "" && principal(c) != nil {
+		req.ApprovedBy = principal(c).Name
+	}
+
+	input := service.UpdateSRSInput{
+		Content:    req.Content,
+		Status:     req.Status,
+		Author:     req.Author,
+		ApprovedBy: req.ApprovedBy,
+	}
+
+	if err := h.service.UpdateSRS(auditActor(c), uint(id), input); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -132,7 +190,7 @@ func (h *SRSHandler) Delete(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.DeleteSRS(uint(id)); err != nil {
+	if err := h.service.DeleteSRS(auditActor(c), uint(id)); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -142,3 +200,23 @@ func (h *SRSHandler) Delete(c *fiber.Ctx) error {
 		"message": "SRS deleted successfully",
 	})
 }
+
+func (h *SRSHandler) GetRevisions(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	revisions, err := h.service.GetRevisions(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": revisions,
+	})
+}
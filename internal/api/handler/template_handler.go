@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"srs-automation/internal/core/service"
+	"srs-automation/internal/infra/render"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TemplateHandler struct {
+	service *service.TemplateService
+}
+
+func NewTemplateHandler(service *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func (h *TemplateHandler) Upload(c *fiber.Ctx) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File is required",
+		})
+	}
+
+	fileContent, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to read file",
+		})
+	}
+	defer fileContent.Close()
+
+	fileData, err := io.ReadAll(fileContent)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to read file content",
+		})
+	}
+
+	isDefault := c.FormValue("is_default") == "true"
+
+	tmpl, err := h.service.UploadTemplate(projectID(c), c.FormValue("name"), file.Filename, fileData, isDefault)
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedTemplate) || errors.Is(err, render.ErrInvalidTemplate) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Template uploaded successfully",
+		"data":    tmpl,
+	})
+}
+
+func (h *TemplateHandler) GetAll(c *fiber.Ctx) error {
+	templates, err := h.service.GetTemplatesByProject(projectID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": templates,
+	})
+}
+
+func (h *TemplateHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	tmpl, err := h.service.GetTemplate(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Template not found",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": tmpl,
+	})
+}
+
+func (h *TemplateHandler) SetDefault(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	if err := h.service.SetDefault(uint(id)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Default template updated successfully",
+	})
+}
+
+func (h *TemplateHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid template ID",
+		})
+	}
+
+	if err := h.service.DeleteTemplate(uint(id)); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Template deleted successfully",
+	})
+}
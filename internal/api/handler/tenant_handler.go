@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TenantHandler struct {
+	service *service.TenantService
+}
+
+func NewTenantHandler(service *service.TenantService) *TenantHandler {
+	return &TenantHandler{service: service}
+}
+
+// Operator rejects callers who aren't admins of an operator tenant
+func (h *TenantHandler) Operator(c *fiber.Ctx) error {
+	p := principal(c)
+	if p == nil || !p.Can(domain.PermissionAdmin) || !h.service.IsOperator(p.TenantID) {
+		return authError(c, service.ErrForbidden)
+	}
+	return c.Next()
+}
+
+// Endpoint: GET /api/v1/tenant
+func (h *TenantHandler) Current(c *fiber.Ctx) error {
+	tenant, err := h.service.GetTenant(principal(c).TenantID)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": tenant,
+	})
+}
+
+// Endpoint: GET /api/v1/tenant/ai-usage
+func (h *TenantHandler) CurrentUsage(c *fiber.Ctx) error {
+	usage, err := h.service.GetUsage(principal(c).TenantID)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": usage,
+	})
+}
+
+// Endpoint: POST /api/v1/tenants
+func (h *TenantHandler) Create(c *fiber.Ctx) error {
+	var req service.TenantInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tenant, admin, err := h.service.CreateTenant(req)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Tenant created successfully",
+		"data": fiber.Map{
+			"tenant": tenant,
+			"admin":  admin,
+		},
+	})
+}
+
+// Endpoint: GET /api/v1/tenants
+func (h *TenantHandler) GetAll(c *fiber.Ctx) error {
+	tenants, err := h.service.GetTenants()
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": tenants,
+	})
+}
+
+// Endpoint: GET /api/v1/tenants/:tenantId
+func (h *TenantHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	tenant, err := h.service.GetTenant(uint(id))
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": tenant,
+	})
+}
+
+// Endpoint: PUT /api/v1/tenants/:tenantId
+func (h *TenantHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var req service.TenantUpdate
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	tenant, err := h.service.UpdateTenant(uint(id), req)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Tenant updated successfully",
+		"data":    tenant,
+	})
+}
+
+// Endpoint: GET /api/v1/tenants/:tenantId/ai-credentials
+func (h *TenantHandler) GetCredentials(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	credentials, err := h.service.GetCredentials(uint(id))
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": credentials,
+	})
+}
+
+// Endpoint: PUT /api/v1/tenants/:tenantId/ai-credentials/:provider
+func (h *TenantHandler) SetCredential(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	var req service.AICredentialInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	credential, err := h.service.SetCredential(uint(id), c.Params("provider"), req)
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "AI credential saved successfully",
+		"data":    credential,
+	})
+}
+
+// Endpoint: DELETE /api/v1/tenants/:tenantId/ai-credentials/:provider
+func (h *TenantHandler) DeleteCredential(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	if err := h.service.DeleteCredential(uint(id), c.Params("provider")); err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "AI credential deleted successfully",
+	})
+}
+
+// Endpoint: GET /api/v1/tenants/:tenantId/ai-usage
+func (h *TenantHandler) GetUsage(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("tenantId")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid tenant ID",
+		})
+	}
+
+	usage, err := h.service.GetUsage(uint(id))
+	if err != nil {
+		return tenantError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": usage,
+	})
+}
+
+// TenantOf returns the tenant of the caller resolved by AuthHandler.Authenticate
+func TenantOf(c *fiber.Ctx) (uint, bool) {
+	p := principal(c)
+	if p == nil {
+		return 0, false
+	}
+	return p.TenantID, true
+}
+
+func tenantError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrTenantNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidTenant), errors.Is(err, service.ErrInvalidCredential),
+		errors.Is(err, service.ErrInvalidUser):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrDuplicateTenant), errors.Is(err, service.ErrDuplicateUser):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UseCaseHandler struct {
+	service *service.UseCaseService
+}
+
+func NewUseCaseHandler(service *service.UseCaseService) *UseCaseHandler {
+	return &UseCaseHandler{service: service}
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/use-cases
+func (h *UseCaseHandler) Extract(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	model, err := h.service.ExtractModel(uint(id))
+	if err != nil {
+		return useCaseError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Use case model extracted successfully",
+		"data":    model,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/use-cases
+func (h *UseCaseHandler) GetModel(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	model, err := h.service.GetModel(uint(id))
+	if err != nil {
+		return useCaseError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": model,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/diagrams?format=mermaid|plantuml&kind=usecase|sequence|er
+func (h *UseCaseHandler) GetDiagrams(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	diagrams, err := h.service.GetDiagrams(uint(id), c.Query("format"), c.Query("kind"))
+	if err != nil {
+		return useCaseError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": diagrams,
+	})
+}
+
+func useCaseError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSRSNotFound), errors.Is(err, service.ErrUseCaseModelNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIQuotaExceeded), errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidAIResponse):
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
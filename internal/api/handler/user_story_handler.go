@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserStoryHandler struct {
+	service *service.UserStoryService
+}
+
+func NewUserStoryHandler(service *service.UserStoryService) *UserStoryHandler {
+	return &UserStoryHandler{service: service}
+}
+
+type GenerateUserStoriesRequest struct {
+	Codes []string `json:"codes"`
+}
+
+// Endpoint: POST /api/v1/projects/:projectId/srs/:id/user-stories
+func (h *UserStoryHandler) Generate(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	var req GenerateUserStoriesRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid request body",
+			})
+		}
+	}
+
+	stories, err := h.service.GenerateStories(uint(id), req.Codes)
+	if err != nil {
+		return userStoryError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": fmt.Sprintf("%d user stories generated", len(stories)),
+		"data":    stories,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/user-stories
+func (h *UserStoryHandler) GetBySRS(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	stories, err := h.service.GetStories(uint(id))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data": stories,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/srs/:id/user-stories/features
+func (h *UserStoryHandler) ExportFeatures(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid SRS ID",
+		})
+	}
+
+	result, err := h.service.ExportFeatures(uint(id))
+	if err != nil {
+		return userStoryError(c, err)
+	}
+
+	return sendAttachment(c, result)
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/user-stories/:id
+func (h *UserStoryHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user story ID",
+		})
+	}
+
+	story, err := h.service.GetStory(uint(id))
+	if err != nil {
+		return userStoryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": story,
+	})
+}
+
+// Endpoint: GET /api/v1/projects/:projectId/user-stories/:id/feature
+func (h *UserStoryHandler) ExportFeature(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user story ID",
+		})
+	}
+
+	result, err := h.service.ExportFeature(uint(id))
+	if err != nil {
+		return userStoryError(c, err)
+	}
+
+	return sendAttachment(c, result)
+}
+
+// Endpoint: DELETE /api/v1/projects/:projectId/user-stories/:id
+func (h *UserStoryHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid user story ID",
+		})
+	}
+
+	if err := h.service.DeleteStory(uint(id)); err != nil {
+		return userStoryError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "User story deleted successfully",
+	})
+}
+
+func sendAttachment(c *fiber.Ctx, result *service.ExportResult) error {
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, result.Filename))
+	return c.Send(result.Data)
+}
+
+func userStoryError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrSRSNotFound), errors.Is(err, service.ErrUserStoryNotFound),
+		errors.Is(err, service.ErrNoUserStories):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrNoRequirements):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrAIQuotaExceeded), errors.Is(err, service.ErrAIBudgetExceeded):
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidAIResponse):
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"srs-automation/internal/core/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	service *service.WebhookService
+}
+
+func NewWebhookHandler(service *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// Endpoint: GET /api/v1/webhooks
+func (h *WebhookHandler) GetAll(c *fiber.Ctx) error {
+	webhooks, err := h.service.GetWebhooks()
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": webhooks,
+	})
+}
+
+// Endpoint: POST /api/v1/webhooks
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	var req service.WebhookInput
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	created, err := h.service.CreateWebhook(req)
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Webhook created; store the secret now, it will not be shown again",
+		"data":    created,
+	})
+}
+
+// Endpoint: GET /api/v1/webhooks/:id
+func (h *WebhookHandler) GetByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	webhook, err := h.service.GetWebhook(uint(id))
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": webhook,
+	})
+}
+
+// Endpoint: PUT /api/v1/webhooks/:id
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	var req service.WebhookUpdate
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	webhook, err := h.service.UpdateWebhook(uint(id), req)
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Webhook updated successfully",
+		"data":    webhook,
+	})
+}
+
+// Endpoint: DELETE /api/v1/webhooks/:id
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	if err := h.service.DeleteWebhook(uint(id)); err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// Endpoint: GET /api/v1/webhooks/:id/deliveries?limit=
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	deliveries, err := h.service.GetDeliveries(uint(id), c.QueryInt("limit"))
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"data": deliveries,
+	})
+}
+
+// Endpoint: POST /api/v1/webhooks/:id/ping
+func (h *WebhookHandler) Ping(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid webhook ID",
+		})
+	}
+
+	delivery, err := h.service.Ping(uint(id))
+	if err != nil {
+		return webhookError(c, err)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Ping queued; check the delivery log for the result",
+		"data":    delivery,
+	})
+}
+
+func webhookError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrWebhookNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidWebhook):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+}
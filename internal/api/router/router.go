@@ -1,55 +1,174 @@
 package router
 
 import (
+	"log"
+	"os"
 	"srs-automation/internal/api/handler"
+	"srs-automation/internal/core/domain"
 	"srs-automation/internal/core/ports"
 	"srs-automation/internal/core/service"
 	"srs-automation/internal/infra/external"
 	"srs-automation/internal/infra/repository"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func SetupRoutes(app *fiber.App, db *gorm.DB, aiClient ports.AIService) {
-	// Initialize repositories
+// SetupRoutes wires the API. aiProviders maps provider names (e.g. "groq")
+// to the server-wide clients; projects without an AI provider setting use
+// defaultProvider, and tenants may replace a client with their own key.
+func SetupRoutes(app *fiber.App, db *gorm.DB, aiFactory ports.AIClientFactory, aiProviders map[string]ports.AIService, defaultProvider string) {
+	// Initialize repositories; these see every tenant and only serve
+	// sign-in, signed downloads, tenant management and background jobs
 	docRepo := repository.NewDocumentRepository(db)
 	srsRepo := repository.NewSRSRepository(db)
+	accessLogRepo := repository.NewFileAccessLogRepository(db)
+	templateRepo := repository.NewDocxTemplateRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	tenantRepo := repository.NewTenantRepository(db)
+	credentialRepo := repository.NewTenantAICredentialRepository(db)
+	usageRepo := repository.NewTenantAIUsageRepository(db)
+	deliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	aiCacheRepo := repository.NewAICacheRepository(db)
 
 	// Initialize external services
 	fileStorage := external.NewFileStorage()
+	urlSigner := external.NewURLSigner()
+	secretCipher := external.NewSecretCipher()
+	tokenIssuer, err := external.NewJWTIssuer()
+	if err != nil {
+		log.Fatal("Invalid JWT configuration:", err)
+	}
 
 	// Initialize services
-	docService := service.NewDocumentService(docRepo, aiClient, fileStorage)
-	srsService := service.NewSRSService(srsRepo, docRepo, aiClient)
+	downloadService := service.NewDownloadService(docRepo, accessLogRepo, urlSigner, downloadLinkTTL())
+	authService := service.NewAuthService(userRepo, apiKeyRepo, userRepo, tokenIssuer, tokenTTL())
+	tenantService := service.NewTenantService(tenantRepo, credentialRepo, usageRepo, projectRepo, authService, secretCipher, aiFactory)
 
-	// Initialize handlers
-	docHandler := handler.NewDocumentHandler(docService)
-	srsHandler := handler.NewSRSHandler(srsService)
+	defaultTenant, err := tenantRepo.FindBySlug(domain.DefaultTenantSlug)
+	if err != nil {
+		log.Fatal("Default tenant is missing:", err)
+	}
+	if user, err := authService.Bootstrap(defaultTenant.ID, os.Getenv("AUTH_ADMIN_EMAIL"), os.Getenv("AUTH_ADMIN_PASSWORD")); err != nil {
+		log.Fatal("Failed to create the initial admin user:", err)
+	} else if user != nil {
+		log.Printf("Created initial admin user %s", user.Email)
+	}
+
+	retentionPolicies, err := service.ParseRetentionPolicies(os.Getenv("RETENTION_POLICIES"))
+	if err != nil {
+		log.Fatal("Invalid RETENTION_POLICIES:", err)
+	}
+	// The background sweeper covers the files of every tenant
+	retentionService := service.NewRetentionService(docRepo, srsRepo, templateRepo, fileStorage, retentionPolicies)
+	if interval := retentionSweepInterval(); interval > 0 {
+		go retentionService.RunSweeper(interval)
+	}
+
+	// The dispatcher sends the webhook deliveries of every tenant
+	webhookDispatcher := service.NewWebhookDispatcher(deliveryRepo, external.NewWebhookSender(), secretCipher, webhookMaxAttempts(), webhookRetryBase())
+	go webhookDispatcher.Run(webhookPollInterval)
+
+	// Email notifications are only queued when SMTP is configured
+	var notificationDispatcher *service.NotificationDispatcher
+	if mailer := external.NewMailer(); mailer != nil {
+		notificationDispatcher = service.NewNotificationDispatcher(notificationRepo, mailer, notificationDigestInterval())
+		go notificationDispatcher.Run(notificationPollInterval)
+	} else {
+		log.Println("SMTP_HOST is not set, email notifications are disabled")
+	}
+
+	aiPrices, err := service.ParseAIPrices(os.Getenv("AI_PRICES"))
+	if err != nil {
+		log.Fatal("Invalid AI_PRICES:", err)
+	}
+
+	aiProviderLimits, err := service.ParseAIProviderLimits(os.Getenv("AI_PROVIDER_LIMITS"))
+	if err != nil {
+		log.Fatal("Invalid AI_PROVIDER_LIMITS:", err)
+	}
+	aiLimiter := service.NewAILimiter(service.AILimits{
+		RequestsPerMinute: envInt("AI_RATE_LIMIT", 0),
+		Providers:         aiProviderLimits,
+		MaxConcurrent:     envInt("AI_MAX_CONCURRENCY", 4),
+	})
+
+	redactionRules, err := service.ParseRedactionRules(os.Getenv("REDACTION_RULES"))
+	if err != nil {
+		log.Fatal("Invalid REDACTION_RULES:", err)
+	}
+
+	// Expired AI responses of every tenant are purged in the background
+	cacheTTL := aiCacheTTL()
+	go service.NewAICache(aiCacheRepo, nil, 0, cacheTTL).RunPurger(aiCachePurgeInterval)
+	var aiMemory *service.AIResponseLRU
+	if size := envInt("AI_CACHE_LRU_SIZE", 128); size > 0 && cacheTTL > 0 {
+		aiMemory = service.NewAIResponseLRU(size)
+	}
+
+	tenants := newTenantRouter(&sharedDeps{
+		db:              db,
+		fileStorage:     fileStorage,
+		urlSigner:       urlSigner,
+		tokens:          tokenIssuer,
+		directory:       userRepo,
+		tenants:         tenantService,
+		aiProviders:     aiProviders,
+		defaultProvider: defaultProvider,
+		retention:       retentionPolicies,
+		cipher:          secretCipher,
+		progress:        external.NewProgressBus(),
+		webhooks:        webhookDispatcher,
+		notifications:   notificationDispatcher,
+		aiCacheTTL:      cacheTTL,
+		aiMemory:        aiMemory,
+		aiPrices:        aiPrices,
+		aiLimiter:       aiLimiter,
+		rateLimiter:     handler.NewRateLimiter(envInt("API_RATE_LIMIT", 30), envInt("API_RATE_BURST", 5)),
+		redactionRules:  redactionRules,
+	})
 
-	app.Static("/uploads", "./uploads")
+	// Initialize handlers
+	downloadHandler := handler.NewDownloadHandler(downloadService)
+	tenantHandler := handler.NewTenantHandler(tenantService)
+	authHandler := handler.NewAuthHandler(authService)
 
 	// API routes
 	api := app.Group("/api/v1")
 
-	// Document routes
-	documents := api.Group("/documents")
-	documents.Post("/", docHandler.Upload)
-	documents.Get("/", docHandler.GetAll)
-	documents.Get("/:id", docHandler.GetByID)
-	documents.Post("/:id/process", docHandler.Process)
-	documents.Delete("/:id", docHandler.Delete)
-
-	documents.Get("/:id/download", docHandler.DownloadResult)
-
-	// SRS routes
-	srs := api.Group("/srs")
-	srs.Post("/", srsHandler.Generate)
-	srs.Get("/", srsHandler.GetAll)
-	srs.Get("/:id", srsHandler.GetByID)
-	srs.Get("/document/:documentId", srsHandler.GetByDocument)
-	srs.Put("/:id", srsHandler.Update)
-	srs.Delete("/:id", srsHandler.Delete)
+	// Public routes: login, and signed file downloads (uploads and outputs are
+	// never served statically; the signature authorizes the download)
+	api.Post("/auth/login", authHandler.Login)
+	api.Get("/downloads/:id/:kind", downloadHandler.Download)
+
+	// Every other API route needs a JWT or an API key
+	api.Use(authHandler.Authenticate)
+	read := handler.Require(domain.PermissionRead)
+
+	api.Get("/auth/me", authHandler.Me)
+
+	// The caller's own tenant
+	api.Get("/tenant", read, tenantHandler.Current)
+	api.Get("/tenant/ai-usage", read, tenantHandler.CurrentUsage)
+
+	// Tenant management, for admins of the operator tenant
+	tenantAdmin := api.Group("/tenants", tenantHandler.Operator)
+	tenantAdmin.Get("/", tenantHandler.GetAll)
+	tenantAdmin.Post("/", tenantHandler.Create)
+	tenantAdmin.Get("/:tenantId", tenantHandler.GetByID)
+	tenantAdmin.Put("/:tenantId", tenantHandler.Update)
+	tenantAdmin.Get("/:tenantId/ai-credentials", tenantHandler.GetCredentials)
+	tenantAdmin.Put("/:tenantId/ai-credentials/:provider", tenantHandler.SetCredential)
+	tenantAdmin.Delete("/:tenantId/ai-credentials/:provider", tenantHandler.DeleteCredential)
+	tenantAdmin.Get("/:tenantId/ai-usage", tenantHandler.GetUsage)
+
+	// Everything else is served by the routes of the caller's tenant (see tenant.go)
+	api.Use(tenants.Dispatch)
 
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -58,3 +177,109 @@ func SetupRoutes(app *fiber.App, db *gorm.DB, aiClient ports.AIService) {
 		})
 	})
 }
+
+// downloadLinkTTL reads DOWNLOAD_LINK_TTL (e.g. "15m"), defaulting to 15 minutes
+func downloadLinkTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("DOWNLOAD_LINK_TTL"))
+	if err != nil || ttl <= 0 {
+		return 15 * time.Minute
+	}
+	return ttl
+}
+
+// tokenTTL reads JWT_TTL (e.g. "8h"), defaulting to 8 hours
+func tokenTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("JWT_TTL"))
+	if err != nil || ttl <= 0 {
+		return 8 * time.Hour
+	}
+	return ttl
+}
+
+// retentionSweepInterval reads RETENTION_SWEEP_INTERVAL, defaulting to 24 hours.
+// A value of "0" disables the background sweeper.
+func retentionSweepInterval() time.Duration {
+	raw := os.Getenv("RETENTION_SWEEP_INTERVAL")
+	if raw == "" {
+		return 24 * time.Hour
+	}
+
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid RETENTION_SWEEP_INTERVAL %q, using 24h", raw)
+		return 24 * time.Hour
+	}
+	return interval
+}
+
+// webhookPollInterval is how often the dispatcher looks for due retries;
+// new events wake it immediately
+const webhookPollInterval = 5 * time.Second
+
+// webhookMaxAttempts reads WEBHOOK_MAX_ATTEMPTS, defaulting to 6
+func webhookMaxAttempts() int {
+	attempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil || attempts <= 0 {
+		return 6
+	}
+	return attempts
+}
+
+// webhookRetryBase reads WEBHOOK_RETRY_BASE (e.g. "30s"), the wait before the
+// first retry, which doubles on every further retry. Defaults to 30 seconds.
+func webhookRetryBase() time.Duration {
+	base, err := time.ParseDuration(os.Getenv("WEBHOOK_RETRY_BASE"))
+	if err != nil || base <= 0 {
+		return 30 * time.Second
+	}
+	return base
+}
+
+// notificationPollInterval is how often failed immediate emails are retried;
+// new notifications wake the dispatcher immediately
+const notificationPollInterval = 30 * time.Second
+
+// notificationDigestInterval reads NOTIFY_DIGEST_INTERVAL (e.g. "24h"), how
+// often digest-mode users get their collected notifications. Defaults to 24 hours.
+func notificationDigestInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("NOTIFY_DIGEST_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 24 * time.Hour
+	}
+	return interval
+}
+
+// aiCachePurgeInterval is how often expired AI responses are deleted
+const aiCachePurgeInterval = time.Hour
+
+// aiCacheTTL reads AI_CACHE_TTL (e.g. "168h"), how long AI responses are
+// reused, defaulting to 7 days. A value of "0" disables the cache.
+func aiCacheTTL() time.Duration {
+	raw := os.Getenv("AI_CACHE_TTL")
+	if raw == "" {
+		return 7 * 24 * time.Hour
+	}
+
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl < 0 {
+		log.Printf("Invalid AI_CACHE_TTL %q, using 168h", raw)
+		return 7 * 24 * time.Hour
+	}
+	return ttl
+}
+
+// envInt reads a non-negative integer setting, using fallback when it is
+// unset or invalid. A value of "0" disables the limits read with it.
+func envInt(name string, fallback int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 0 {
+		log.Printf("Invalid %s %q, using %d", name, raw, fallback)
+		return fallback
+	}
+	return n
+}
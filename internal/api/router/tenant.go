@@ -0,0 +1,370 @@
+package router
+
+import (
+	"log"
+	"srs-automation/internal/api/handler"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"srs-automation/internal/core/service"
+	"srs-automation/internal/infra/database"
+	"srs-automation/internal/infra/external"
+	"srs-automation/internal/infra/render"
+	"srs-automation/internal/infra/repository"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"gorm.io/gorm"
+)
+
+// sharedDeps are the clients every tenant's routes are built from
+type sharedDeps struct {
+	db              *gorm.DB
+	fileStorage     *external.FileStorage
+	urlSigner       ports.URLSigner
+	tokens          ports.TokenIssuer
+	directory       ports.UserRepository
+	tenants         *service.TenantService
+	aiProviders     map[string]ports.AIService
+	defaultProvider string
+	retention       []domain.RetentionPolicy
+	cipher          ports.SecretCipher
+	// These outlive the per-tenant apps, which are rebuilt when settings change
+	progress ports.ProgressBus
+	webhooks *service.WebhookDispatcher
+	// nil when email is not configured
+	notifications *service.NotificationDispatcher
+	// AI responses are cached for aiCacheTTL (0 disables the cache); aiMemory
+	// is the in-memory part shared by all tenants, nil when disabled
+	aiCacheTTL time.Duration
+	aiMemory   *service.AIResponseLRU
+	// USD per million tokens, keyed by "provider/model"
+	aiPrices map[string]domain.AIPrice
+	// Pace the AI calls and the upload and generation requests of all tenants
+	aiLimiter   *service.AILimiter
+	rateLimiter *handler.RateLimiter
+	// Built-in rules hiding sensitive values from AI providers
+	redactionRules []domain.RedactionKind
+}
+
+// tenantRouter serves the tenant-scoped API. Each tenant gets its own route
+// table built on a database session and file storage limited to that tenant,
+// so a handler can't reach another tenant's rows or files even by ID.
+type tenantRouter struct {
+	deps *sharedDeps
+
+	mu       sync.Mutex
+	handlers map[uint]fasthttp.RequestHandler
+}
+
+func newTenantRouter(deps *sharedDeps) *tenantRouter {
+	r := &tenantRouter{deps: deps, handlers: map[uint]fasthttp.RequestHandler{}}
+	// Rebuild a tenant's routes when its AI credentials or quota change
+	deps.tenants.OnChange(r.forget)
+	return r
+}
+
+// Dispatch hands an authenticated request to the routes of the caller's tenant
+func (r *tenantRouter) Dispatch(c *fiber.Ctx) error {
+	tenantID, ok := handler.TenantOf(c)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": service.ErrUnauthenticated.Error(),
+		})
+	}
+
+	serve, err := r.handler(tenantID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// The tenant app writes the response; locals such as the principal are
+	// shared because both apps serve the same request context
+	serve(c.Context())
+	return nil
+}
+
+func (r *tenantRouter) handler(tenantID uint) (fasthttp.RequestHandler, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if serve, ok := r.handlers[tenantID]; ok {
+		return serve, nil
+	}
+
+	tenant, err := r.deps.tenants.GetTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	aiProviders, err := r.deps.tenants.AIProviders(tenant.ID, r.deps.aiProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	app := fiber.New()
+	setupTenantRoutes(app, r.deps, tenant, aiProviders)
+	serve := app.Handler()
+	r.handlers[tenantID] = serve
+	return serve, nil
+}
+
+func (r *tenantRouter) forget(tenantID uint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.handlers, tenantID)
+}
+
+// setupTenantRoutes wires the routes whose data belongs to one tenant
+func setupTenantRoutes(app *fiber.App, deps *sharedDeps, tenant *domain.Tenant, aiProviders map[string]ports.AIService) {
+	db := database.TenantDB(deps.db, tenant.ID)
+
+	// Initialize repositories
+	docRepo := repository.NewDocumentRepository(db)
+	srsRepo := repository.NewSRSRepository(db)
+	accessLogRepo := repository.NewFileAccessLogRepository(db)
+	templateRepo := repository.NewDocxTemplateRepository(db)
+	revisionRepo := repository.NewSRSRevisionRepository(db)
+	requirementRepo := repository.NewRequirementRepository(db)
+	userStoryRepo := repository.NewUserStoryRepository(db)
+	useCaseModelRepo := repository.NewUseCaseModelRepository(db)
+	apiSpecRepo := repository.NewAPISpecRepository(db)
+	dataEntityRepo := repository.NewDataEntityRepository(db)
+	glossaryRepo := repository.NewGlossaryRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	auditRepo := repository.NewAuditEventRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	deliveryRepo := repository.NewWebhookDeliveryRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	preferenceRepo := repository.NewNotificationPreferenceRepository(db)
+	aiCacheRepo := repository.NewAICacheRepository(db)
+	aiUsageRepo := repository.NewAIUsageRepository(db)
+	redactionRepo := repository.NewRedactionReportRepository(db)
+
+	// Initialize external services
+	fileStorage := deps.fileStorage.ForTenant(tenant.Slug)
+	docxExporter := render.NewDocxExporter()
+	exporters := []ports.Exporter{
+		docxExporter,
+		render.NewPDFExporter(),
+		render.NewMarkdownExporter(),
+		render.NewHTMLExporter(),
+		render.NewJSONExporter(),
+		render.NewReqIFExporter(),
+	}
+
+	issueExporters := []ports.IssueExporter{
+		render.NewJiraCSVExporter(),
+		render.NewGitHubIssuesExporter(),
+	}
+	issueTracker := external.NewIssueTrackerClient()
+
+	diagramRenderers := []ports.DiagramRenderer{
+		render.NewMermaidRenderer(),
+		render.NewPlantUMLRenderer(),
+	}
+
+	// Initialize services
+	auditService := service.NewAuditService(auditRepo)
+	webhookService := service.NewWebhookService(webhookRepo, deliveryRepo, projectRepo, deps.cipher, deps.webhooks)
+	notificationService := service.NewNotificationService(notificationRepo, preferenceRepo, userRepo, projectRepo, deps.notifications)
+	// The cache sits in front of the quota, so cached answers are free
+	aiCache := service.NewAICache(aiCacheRepo, deps.aiMemory, tenant.ID, deps.aiCacheTTL)
+	aiUsageService := service.NewAIUsageService(aiUsageRepo, projectRepo, deps.aiPrices)
+	redactionService := service.NewRedactionService(redactionRepo, deps.redactionRules)
+	aiResolver := service.NewAIResolver(projectRepo, aiCache.Wrap(service.GuardSRSOutput(deps.aiLimiter.Wrap(aiProviders))), deps.defaultProvider, aiUsageService, redactionService)
+	glossaryService := service.NewGlossaryService(glossaryRepo, docRepo, srsRepo, aiResolver)
+	templateService := service.NewTemplateService(templateRepo, fileStorage, docxExporter)
+	projectService := service.NewProjectService(projectRepo, docRepo, srsRepo, templateRepo, glossaryRepo, userStoryRepo, dataEntityRepo, templateService, glossaryService, aiResolver)
+	docService := service.NewDocumentService(docRepo, srsRepo, aiResolver, fileStorage, projectService, auditService, deps.progress, webhookService, notificationService, docxExporter)
+	requirementService := service.NewRequirementService(requirementRepo, srsRepo)
+	dataDictionaryService := service.NewDataDictionaryService(dataEntityRepo, srsRepo, aiResolver)
+	srsService := service.NewSRSService(srsRepo, revisionRepo, docRepo, aiResolver, requirementService, projectService, auditService, webhookService, notificationService, dataDictionaryService)
+	issueService := service.NewIssueService(srsRepo, requirementService, issueTracker, issueExporters...)
+	userStoryService := service.NewUserStoryService(userStoryRepo, srsRepo, requirementService, aiResolver, render.NewGherkinWriter(), projectService)
+	useCaseService := service.NewUseCaseService(useCaseModelRepo, srsRepo, aiResolver, diagramRenderers...)
+	apiSpecService := service.NewAPISpecService(apiSpecRepo, srsRepo, useCaseModelRepo, requirementService, aiResolver, render.NewOpenAPIRenderer())
+	exportService := service.NewExportService(srsRepo, revisionRepo, useCaseModelRepo, dataEntityRepo, templateService, exporters...)
+	downloadService := service.NewDownloadService(docRepo, accessLogRepo, deps.urlSigner, downloadLinkTTL())
+	authService := service.NewAuthService(userRepo, apiKeyRepo, deps.directory, deps.tokens, tokenTTL())
+	retentionService := service.NewRetentionService(docRepo, srsRepo, templateRepo, fileStorage, deps.retention)
+
+	// Initialize handlers
+	docHandler := handler.NewDocumentHandler(docService)
+	srsHandler := handler.NewSRSHandler(srsService)
+	downloadHandler := handler.NewDownloadHandler(downloadService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	templateHandler := handler.NewTemplateHandler(templateService)
+	exportHandler := handler.NewExportHandler(exportService)
+	requirementHandler := handler.NewRequirementHandler(requirementService, issueService)
+	userStoryHandler := handler.NewUserStoryHandler(userStoryService)
+	useCaseHandler := handler.NewUseCaseHandler(useCaseService)
+	apiSpecHandler := handler.NewAPISpecHandler(apiSpecService)
+	dataDictionaryHandler := handler.NewDataDictionaryHandler(dataDictionaryService)
+	glossaryHandler := handler.NewGlossaryHandler(glossaryService)
+	projectHandler := handler.NewProjectHandler(projectService)
+	authHandler := handler.NewAuthHandler(authService)
+	auditHandler := handler.NewAuditHandler(auditService)
+	webhookHandler := handler.NewWebhookHandler(webhookService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	aiCacheHandler := handler.NewAICacheHandler(aiCache)
+	aiUsageHandler := handler.NewAIUsageHandler(aiUsageService)
+	redactionHandler := handler.NewRedactionHandler(redactionService)
+
+	// The caller was authenticated before the request was dispatched here;
+	// the caller's role needs the permission given on the route
+	api := app.Group("/api/v1")
+	read := handler.Require(domain.PermissionRead)
+	write := handler.Require(domain.PermissionWrite)
+	// Uploads and AI generation are rate limited per API key or user
+	limited := deps.rateLimiter.Limit
+	admin := handler.Require(domain.PermissionAdmin)
+
+	// User and API key management
+	users := api.Group("/users", admin)
+	users.Get("/", authHandler.GetUsers)
+	users.Post("/", authHandler.CreateUser)
+	users.Put("/:id", authHandler.UpdateUser)
+
+	apiKeys := api.Group("/api-keys", admin)
+	apiKeys.Get("/", authHandler.GetAPIKeys)
+	apiKeys.Post("/", authHandler.CreateAPIKey)
+	apiKeys.Delete("/:id", authHandler.RevokeAPIKey)
+
+	// Project routes
+	projects := api.Group("/projects")
+	projects.Post("/", admin, projectHandler.Create)
+	projects.Get("/", read, projectHandler.GetAll)
+
+	// Document and SRS lists across projects, filtered by project_id
+	api.Get("/documents", read, docHandler.GetAll)
+	api.Get("/srs", read, srsHandler.GetAll)
+
+	// Everything a project owns is scoped under /projects/:projectId; routes
+	// with an :id check that the resource belongs to that project
+	project := projects.Group("/:projectId", projectHandler.Scope)
+	project.Get("/", read, projectHandler.GetByID)
+	project.Put("/", admin, projectHandler.Update)
+	project.Put("/settings", admin, projectHandler.UpdateSettings)
+	project.Delete("/", admin, projectHandler.Delete)
+	project.Get("/ai-usage", read, aiUsageHandler.Project)
+
+	ownsDocument := projectHandler.Owns(service.ResourceDocument, "id")
+	ownsSRS := projectHandler.Owns(service.ResourceSRS, "id")
+	ownsTemplate := projectHandler.Owns(service.ResourceTemplate, "id")
+	ownsTerm := projectHandler.Owns(service.ResourceGlossaryTerm, "id")
+	ownsStory := projectHandler.Owns(service.ResourceUserStory, "id")
+	ownsEntity := projectHandler.Owns(service.ResourceDataEntity, "id")
+
+	// Document routes
+	documents := project.Group("/documents")
+	documents.Post("/", write, limited, docHandler.Upload)
+	documents.Get("/", read, docHandler.GetAll)
+	documents.Get("/:id", read, ownsDocument, docHandler.GetByID)
+	documents.Post("/:id/process", write, limited, ownsDocument, docHandler.Process)
+	documents.Delete("/:id", write, ownsDocument, docHandler.Delete)
+	documents.Get("/:id/events", read, ownsDocument, docHandler.Events)
+	documents.Get("/:id/ws", read, ownsDocument, docHandler.Socket())
+	documents.Get("/:id/ai-usage", read, ownsDocument, aiUsageHandler.Document)
+	documents.Get("/:id/redactions", read, ownsDocument, redactionHandler.GetReport)
+
+	documents.Get("/:id/download-link", read, ownsDocument, downloadHandler.CreateLink)
+	documents.Get("/:id/access-logs", read, ownsDocument, downloadHandler.AccessLogs)
+
+	// SRS routes
+	srs := project.Group("/srs")
+	srs.Post("/", write, limited, srsHandler.Generate)
+	srs.Get("/", read, srsHandler.GetAll)
+	srs.Get("/:id", read, ownsSRS, srsHandler.GetByID)
+	srs.Get("/document/:documentId", read, projectHandler.Owns(service.ResourceDocument, "documentId"), srsHandler.GetByDocument)
+	srs.Put("/:id", write, ownsSRS, srsHandler.Update)
+	srs.Delete("/:id", write, ownsSRS, srsHandler.Delete)
+	srs.Get("/:id/revisions", read, ownsSRS, srsHandler.GetRevisions)
+	srs.Get("/:id/export", read, ownsSRS, exportHandler.Export)
+	srs.Get("/:id/requirements", read, ownsSRS, requirementHandler.GetBySRS)
+	srs.Get("/:id/issues/export", read, ownsSRS, requirementHandler.ExportIssues)
+	srs.Post("/:id/issues/push", write, ownsSRS, requirementHandler.PushIssues)
+	srs.Post("/:id/user-stories", write, limited, ownsSRS, userStoryHandler.Generate)
+	srs.Get("/:id/user-stories", read, ownsSRS, userStoryHandler.GetBySRS)
+	srs.Get("/:id/user-stories/features", read, ownsSRS, userStoryHandler.ExportFeatures)
+	srs.Post("/:id/use-cases", write, limited, ownsSRS, useCaseHandler.Extract)
+	srs.Get("/:id/use-cases", read, ownsSRS, useCaseHandler.GetModel)
+	srs.Get("/:id/diagrams", read, ownsSRS, useCaseHandler.GetDiagrams)
+	srs.Post("/:id/openapi", write, limited, ownsSRS, apiSpecHandler.Generate)
+	srs.Get("/:id/openapi", read, ownsSRS, apiSpecHandler.Download)
+	srs.Get("/:id/openapi/operations", read, ownsSRS, apiSpecHandler.GetOperations)
+	srs.Post("/:id/data-dictionary/extract", write, limited, ownsSRS, dataDictionaryHandler.Extract)
+	srs.Get("/:id/data-dictionary", read, ownsSRS, dataDictionaryHandler.GetBySRS)
+	srs.Post("/:id/data-dictionary", write, ownsSRS, dataDictionaryHandler.Create)
+	srs.Get("/:id/glossary-check", read, ownsSRS, glossaryHandler.CheckSRS)
+
+	// Data dictionary routes
+	dataEntities := project.Group("/data-entities")
+	dataEntities.Get("/:id", read, ownsEntity, dataDictionaryHandler.GetByID)
+	dataEntities.Put("/:id", write, ownsEntity, dataDictionaryHandler.Update)
+	dataEntities.Delete("/:id", write, ownsEntity, dataDictionaryHandler.Delete)
+
+	// User story routes
+	stories := project.Group("/user-stories")
+	stories.Get("/:id", read, ownsStory, userStoryHandler.GetByID)
+	stories.Get("/:id/feature", read, ownsStory, userStoryHandler.ExportFeature)
+	stories.Delete("/:id", write, ownsStory, userStoryHandler.Delete)
+
+	// Export template routes
+	templates := project.Group("/templates")
+	templates.Post("/", write, limited, templateHandler.Upload)
+	templates.Get("/", read, templateHandler.GetAll)
+	templates.Get("/:id", read, ownsTemplate, templateHandler.GetByID)
+	templates.Put("/:id/default", write, ownsTemplate, templateHandler.SetDefault)
+	templates.Delete("/:id", write, ownsTemplate, templateHandler.Delete)
+
+	// Glossary routes
+	glossary := project.Group("/glossary")
+	glossary.Get("/", read, glossaryHandler.GetAll)
+	glossary.Post("/", write, glossaryHandler.Create)
+	glossary.Post("/extract", write, limited, glossaryHandler.Extract)
+	glossary.Get("/:id", read, ownsTerm, glossaryHandler.GetByID)
+	glossary.Put("/:id", write, ownsTerm, glossaryHandler.Update)
+	glossary.Delete("/:id", write, ownsTerm, glossaryHandler.Delete)
+
+	// Audit trail routes
+	audit := api.Group("/audit-events", admin)
+	audit.Get("/", auditHandler.GetEvents)
+	audit.Get("/export", auditHandler.Export)
+	audit.Get("/verify", auditHandler.Verify)
+
+	// Webhook routes
+	webhooks := api.Group("/webhooks", admin)
+	webhooks.Get("/", webhookHandler.GetAll)
+	webhooks.Post("/", webhookHandler.Create)
+	webhooks.Get("/:id", webhookHandler.GetByID)
+	webhooks.Put("/:id", webhookHandler.Update)
+	webhooks.Delete("/:id", webhookHandler.Delete)
+	webhooks.Get("/:id/deliveries", webhookHandler.GetDeliveries)
+	webhooks.Post("/:id/ping", webhookHandler.Ping)
+
+	// Email notification routes
+	notifications := api.Group("/notifications")
+	notifications.Get("/", admin, notificationHandler.GetAll)
+	notifications.Get("/preferences", read, notificationHandler.GetPreference)
+	notifications.Put("/preferences", read, notificationHandler.SetPreference)
+
+	// AI usage and cost of the whole tenant
+	api.Get("/ai-usage", admin, aiUsageHandler.Tenant)
+
+	// AI response cache routes
+	aiCacheRoutes := api.Group("/ai-cache", admin)
+	aiCacheRoutes.Get("/", aiCacheHandler.Stats)
+	aiCacheRoutes.Delete("/", aiCacheHandler.Clear)
+
+	// Retention routes
+	retention := api.Group("/retention")
+	retention.Get("/report", admin, retentionHandler.Report)
+	retention.Post("/sweep", admin, retentionHandler.Sweep)
+
+	log.Printf("Routes ready for tenant %s", tenant.Slug)
+}
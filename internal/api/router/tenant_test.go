@@ -0,0 +1,92 @@
+package router
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"srs-automation/internal/core/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// newDispatchApp serves /api/v1/* through a tenantRouter whose tenant apps
+// are already built. The X-Test-Tenant header stands in for authentication.
+func newDispatchApp(handlers map[uint]fasthttp.RequestHandler) *fiber.App {
+	r := &tenantRouter{handlers: handlers}
+
+	app := fiber.New()
+	app.Use("/api/v1", func(c *fiber.Ctx) error {
+		if raw := c.Get("X-Test-Tenant"); raw != "" {
+			id, _ := strconv.Atoi(raw)
+			c.Locals("principal", &domain.Principal{TenantID: uint(id)})
+		}
+		return c.Next()
+	}, r.Dispatch)
+	return app
+}
+
+// tenantApp answers every request with the tenant's name
+func tenantApp(name string) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBodyString(name + " " + string(ctx.Path()))
+	}
+}
+
+func dispatch(t *testing.T, app *fiber.App, tenant string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", "/api/v1/projects/1/documents/7", nil)
+	if tenant != "" {
+		req.Header.Set("X-Test-Tenant", tenant)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestDispatchServesCallerTenantApp(t *testing.T) {
+	app := newDispatchApp(map[uint]fasthttp.RequestHandler{
+		1: tenantApp("acme"),
+		2: tenantApp("globex"),
+	})
+
+	for tenant, want := range map[string]string{
+		"1": "acme /api/v1/projects/1/documents/7",
+		"2": "globex /api/v1/projects/1/documents/7",
+	} {
+		status, body := dispatch(t, app, tenant)
+		if status != fiber.StatusOK || body != want {
+			t.Errorf("tenant %s: %d %q, want 200 %q", tenant, status, body, want)
+		}
+	}
+}
+
+func TestDispatchWithoutTenantIsUnauthorized(t *testing.T) {
+	app := newDispatchApp(map[uint]fasthttp.RequestHandler{1: tenantApp("acme")})
+
+	status, body := dispatch(t, app, "")
+	if status != fiber.StatusUnauthorized {
+		t.Errorf("no principal: %d %q, want 401", status, body)
+	}
+}
+
+func TestForgetDropsOnlyThatTenantApp(t *testing.T) {
+	r := &tenantRouter{handlers: map[uint]fasthttp.RequestHandler{
+		1: tenantApp("acme"),
+		2: tenantApp("globex"),
+	}}
+
+	r.forget(1)
+	if _, ok := r.handlers[1]; ok {
+		t.Error("tenant 1 app still cached after forget")
+	}
+	if _, ok := r.handlers[2]; !ok {
+		t.Error("tenant 2 app dropped by forgetting tenant 1")
+	}
+}
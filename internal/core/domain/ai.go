@@ -0,0 +1,146 @@
+package domain
+
+import "time"
+
+// AIClientInfo identifies what produced an AI response
+type AIClientInfo struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	// Changes whenever the prompt texts change, so responses to old prompts
+	// aren't reused
+	PromptVersion string `json:"prompt_version"`
+}
+
+// AITokenUsage is what one request to an AI provider consumed
+type AITokenUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	// The provider didn't report the usage of the request; the counts are 0
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// AIProviderLimit is the rate a provider accepts from this server; 0 is unlimited
+type AIProviderLimit struct {
+	RequestsPerMinute int `json:"requests_per_minute"`
+	TokensPerMinute   int `json:"tokens_per_minute"`
+}
+
+// AICacheEntry is a stored AI response, keyed by a hash of the client info,
+// the AIService method and its input
+type AICacheEntry struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	TenantID      uint      `json:"-" gorm:"uniqueIndex:idx_ai_cache_key;not null;default:0"`
+	Key           string    `json:"key" gorm:"uniqueIndex:idx_ai_cache_key;not null"`
+	Provider      string    `json:"provider"`
+	Model         string    `json:"model"`
+	PromptVersion string    `json:"prompt_version"`
+	Method        string    `json:"method" gorm:"index"`
+	Response      string    `json:"-" gorm:"type:text"`
+	Hits          int64     `json:"hits"`
+	ExpiresAt     time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AICacheMethodStats counts the cached responses of one AIService method.
+// Every stored entry was one cache miss; hits are the calls it answered since.
+type AICacheMethodStats struct {
+	Method  string `json:"method"`
+	Entries int64  `json:"entries"`
+	Hits    int64  `json:"hits"`
+}
+
+// AICacheStats reports how well a tenant's AI cache works
+type AICacheStats struct {
+	Entries int64 `json:"entries"`
+	Hits    int64 `json:"hits"`
+	// Hits / (hits + entries)
+	HitRate float64              `json:"hit_rate"`
+	Methods []AICacheMethodStats `json:"methods"`
+}
+
+// AIUsageRecord is one request sent to an AI provider. Cache hits send no
+// request and are not recorded.
+type AIUsageRecord struct {
+	ID        uint `json:"id" gorm:"primaryKey"`
+	TenantID  uint `json:"-" gorm:"index;not null;default:0"`
+	ProjectID uint `json:"project_id" gorm:"index:idx_ai_usage_project_time"`
+	// 0 when the call wasn't made for a document
+	DocumentID       uint   `json:"document_id" gorm:"index"`
+	Provider         string `json:"provider"`
+	Model            string `json:"model"`
+	Method           string `json:"method"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+	LatencyMs        int64  `json:"latency_ms"`
+	// The provider didn't report token counts, so the call has no tokens or cost
+	UsageUnknown bool `json:"usage_unknown,omitempty"`
+	// USD, priced when the call was made
+	Cost      float64   `json:"cost"`
+	Error     string    `json:"error,omitempty" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_ai_usage_project_time"`
+}
+
+// AIPrice is what a model costs in USD per million tokens
+type AIPrice struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// AIUsageGroup is how usage records are aggregated
+type AIUsageGroup string
+
+const (
+	AIUsageByDay      AIUsageGroup = "day"
+	AIUsageByProject  AIUsageGroup = "project"
+	AIUsageByDocument AIUsageGroup = "document"
+	AIUsageByModel    AIUsageGroup = "model"
+)
+
+func (g AIUsageGroup) Valid() bool {
+	switch g {
+	case AIUsageByDay, AIUsageByProject, AIUsageByDocument, AIUsageByModel:
+		return true
+	}
+	return false
+}
+
+// AIUsageFilter selects usage records; zero fields select everything
+type AIUsageFilter struct {
+	ProjectID  uint
+	DocumentID uint
+	From       *time.Time
+	To         *time.Time
+}
+
+// AIUsageSummary adds up usage records; only the fields of the grouping are set
+type AIUsageSummary struct {
+	Day              string  `json:"day,omitempty"`
+	ProjectID        uint    `json:"project_id,omitempty"`
+	DocumentID       uint    `json:"document_id,omitempty"`
+	Provider         string  `json:"provider,omitempty"`
+	Model            string  `json:"model,omitempty"`
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	Cost             float64 `json:"cost"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms"`
+}
+
+// AIBudgetStatus compares a project's AI cost this month to its budget
+type AIBudgetStatus struct {
+	Period string `json:"period"`
+	// 0 is unlimited
+	Budget   float64 `json:"budget"`
+	Spent    float64 `json:"spent"`
+	Exceeded bool    `json:"exceeded"`
+}
+
+// AIUsageReport is the total of the selected usage and its breakdown
+type AIUsageReport struct {
+	Group  AIUsageGroup     `json:"group"`
+	Total  AIUsageSummary   `json:"total"`
+	Groups []AIUsageSummary `json:"groups"`
+	// Only reported for one project
+	Budget *AIBudgetStatus `json:"budget,omitempty"`
+}
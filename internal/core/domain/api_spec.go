@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// APISpec is a draft OpenAPI document derived from the functional requirements
+// and data entities of an SRS
+type APISpec struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	TenantID   uint           `json:"-" gorm:"index;not null;default:0"`
+	SRSID      uint           `json:"srs_id" gorm:"uniqueIndex;not null"`
+	Title      string         `json:"title"`
+	Version    string         `json:"version"`
+	Operations []APIOperation `json:"operations" gorm:"type:jsonb;serializer:json"`
+	Schemas    []APISchema    `json:"schemas" gorm:"type:jsonb;serializer:json"`
+	// Rendered and validated OpenAPI 3.1 YAML
+	Document  string    `json:"-" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// The spec is removed together with its SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
+// APIOperation is one candidate endpoint and the requirements that justify it
+type APIOperation struct {
+	OperationID string `json:"operation_id"`
+	Method      string `json:"method"`
+	// Path template, e.g. /orders/{orderId}
+	Path        string `json:"path"`
+	Resource    string `json:"resource"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	// Schema names of the request and response bodies; empty for none
+	RequestSchema  string `json:"request_schema"`
+	ResponseSchema string `json:"response_schema"`
+	ResponseIsList bool   `json:"response_is_list"`
+	// Codes of the requirements the operation realizes (e.g. FR-001)
+	RequirementCodes []string `json:"requirement_ids"`
+}
+
+type APISchema struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Properties  []APIProperty `json:"properties"`
+}
+
+type APIProperty struct {
+	Name string `json:"name"`
+	// string, integer, number, boolean, date, date-time or a schema name;
+	// a "[]" suffix makes it an array
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+// FindSchema returns the schema with the given name, or nil
+func (s *APISpec) FindSchema(name string) *APISchema {
+	for i := range s.Schemas {
+		if s.Schemas[i].Name == name {
+			return &s.Schemas[i]
+		}
+	}
+	return nil
+}
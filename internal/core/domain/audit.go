@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditAction is something done to an audited entity
+type AuditAction string
+
+const (
+	AuditActionUpload       AuditAction = "upload"
+	AuditActionProcess      AuditAction = "process"
+	AuditActionGenerate     AuditAction = "generate"
+	AuditActionEdit         AuditAction = "edit"
+	AuditActionApprove      AuditAction = "approve"
+	AuditActionChangeStatus AuditAction = "change_status"
+	AuditActionDelete       AuditAction = "delete"
+)
+
+// Audited entity types
+const (
+	AuditEntityDocument = "document"
+	AuditEntitySRS      = "srs"
+)
+
+// AuditActor identifies who performed an action and the request it came from
+type AuditActor struct {
+	// AuthMethod of the caller, or "system" for background jobs
+	Type      string
+	ID        uint
+	Name      string
+	IP        string
+	RequestID string
+}
+
+// AuditEvent is one entry of a tenant's append-only audit trail. Each event
+// carries the hash of the previous one, so editing or removing an event
+// breaks the chain from that point on.
+type AuditEvent struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	TenantID   uint        `json:"-" gorm:"index;not null;default:0"`
+	ActorType  string      `json:"actor_type" gorm:"not null"`
+	ActorID    uint        `json:"actor_id" gorm:"index"`
+	ActorName  string      `json:"actor_name"`
+	Action     AuditAction `json:"action" gorm:"index;not null"`
+	EntityType string      `json:"entity_type" gorm:"index:idx_audit_entity;not null"`
+	EntityID   uint        `json:"entity_id" gorm:"index:idx_audit_entity;not null"`
+	// SHA-256 of the entity's JSON before and after the action; empty when
+	// the entity didn't exist before or doesn't exist after
+	BeforeHash string    `json:"before_hash"`
+	AfterHash  string    `json:"after_hash"`
+	IP         string    `json:"ip"`
+	RequestID  string    `json:"request_id" gorm:"index"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+// ComputeHash hashes the event's content together with the previous hash.
+// The ID and tenant are left out; both are assigned when the event is stored.
+func (e *AuditEvent) ComputeHash() string {
+	fields := []string{
+		e.PrevHash,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+		e.ActorType,
+		strconv.FormatUint(uint64(e.ActorID), 10),
+		e.ActorName,
+		string(e.Action),
+		e.EntityType,
+		strconv.FormatUint(uint64(e.EntityID), 10),
+		e.BeforeHash,
+		e.AfterHash,
+		e.IP,
+		e.RequestID,
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Seal links the event to the previous event of the chain
+func (e *AuditEvent) Seal(prevHash string) {
+	e.PrevHash = prevHash
+	e.Hash = e.ComputeHash()
+}
+
+// AuditFilter selects audit events; zero fields select everything
+type AuditFilter struct {
+	ActorID    uint
+	ActorName  string
+	Action     AuditAction
+	EntityType string
+	EntityID   uint
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+// AuditVerification is the result of checking a tenant's hash chain
+type AuditVerification struct {
+	Valid  bool `json:"valid"`
+	Events int  `json:"events"`
+	// First event whose hash or link to the previous event doesn't match
+	BrokenAt *uint  `json:"broken_at,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
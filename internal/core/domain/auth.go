@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+// Role grants a fixed set of permissions; each role includes those of the roles before it
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleAnalyst  Role = "analyst"
+	RoleReviewer Role = "reviewer"
+	RoleAdmin    Role = "admin"
+)
+
+// Permission is an action checked on every protected route
+type Permission string
+
+const (
+	// Read documents, SRS and everything derived from them
+	PermissionRead Permission = "read"
+	// Upload, generate, edit and delete project content
+	PermissionWrite Permission = "write"
+	// Approve or reject SRS and curate glossary terms
+	PermissionReview Permission = "review"
+	// Manage projects, users, API keys and retention
+	PermissionAdmin Permission = "admin"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleViewer:   {PermissionRead},
+	RoleAnalyst:  {PermissionRead, PermissionWrite},
+	RoleReviewer: {PermissionRead, PermissionWrite, PermissionReview},
+	RoleAdmin:    {PermissionRead, PermissionWrite, PermissionReview, PermissionAdmin},
+}
+
+func (r Role) Valid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) Can(permission Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// User is a person signing in with email and password to get a JWT
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TenantID     uint      `json:"-" gorm:"index;not null;default:0"`
+	Email        string    `json:"email" gorm:"uniqueIndex;not null"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	Role         Role      `json:"role" gorm:"not null;default:'viewer'"`
+	Active       bool      `json:"active" gorm:"default:true"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// APIKey authenticates automation clients. Only a hash of the key is stored;
+// the key itself is shown once when it is created.
+type APIKey struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	TenantID uint   `json:"-" gorm:"index;not null;default:0"`
+	Name     string `json:"name" gorm:"not null"`
+	// First characters of the key, to tell keys apart in listings
+	Prefix     string     `json:"prefix" gorm:"index"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex;not null"`
+	Role       Role       `json:"role" gorm:"not null"`
+	CreatedBy  *uint      `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Usable reports whether the key may still authenticate requests
+func (k *APIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// TokenClaims are the claims carried by a locally issued JWT
+type TokenClaims struct {
+	UserID    uint
+	Email     string
+	Role      Role
+	ExpiresAt time.Time
+}
+
+// AuthMethod tells how a request was authenticated
+type AuthMethod string
+
+const (
+	AuthMethodJWT    AuthMethod = "jwt"
+	AuthMethodAPIKey AuthMethod = "api_key"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Method   AuthMethod `json:"method"`
+	TenantID uint       `json:"tenant_id"`
+	// Set for JWT callers
+	UserID uint `json:"user_id,omitempty"`
+	// Set for API key callers
+	APIKeyID uint   `json:"api_key_id,omitempty"`
+	Name     string `json:"name"`
+	Role     Role   `json:"role"`
+}
+
+func (p *Principal) Can(permission Permission) bool {
+	return p.Role.Can(permission)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// DataEntity is one business entity of the data dictionary of an SRS
+type DataEntity struct {
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	TenantID    uint            `json:"-" gorm:"index;not null;default:0"`
+	SRSID       uint            `json:"srs_id" gorm:"uniqueIndex:idx_srs_data_entity;not null"`
+	Name        string          `json:"name" gorm:"uniqueIndex:idx_srs_data_entity;not null"`
+	Description string          `json:"description" gorm:"type:text"`
+	Attributes  []DataAttribute `json:"attributes" gorm:"type:jsonb;serializer:json"`
+	// Where the entity is mentioned: requirement codes or SRS section titles
+	Sources []string `json:"sources" gorm:"type:jsonb;serializer:json"`
+	// Set once an analyst edits the entity; curated entities survive re-extraction
+	Curated   bool      `json:"curated"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// The dictionary is removed together with its SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
+// DataAttribute is a field of a data entity, e.g. "nomor rekening"
+type DataAttribute struct {
+	Name string `json:"name"`
+	// string, integer, decimal, boolean, date, date-time or enum
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Constraints []string `json:"constraints"`
+	Description string   `json:"description"`
+	// Requirement code or section title the attribute was found in
+	Source string `json:"source"`
+}
@@ -13,6 +13,14 @@ const (
 	DocumentTypeOther DocumentType = "OTHER"
 )
 
+func (t DocumentType) Valid() bool {
+	switch t {
+	case DocumentTypeBRD, DocumentTypeSRS, DocumentTypeOther:
+		return true
+	}
+	return false
+}
+
 // DocumentStatus represents the processing status
 type DocumentStatus string
 
@@ -25,13 +33,24 @@ const (
 
 // Document represents a document entity
 type Document struct {
-	ID            uint           `json:"id" gorm:"primaryKey"`
-	Filename      string         `json:"filename" gorm:"not null"`
-	Type          DocumentType   `json:"type" gorm:"not null"`
-	FilePath      string         `json:"file_path" gorm:"not null"`
-	Status        DocumentStatus `json:"status" gorm:"default:'UPLOADED'"`
-	ExtractedData []byte         `json:"extracted_data"`
-	GoogleDocLink string         `json:"google_doc_link"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	TenantID  uint           `json:"-" gorm:"index;not null;default:0"`
+	ProjectID uint           `json:"project_id" gorm:"index;index:idx_documents_project_created;default:0"`
+	Filename  string         `json:"filename" gorm:"not null"`
+	Type      DocumentType   `json:"type" gorm:"index;not null"`
+	FilePath  string         `json:"file_path" gorm:"not null"`
+	Status    DocumentStatus `json:"status" gorm:"index;default:'UPLOADED'"`
+	// Name of the user or API key that uploaded the document
+	Author        string    `json:"author" gorm:"index"`
+	ExtractedData []byte    `json:"extracted_data"`
+	GoogleDocLink string    `json:"google_doc_link"`
+	CreatedAt     time.Time `json:"created_at" gorm:"index:idx_documents_project_created"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// Set when the retention sweeper removes the file from disk
+	SourcePurgedAt *time.Time `json:"source_purged_at,omitempty"`
+	ResultPurgedAt *time.Time `json:"result_purged_at,omitempty"`
+
+	// Instruction-like passages found in the extracted text on the last run
+	InjectionFlags []InjectionFlag `json:"injection_flags,omitempty" gorm:"type:jsonb;serializer:json"`
 }
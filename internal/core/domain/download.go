@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// FileKind identifies which file of a document is being downloaded
+type FileKind string
+
+const (
+	FileKindSource FileKind = "source"
+	FileKindResult FileKind = "result"
+)
+
+// DownloadLink represents a signed, time-limited URL for a document file
+type DownloadLink struct {
+	DocumentID uint      `json:"document_id"`
+	Kind       FileKind  `json:"kind"`
+	URL        string    `json:"url"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// FileAccessLog records every attempt to download a document file
+type FileAccessLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	TenantID   uint      `json:"-" gorm:"index;not null;default:0"`
+	DocumentID uint      `json:"document_id" gorm:"index;not null"`
+	Kind       FileKind  `json:"kind" gorm:"not null"`
+	ClientIP   string    `json:"client_ip"`
+	UserAgent  string    `json:"user_agent"`
+	Granted    bool      `json:"granted"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+// ExportDocument is everything an exporter needs to render one SRS (revision)
+type ExportDocument struct {
+	SRSID        uint
+	Revision     int
+	Meta         ExportMeta
+	Content      string
+	Sections     []SRSSection
+	Requirements []Requirement
+	// Data dictionary, rendered as its own section
+	DataDictionary []DataEntity
+	// Diagram sources embedded by the Markdown and HTML exporters
+	Diagrams []Diagram
+
+	// DOCX reference template; nil uses the built-in layout
+	Template []byte
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type GlossaryTermStatus string
+
+const (
+	// Extracted by the AI provider, waiting for review
+	TermProposed GlossaryTermStatus = "PROPOSED"
+	// Curated; used in generation prompts and consistency checks
+	TermApproved GlossaryTermStatus = "APPROVED"
+	TermRejected GlossaryTermStatus = "REJECTED"
+)
+
+// GlossaryTerm is a preferred term of a project and the synonyms to avoid
+type GlossaryTerm struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	TenantID   uint   `json:"-" gorm:"index;not null;default:0"`
+	ProjectID  uint   `json:"project_id" gorm:"uniqueIndex:idx_project_term;default:0"`
+	Term       string `json:"term" gorm:"uniqueIndex:idx_project_term;not null"`
+	Definition string `json:"definition" gorm:"type:text"`
+	// Other names of the same concept that should be replaced by Term
+	Synonyms         []string           `json:"synonyms" gorm:"type:jsonb;serializer:json"`
+	Status           GlossaryTermStatus `json:"status" gorm:"default:'PROPOSED'"`
+	SourceDocumentID *uint              `json:"source_document_id,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+type TermIssueKind string
+
+const (
+	// A synonym is used where the glossary prescribes another term
+	TermIssueSynonym TermIssueKind = "synonym"
+	// A term looks domain specific but is not in the glossary
+	TermIssueUndefined TermIssueKind = "undefined"
+)
+
+// TermIssue is one finding of a glossary consistency check
+type TermIssue struct {
+	Kind TermIssueKind `json:"kind"`
+	// The text found in the SRS
+	Found   string `json:"found"`
+	Line    int    `json:"line"`
+	Section string `json:"section"`
+	// Preferred glossary term, for synonym findings
+	Suggestion string `json:"suggestion,omitempty"`
+}
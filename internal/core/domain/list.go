@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// SortField is a field a list endpoint can be sorted by
+type SortField struct {
+	Name string
+	// Time fields are compared as timestamps when paging with a cursor
+	Time bool
+}
+
+// Sort fields of the document and SRS lists; the first is the default
+var (
+	DocumentSortFields = []SortField{{"created_at", true}, {"updated_at", true}, {"filename", false}, {"status", false}, {"type", false}}
+	SRSSortFields      = []SortField{{"created_at", true}, {"updated_at", true}, {"title", false}, {"status", false}, {"author", false}}
+)
+
+// PageRequest selects one page of a list, either by offset or by a cursor
+// from the previous page. A cursor keeps its place while rows are added.
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+	Cursor string
+	// The decoded cursor: the sort value and ID of the last row already seen
+	After *PagePosition
+}
+
+type PagePosition struct {
+	Value any
+	ID    uint
+}
+
+// PageInfo describes the page returned with a list
+type PageInfo struct {
+	// Rows matching the filters, on all pages
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// DocumentFilter selects documents; zero fields select everything
+type DocumentFilter struct {
+	ProjectID uint
+	Status    DocumentStatus
+	Type      DocumentType
+	// The uploader's name
+	Author string
+	// Matched against the filename, case-insensitively
+	Search string
+	From   *time.Time
+	To     *time.Time
+	Page   PageRequest
+}
+
+// SRSFilter selects SRS documents; zero fields select everything
+type SRSFilter struct {
+	ProjectID uint
+	Status    string
+	Author    string
+	// Matched against the title, case-insensitively
+	Search string
+	From   *time.Time
+	To     *time.Time
+	Page   PageRequest
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationType is a kind of email notification
+type NotificationType string
+
+const (
+	// A document was processed and its SRS draft is ready
+	NotifySRSReady         NotificationType = "srs_ready"
+	NotifyReviewRequested  NotificationType = "review_requested"
+	NotifyChangesRequested NotificationType = "changes_requested"
+	NotifyApproved         NotificationType = "approved"
+	NotifyProcessingFailed NotificationType = "processing_failed"
+)
+
+// NotificationTypes lists the notifications users can subscribe to
+var NotificationTypes = []NotificationType{
+	NotifySRSReady,
+	NotifyReviewRequested,
+	NotifyChangesRequested,
+	NotifyApproved,
+	NotifyProcessingFailed,
+}
+
+// DefaultNotificationTypes are the notifications a user gets until they set
+// their own preferences: authors hear about their drafts, reviewers about
+// drafts awaiting them
+func DefaultNotificationTypes(role Role) []NotificationType {
+	switch role {
+	case RoleAnalyst:
+		return []NotificationType{NotifySRSReady, NotifyChangesRequested, NotifyApproved, NotifyProcessingFailed}
+	case RoleReviewer:
+		return []NotificationType{NotifyReviewRequested, NotifyApproved}
+	case RoleAdmin:
+		return []NotificationType{NotifyReviewRequested, NotifyProcessingFailed}
+	default:
+		return nil
+	}
+}
+
+// NotificationMode is how a user receives notifications
+type NotificationMode string
+
+const (
+	// One email per notification, sent right away
+	NotifyImmediate NotificationMode = "immediate"
+	// Notifications are collected and sent as one email per digest interval
+	NotifyDigest NotificationMode = "digest"
+	NotifyOff    NotificationMode = "off"
+)
+
+func (m NotificationMode) Valid() bool {
+	return m == NotifyImmediate || m == NotifyDigest || m == NotifyOff
+}
+
+// NotificationPreference is a user's choice of notifications and delivery mode
+type NotificationPreference struct {
+	ID       uint             `json:"-" gorm:"primaryKey"`
+	TenantID uint             `json:"-" gorm:"index;not null;default:0"`
+	UserID   uint             `json:"user_id" gorm:"uniqueIndex;not null"`
+	Mode     NotificationMode `json:"mode" gorm:"not null"`
+	// Comma-separated NotificationType values
+	Types     string    `json:"types"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribes reports whether the user receives the notification
+func (p *NotificationPreference) Subscribes(t NotificationType) bool {
+	if p.Mode == NotifyOff {
+		return false
+	}
+	for _, s := range strings.Split(p.Types, ",") {
+		if NotificationType(strings.TrimSpace(s)) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultNotificationPreference is the preference of a user who hasn't set one
+func DefaultNotificationPreference(user *User) *NotificationPreference {
+	types := make([]string, 0, 4)
+	for _, t := range DefaultNotificationTypes(user.Role) {
+		types = append(types, string(t))
+	}
+	return &NotificationPreference{
+		UserID: user.ID,
+		Mode:   NotifyImmediate,
+		Types:  strings.Join(types, ","),
+	}
+}
+
+// NotificationStatus is the state of a queued email
+type NotificationStatus string
+
+const (
+	NotificationPending NotificationStatus = "pending"
+	NotificationSent    NotificationStatus = "sent"
+	NotificationFailed  NotificationStatus = "failed"
+)
+
+// Notification is one rendered email to one user, queued until it is sent
+// on its own or as part of a digest
+type Notification struct {
+	ID       uint             `json:"id" gorm:"primaryKey"`
+	TenantID uint             `json:"-" gorm:"index;not null;default:0"`
+	UserID   uint             `json:"user_id" gorm:"index;not null"`
+	Email    string           `json:"email" gorm:"not null"`
+	Type     NotificationType `json:"type" gorm:"not null"`
+	Subject  string           `json:"subject"`
+	Body     string           `json:"body" gorm:"type:text"`
+	// Sent with the recipient's next digest instead of on its own
+	Digest        bool               `json:"digest" gorm:"index:idx_notification_due"`
+	Status        NotificationStatus `json:"status" gorm:"index:idx_notification_due;not null"`
+	Attempts      int                `json:"attempts"`
+	Error         string             `json:"error,omitempty"`
+	NextAttemptAt *time.Time         `json:"-" gorm:"index:idx_notification_due"`
+	SentAt        *time.Time         `json:"sent_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+// NotificationContext describes what a notification is about; it is the data
+// its email template is rendered with
+type NotificationContext struct {
+	ProjectID   uint
+	ProjectName string
+	DocumentID  uint
+	Filename    string
+	SRSID       uint
+	Title       string
+	Status      string
+	// Who caused the notification; a user isn't notified of their own action
+	ActorID   uint
+	ActorName string
+	Error     string
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// ProgressStage is a step of document processing
+type ProgressStage string
+
+const (
+	// Waiting to be processed
+	ProgressUploaded   ProgressStage = "uploaded"
+	ProgressExtracting ProgressStage = "extracting"
+	// One page (PDF) or the whole file read
+	ProgressChunk      ProgressStage = "chunk"
+	ProgressGenerating ProgressStage = "generating"
+	// A piece of the SRS text as the AI writes it
+	ProgressToken     ProgressStage = "token"
+	ProgressRendering ProgressStage = "rendering"
+	ProgressCompleted ProgressStage = "completed"
+	ProgressFailed    ProgressStage = "failed"
+)
+
+// ProgressEvent reports how far the processing of a document has come
+type ProgressEvent struct {
+	DocumentID uint          `json:"document_id"`
+	Stage      ProgressStage `json:"stage"`
+	Message    string        `json:"message,omitempty"`
+	Chunk      int           `json:"chunk,omitempty"`
+	Chunks     int           `json:"chunks,omitempty"`
+	Token      string        `json:"token,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Time       time.Time     `json:"time"`
+}
+
+// Final reports whether no more events follow for this processing run
+func (e ProgressEvent) Final() bool {
+	return e.Stage == ProgressCompleted || e.Stage == ProgressFailed
+}
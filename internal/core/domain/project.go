@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// DefaultProjectName is the project that owns rows created before projects existed
+const DefaultProjectName = "Default"
+
+// Project groups the documents, SRS, templates and glossary of one team or product
+type Project struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	TenantID    uint   `json:"-" gorm:"uniqueIndex:idx_tenant_project_name;not null;default:0"`
+	Name        string `json:"name" gorm:"uniqueIndex:idx_tenant_project_name;not null"`
+	Description string `json:"description" gorm:"type:text"`
+	// Output language of generated documents ("id" or "en")
+	Language string `json:"language" gorm:"default:'id'"`
+	// AI provider used for this project's generation; empty uses the server default
+	AIProvider string `json:"ai_provider"`
+	// Monthly allowance for AI calls in USD; 0 is unlimited. Processing stops
+	// once the month's cost reaches it.
+	MonthlyAIBudget float64 `json:"monthly_ai_budget" gorm:"not null;default:0"`
+	// Words and names replaced by placeholders before text is sent to the AI
+	// provider, in addition to the built-in redaction rules
+	RedactionTerms []string  `json:"redaction_terms" gorm:"type:jsonb;serializer:json"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// Filled from the template marked as default, not stored on the project
+	DefaultTemplateID *uint `json:"default_template_id" gorm:"-"`
+}
+
+// PromptContext carries the project settings that shape generation prompts
+type PromptContext struct {
+	// Output language code, e.g. "id"; empty keeps the prompt's own instructions
+	Language string
+	// Approved glossary terms, one per line; may be empty
+	Glossary string
+	// Asks a caching client for a fresh response instead of a cached one
+	NoCache bool
+	// The input contains redaction placeholders that must be kept as they are
+	Redacted bool
+}
@@ -0,0 +1,10 @@
+package domain
+
+// InjectionFlag is a passage of extracted document text that reads like an
+// instruction to the AI rather than business content. Flagged documents are
+// still processed; the flags are for the reviewer of the generated SRS.
+type InjectionFlag struct {
+	Rule    string `json:"rule"`
+	Line    int    `json:"line"`
+	Excerpt string `json:"excerpt"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// RedactionKind is the kind of sensitive value a redaction rule finds
+type RedactionKind string
+
+const (
+	RedactNIK         RedactionKind = "nik"
+	RedactNPWP        RedactionKind = "npwp"
+	RedactPhone       RedactionKind = "phone"
+	RedactEmail       RedactionKind = "email"
+	RedactBankAccount RedactionKind = "bank_account"
+	// A custom term from the project settings
+	RedactTerm RedactionKind = "term"
+)
+
+// RedactionKinds are the built-in rules, in the order they are applied
+var RedactionKinds = []RedactionKind{RedactEmail, RedactNPWP, RedactBankAccount, RedactNIK, RedactPhone}
+
+// RedactionFinding is one distinct sensitive value and its placeholder
+type RedactionFinding struct {
+	Placeholder string        `json:"placeholder"`
+	Kind        RedactionKind `json:"kind"`
+	// The value with most characters hidden
+	Masked      string `json:"masked"`
+	Occurrences int    `json:"occurrences"`
+}
+
+// RedactionReport lists what was hidden from the AI provider when the SRS of
+// a document was last generated. Original values are never stored.
+type RedactionReport struct {
+	ID         uint               `json:"id" gorm:"primaryKey"`
+	TenantID   uint               `json:"-" gorm:"index;not null;default:0"`
+	DocumentID uint               `json:"document_id" gorm:"uniqueIndex;not null"`
+	Total      int                `json:"total"`
+	Counts     map[string]int     `json:"counts" gorm:"type:jsonb;serializer:json"`
+	Findings   []RedactionFinding `json:"findings" gorm:"type:jsonb;serializer:json"`
+	CreatedAt  time.Time          `json:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+	Document   Document           `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+}
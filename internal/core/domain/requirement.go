@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// RequirementType classifies a requirement by its ID prefix or enclosing section
+type RequirementType string
+
+const (
+	RequirementFunctional    RequirementType = "FUNCTIONAL"
+	RequirementNonFunctional RequirementType = "NON_FUNCTIONAL"
+	RequirementBusiness      RequirementType = "BUSINESS"
+	RequirementOther         RequirementType = "OTHER"
+)
+
+// Requirement is a single identified requirement (e.g. FR-001) of an SRS.
+// Requirements are re-extracted from the SRS content whenever it changes;
+// the external issue key survives as long as the requirement code does.
+type Requirement struct {
+	ID                 uint            `json:"id" gorm:"primaryKey"`
+	TenantID           uint            `json:"-" gorm:"index;not null;default:0"`
+	SRSID              uint            `json:"srs_id" gorm:"uniqueIndex:idx_srs_requirement;not null"`
+	Code               string          `json:"code" gorm:"uniqueIndex:idx_srs_requirement;not null"`
+	Type               RequirementType `json:"type"`
+	Statement          string          `json:"statement" gorm:"type:text"`
+	AcceptanceCriteria []string        `json:"acceptance_criteria" gorm:"type:jsonb;serializer:json"`
+	Priority           string          `json:"priority"`
+	Section            string          `json:"section"`
+	ExternalKey        string          `json:"external_key"`
+	ExternalURL        string          `json:"external_url"`
+	PushedAt           *time.Time      `json:"pushed_at"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+
+	// Requirements are removed together with their SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
+// TrackerIssue is a requirement converted to an issue-tracker story
+type TrackerIssue struct {
+	RequirementCode    string   `json:"requirement_code"`
+	Summary            string   `json:"summary"`
+	Description        string   `json:"description"`
+	AcceptanceCriteria []string `json:"acceptance_criteria"`
+	Priority           string   `json:"priority"`
+	Labels             []string `json:"labels"`
+	// Epic groups issues by SRS section
+	Epic string `json:"epic"`
+}
+
+// githubLabelLength is GitHub's maximum label name length
+const githubLabelLength = 50
+
+// TrackerIssueRef identifies an issue created in an external tracker
+type TrackerIssueRef struct {
+	Key string `json:"key"`
+	URL string `json:"url"`
+}
+
+// IssuePushReport summarizes a push of requirements to an issue tracker
+type IssuePushReport struct {
+	Created []Requirement      `json:"created"`
+	Skipped []string           `json:"skipped"`
+	Failed  []IssuePushFailure `json:"failed"`
+}
+
+// IssuePushFailure is a requirement that could not be created in the tracker
+type IssuePushFailure struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// MarkdownBody formats the issue body for trackers that render Markdown (GitHub)
+func (i TrackerIssue) MarkdownBody() string {
+	var b strings.Builder
+	b.WriteString(i.Description)
+	if len(i.AcceptanceCriteria) > 0 {
+		b.WriteString("\n\n## Kriteria Penerimaan\n")
+		for _, c := range i.AcceptanceCriteria {
+			b.WriteString("\n- [ ] " + c)
+		}
+	}
+	if i.Epic != "" {
+		b.WriteString("\n\n**Epic:** " + i.Epic)
+	}
+	b.WriteString("\n\n_Requirement: " + i.RequirementCode + "_")
+	return b.String()
+}
+
+// JiraDescription formats the issue body in Jira wiki markup
+func (i TrackerIssue) JiraDescription() string {
+	var b strings.Builder
+	b.WriteString(i.Description)
+	if len(i.AcceptanceCriteria) > 0 {
+		b.WriteString("\n\nh3. Kriteria Penerimaan\n")
+		for _, c := range i.AcceptanceCriteria {
+			b.WriteString("\n* " + c)
+		}
+	}
+	b.WriteString("\n\n_Requirement: " + i.RequirementCode + "_")
+	return b.String()
+}
+
+// GitHubLabels returns the issue labels plus priority and "epic: <section>"
+// labels, trimmed to GitHub's label length limit
+func (i TrackerIssue) GitHubLabels() []string {
+	labels := append([]string{}, i.Labels...)
+	if i.Priority != "" {
+		labels = append(labels, "priority: "+strings.ToLower(i.Priority))
+	}
+	if i.Epic != "" {
+		labels = append(labels, "epic: "+i.Epic)
+	}
+
+	for n, label := range labels {
+		if runes := []rune(label); len(runes) > githubLabelLength {
+			labels[n] = string(runes[:githubLabelLength])
+		}
+	}
+	return labels
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// RetentionPolicy defines how long artifacts of a document type are kept.
+// A value of 0 days means the artifact is kept forever.
+type RetentionPolicy struct {
+	DocumentType DocumentType `json:"document_type"`
+	SourceDays   int          `json:"source_days"`
+	ResultDays   int          `json:"result_days"`
+	RecordDays   int          `json:"record_days"`
+}
+
+// RetentionActionType describes what the sweeper does with an item
+type RetentionActionType string
+
+const (
+	RetentionDeleteSource     RetentionActionType = "DELETE_SOURCE_FILE"
+	RetentionDeleteResult     RetentionActionType = "DELETE_RESULT_FILE"
+	RetentionDeleteDocument   RetentionActionType = "DELETE_DOCUMENT"
+	RetentionDeleteOrphanFile RetentionActionType = "DELETE_ORPHAN_FILE"
+	RetentionDeleteOrphanSRS  RetentionActionType = "DELETE_ORPHAN_SRS"
+	// Rows of a table owned by SRS, such as revisions, whose SRS is gone
+	RetentionDeleteOrphanSRSData RetentionActionType = "DELETE_ORPHAN_SRS_DATA"
+)
+
+// RetentionAction is a single purge performed (or planned) by the sweeper
+type RetentionAction struct {
+	Action     RetentionActionType `json:"action"`
+	DocumentID uint                `json:"document_id,omitempty"`
+	SRSID      uint                `json:"srs_id,omitempty"`
+	Path       string              `json:"path,omitempty"`
+	Table      string              `json:"table,omitempty"`
+	Rows       int64               `json:"rows,omitempty"`
+	Size       int64               `json:"size,omitempty"`
+	Reason     string              `json:"reason"`
+	Error      string              `json:"error,omitempty"`
+}
+
+// RetentionReport summarizes a sweep run
+type RetentionReport struct {
+	DryRun      bool              `json:"dry_run"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Policies    []RetentionPolicy `json:"policies"`
+	Actions     []RetentionAction `json:"actions"`
+	FreedBytes  int64             `json:"freed_bytes"`
+}
+
+// Add appends an action and accounts for the bytes it frees
+func (r *RetentionReport) Add(action RetentionAction) {
+	r.Actions = append(r.Actions, action)
+	if action.Error == "" {
+		r.FreedBytes += action.Size
+	}
+}
+
+// StoredFile describes a file found in storage
+type StoredFile struct {
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
@@ -2,24 +2,107 @@ package domain
 
 import "time"
 
+// SRS review statuses with a meaning for notifications; other values may be used freely
+const (
+	SRSStatusDraft            = "DRAFT"
+	SRSStatusInReview         = "IN_REVIEW"
+	SRSStatusChangesRequested = "CHANGES_REQUESTED"
+	SRSStatusApproved         = "APPROVED"
+)
+
 // SRS represents a Software Requirements Specification
 type SRS struct {
-	ID               uint      `json:"id" gorm:"primaryKey"`
-	SourceDocumentID uint      `json:"source_document_id" gorm:"not null"`
-	Title            string    `json:"title" gorm:"not null"`
-	Version          string    `json:"version" gorm:"default:'1.0'"`
-	Content          string    `json:"content" gorm:"type:text"`
-	Sections         string    `json:"sections" gorm:"type:jsonb"`
-	Status           string    `json:"status" gorm:"default:'DRAFT'"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	TenantID         uint       `json:"-" gorm:"index;not null;default:0"`
+	ProjectID        uint       `json:"project_id" gorm:"index;index:idx_srs_project_created;default:0"`
+	SourceDocumentID uint       `json:"source_document_id" gorm:"not null"`
+	Title            string     `json:"title" gorm:"not null"`
+	Version          string     `json:"version" gorm:"default:'1.0'"`
+	Content          string     `json:"content" gorm:"type:text"`
+	Sections         string     `json:"sections" gorm:"type:jsonb"`
+	Status           string     `json:"status" gorm:"index;default:'DRAFT'"`
+	Author           string     `json:"author" gorm:"index"`
+	ApprovedBy       string     `json:"approved_by"`
+	ApprovedAt       *time.Time `json:"approved_at"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"index:idx_srs_project_created"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 
 	SourceDocument Document `json:"source_document" gorm:"foreignKey:SourceDocumentID"`
 }
 
+// SRSRevision is an immutable snapshot of an SRS, taken whenever its content or status changes
+type SRSRevision struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	TenantID   uint       `json:"-" gorm:"index;not null;default:0"`
+	SRSID      uint       `json:"srs_id" gorm:"uniqueIndex:idx_srs_revision;not null"`
+	Revision   int        `json:"revision" gorm:"uniqueIndex:idx_srs_revision;not null"`
+	Title      string     `json:"title"`
+	Version    string     `json:"version"`
+	Content    string     `json:"content" gorm:"type:text"`
+	Status     string     `json:"status"`
+	Author     string     `json:"author"`
+	ApprovedBy string     `json:"approved_by"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Revisions are removed together with their SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
 // SRSSection represents a section in the SRS document
 type SRSSection struct {
 	Title       string       `json:"title"`
 	Content     string       `json:"content"`
 	Subsections []SRSSection `json:"subsections,omitempty"`
 }
+
+// Snapshot returns the current state of the SRS as a new revision
+func (s *SRS) Snapshot(revision int) *SRSRevision {
+	return &SRSRevision{
+		SRSID:      s.ID,
+		Revision:   revision,
+		Title:      s.Title,
+		Version:    s.Version,
+		Content:    s.Content,
+		Status:     s.Status,
+		Author:     s.Author,
+		ApprovedBy: s.ApprovedBy,
+		ApprovedAt: s.ApprovedAt,
+	}
+}
+
+// AtRevision returns a copy of the SRS as it was at the given revision
+func (s *SRS) AtRevision(rev *SRSRevision) *SRS {
+	c := *s
+	c.Title = rev.Title
+	c.Version = rev.Version
+	c.Content = rev.Content
+	c.Status = rev.Status
+	c.Author = rev.Author
+	c.ApprovedBy = rev.ApprovedBy
+	c.ApprovedAt = rev.ApprovedAt
+	c.UpdatedAt = rev.CreatedAt
+	return &c
+}
+
+// ExportMeta builds the cover page and approval table fields of the SRS
+func (s *SRS) ExportMeta() ExportMeta {
+	approvalStatus := "Menunggu persetujuan"
+	if s.ApprovedAt != nil {
+		approvalStatus = "Disetujui"
+	}
+
+	created := s.CreatedAt
+	return ExportMeta{
+		Title:    s.Title,
+		Subtitle: "Software Requirements Specification",
+		Version:  s.Version,
+		Author:   s.Author,
+		Status:   s.Status,
+		Date:     s.UpdatedAt,
+		Approvals: []ApprovalEntry{
+			{Role: "Disusun oleh", Name: s.Author, Status: "Selesai", Date: &created},
+			{Role: "Disetujui oleh", Name: s.ApprovedBy, Status: approvalStatus, Date: s.ApprovedAt},
+		},
+	}
+}
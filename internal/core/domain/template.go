@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// DocxTemplate is a corporate .docx/.dotx reference document used when exporting SRS
+type DocxTemplate struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	TenantID  uint      `json:"-" gorm:"index;not null;default:0"`
+	ProjectID uint      `json:"project_id" gorm:"index;default:0"`
+	Name      string    `json:"name" gorm:"not null"`
+	Filename  string    `json:"filename" gorm:"not null"`
+	FilePath  string    `json:"file_path" gorm:"not null"`
+	IsDefault bool      `json:"is_default" gorm:"default:false"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ExportMeta holds the cover and document control fields of an exported SRS
+type ExportMeta struct {
+	Title     string
+	Subtitle  string
+	Version   string
+	Author    string
+	Status    string
+	Date      time.Time
+	Approvals []ApprovalEntry
+}
+
+// ApprovalEntry is a row of the document control (approval) table
+type ApprovalEntry struct {
+	Role   string
+	Name   string
+	Status string
+	Date   *time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// DefaultTenantSlug is the tenant that owns rows created before tenants existed
+const DefaultTenantSlug = "default"
+
+// Tenant is a business unit whose data is isolated from every other tenant
+type Tenant struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Slug string `json:"slug" gorm:"uniqueIndex;not null"`
+	Name string `json:"name" gorm:"not null"`
+	// Admins of an operator tenant manage the other tenants
+	Operator bool `json:"operator"`
+	// Maximum AI requests per calendar month; 0 means unlimited
+	AIMonthlyQuota int       `json:"ai_monthly_quota"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// TenantAICredential is a tenant's own key for an AI provider, used instead
+// of the server-wide key. The key is stored encrypted.
+type TenantAICredential struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	TenantID     uint      `json:"tenant_id" gorm:"uniqueIndex:idx_tenant_ai_provider;not null"`
+	Provider     string    `json:"provider" gorm:"uniqueIndex:idx_tenant_ai_provider;not null"`
+	EncryptedKey string    `json:"-" gorm:"type:text;not null"`
+	Model        string    `json:"model"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TenantAIUsage counts a tenant's AI requests in one calendar month
+type TenantAIUsage struct {
+	ID       uint `json:"-" gorm:"primaryKey"`
+	TenantID uint `json:"tenant_id" gorm:"uniqueIndex:idx_tenant_ai_period;not null"`
+	// Month in "2006-01" form
+	Period    string    `json:"period" gorm:"uniqueIndex:idx_tenant_ai_period;not null"`
+	Requests  int       `json:"requests"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// UseCaseModel is the actor / use case / entity model extracted from an SRS,
+// together with the diagram sources rendered from it
+type UseCaseModel struct {
+	ID        uint          `json:"id" gorm:"primaryKey"`
+	TenantID  uint          `json:"-" gorm:"index;not null;default:0"`
+	SRSID     uint          `json:"srs_id" gorm:"uniqueIndex;not null"`
+	Actors    []Actor       `json:"actors" gorm:"type:jsonb;serializer:json"`
+	UseCases  []UseCase     `json:"use_cases" gorm:"type:jsonb;serializer:json"`
+	Entities  []ModelEntity `json:"entities" gorm:"type:jsonb;serializer:json"`
+	Diagrams  []Diagram     `json:"diagrams" gorm:"type:jsonb;serializer:json"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+
+	// The model is removed together with its SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
+type ActorKind string
+
+const (
+	ActorPrimary   ActorKind = "primary"
+	ActorSecondary ActorKind = "secondary"
+	ActorSystem    ActorKind = "system"
+)
+
+type Actor struct {
+	Name        string    `json:"name"`
+	Kind        ActorKind `json:"kind"`
+	Description string    `json:"description"`
+}
+
+type UseCase struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Names of the actors taking part in the use case
+	Actors []string `json:"actors"`
+	// Codes of the requirements the use case realizes (e.g. FR-001)
+	RequirementCodes []string `json:"requirement_ids"`
+	// IDs of use cases included by / extending this one
+	Includes []string `json:"includes"`
+	Extends  []string `json:"extends"`
+	// Main success scenario, rendered as a sequence diagram
+	Flow []Interaction `json:"flow"`
+}
+
+// Interaction is one message between two participants of a use case flow
+type Interaction struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+type ModelEntity struct {
+	Name       string            `json:"name"`
+	Attributes []EntityAttribute `json:"attributes"`
+	Relations  []EntityRelation  `json:"relations"`
+}
+
+type EntityAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// "PK", "FK" or empty
+	Key string `json:"key,omitempty"`
+}
+
+// Cardinality values of an EntityRelation
+const (
+	CardinalityOneToOne   = "one-to-one"
+	CardinalityOneToMany  = "one-to-many"
+	CardinalityManyToOne  = "many-to-one"
+	CardinalityManyToMany = "many-to-many"
+)
+
+type EntityRelation struct {
+	Target      string `json:"target"`
+	Cardinality string `json:"cardinality"`
+	Label       string `json:"label"`
+}
+
+type DiagramKind string
+
+const (
+	DiagramUseCase  DiagramKind = "usecase"
+	DiagramSequence DiagramKind = "sequence"
+	DiagramER       DiagramKind = "er"
+)
+
+// Diagram is the source text of one diagram in a text-based notation
+type Diagram struct {
+	Kind DiagramKind `json:"kind"`
+	// "mermaid" or "plantuml"
+	Format string `json:"format"`
+	Title  string `json:"title"`
+	Source string `json:"source"`
+}
+
+// FindActor returns the actor with the given name, or nil
+func (m *UseCaseModel) FindActor(name string) *Actor {
+	for i := range m.Actors {
+		if m.Actors[i].Name == name {
+			return &m.Actors[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var slugInvalidRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// UserStory is an AI-generated user story derived from one or more SRS requirements
+type UserStory struct {
+	ID       uint `json:"id" gorm:"primaryKey"`
+	TenantID uint `json:"-" gorm:"index;not null;default:0"`
+	SRSID    uint `json:"srs_id" gorm:"index;not null"`
+	// Codes of the source requirements (e.g. FR-001); codes are stable across SRS edits
+	RequirementCodes []string          `json:"requirement_codes" gorm:"type:jsonb;serializer:json"`
+	Title            string            `json:"title"`
+	Role             string            `json:"as_a"`
+	Goal             string            `json:"i_want" gorm:"type:text"`
+	Benefit          string            `json:"so_that" gorm:"type:text"`
+	Scenarios        []GherkinScenario `json:"scenarios" gorm:"type:jsonb;serializer:json"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+
+	// Stories are removed together with their SRS
+	SRS *SRS `json:"-" gorm:"foreignKey:SRSID;constraint:OnDelete:CASCADE"`
+}
+
+// GherkinScenario is a Given/When/Then acceptance scenario of a user story
+type GherkinScenario struct {
+	Name  string   `json:"name"`
+	Given []string `json:"given"`
+	When  []string `json:"when"`
+	Then  []string `json:"then"`
+}
+
+// LinksTo reports whether the story was derived from the given requirement code
+func (s *UserStory) LinksTo(code string) bool {
+	for _, c := range s.RequirementCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FeatureFilename names the story's .feature file after its first requirement and title
+func (s *UserStory) FeatureFilename() string {
+	name := strings.Trim(slugInvalidRe.ReplaceAllString(strings.ToLower(s.Title), "-"), "-")
+	if len(name) > 60 {
+		name = strings.TrimRight(name[:60], "-")
+	}
+	if len(s.RequirementCodes) > 0 {
+		name = strings.Trim(strings.ToLower(s.RequirementCodes[0])+"-"+name, "-")
+	}
+	if name == "" {
+		name = fmt.Sprintf("story-%d", s.ID)
+	}
+	return name + ".feature"
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// WebhookEvent is a kind of event webhooks can subscribe to
+type WebhookEvent string
+
+const (
+	WebhookDocumentProcessed WebhookEvent = "document.processed"
+	WebhookDocumentFailed    WebhookEvent = "document.failed"
+	WebhookSRSGenerated      WebhookEvent = "srs.generated"
+	WebhookSRSStatusChanged  WebhookEvent = "srs.status_changed"
+	WebhookSRSApproved       WebhookEvent = "srs.approved"
+	// Sent on request to check that a receiver is reachable
+	WebhookPing WebhookEvent = "ping"
+)
+
+// WebhookEvents lists the events a subscription may name
+var WebhookEvents = []WebhookEvent{
+	WebhookDocumentProcessed,
+	WebhookDocumentFailed,
+	WebhookSRSGenerated,
+	WebhookSRSStatusChanged,
+	WebhookSRSApproved,
+}
+
+// Webhook is a subscription that POSTs a signed JSON payload to URL whenever
+// one of its events happens. The secret is stored encrypted.
+type Webhook struct {
+	ID       uint `json:"id" gorm:"primaryKey"`
+	TenantID uint `json:"-" gorm:"index;not null;default:0"`
+	// 0 subscribes to the events of every project
+	ProjectID uint   `json:"project_id" gorm:"index;default:0"`
+	URL       string `json:"url" gorm:"not null"`
+	// Comma-separated WebhookEvent values
+	Events          string    `json:"events" gorm:"not null"`
+	EncryptedSecret string    `json:"-" gorm:"type:text;not null"`
+	Active          bool      `json:"active" gorm:"default:true"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// EventList returns the subscribed events
+func (w *Webhook) EventList() []WebhookEvent {
+	var events []WebhookEvent
+	for _, e := range strings.Split(w.Events, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			events = append(events, WebhookEvent(e))
+		}
+	}
+	return events
+}
+
+// Subscribes reports whether the webhook receives the event
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.EventList() {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is the state of one delivery
+type WebhookDeliveryStatus string
+
+const (
+	// Waiting for its first attempt or a retry
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// Every attempt failed
+	DeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one webhook, kept as the delivery log
+type WebhookDelivery struct {
+	ID        uint                  `json:"id" gorm:"primaryKey"`
+	TenantID  uint                  `json:"-" gorm:"index;not null;default:0"`
+	WebhookID uint                  `json:"webhook_id" gorm:"index;not null"`
+	Event     WebhookEvent          `json:"event" gorm:"not null"`
+	Payload   string                `json:"payload" gorm:"type:text"`
+	Status    WebhookDeliveryStatus `json:"status" gorm:"index:idx_webhook_delivery_due;not null"`
+	Attempts  int                   `json:"attempts"`
+	// Result of the latest attempt
+	ResponseStatus int        `json:"response_status,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty" gorm:"index:idx_webhook_delivery_due"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Deliveries are removed together with their webhook
+	Webhook *Webhook `json:"-" gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookPayload is the JSON body POSTed to a webhook
+type WebhookPayload struct {
+	// ID of the delivery; receivers can use it to ignore retries they already handled
+	ID        uint         `json:"id"`
+	Event     WebhookEvent `json:"event"`
+	ProjectID uint         `json:"project_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      interface{}  `json:"data"`
+}
@@ -1,17 +1,134 @@
 package ports
 
+import (
+	"io"
+	"srs-automation/internal/core/domain"
+	"time"
+)
+
 // AIService defines the interface for AI processing
 type AIService interface {
+	// Info names the provider, model and prompt version behind the responses
+	Info() domain.AIClientInfo
+	// WithUsage returns the same client, reporting the tokens of every request
+	// it sends to report
+	WithUsage(report func(usage domain.AITokenUsage)) AIService
 	// ExtractContent(filePath string, fileType string) (string, error)
-	GenerateSRS(brdContent string) (string, error)
+	// ctx carries the project's output language and glossary
+	GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error)
+	// StreamSRS is GenerateSRS with the output passed to onToken as it is generated
+	StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error)
+	// GenerateUserStories returns a JSON array of user stories for the given requirement list
+	GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error)
+	// ExtractGlossary returns a JSON array of the domain terms defined or used in a BRD
+	ExtractGlossary(brdContent string) (string, error)
+	// ExtractUseCaseModel returns a JSON object with the actors, use cases and data entities of an SRS
+	ExtractUseCaseModel(srsContent string) (string, error)
+	// ExtractDataDictionary returns a JSON array of the data entities mentioned in an SRS
+	ExtractDataDictionary(srsContent string) (string, error)
+	// DraftAPIDesign returns a JSON object with candidate REST operations and schemas
+	DraftAPIDesign(requirements string, entities string) (string, error)
 	// AnalyzeDocument(content string) (map[string]interface{}, error)
 	// CreateGoogleDoc(title string, srsContent string, folderID string) (string, error)
-	GenerateDocxFile(content string, filename string) (string, error)
 }
 
 // FileStorageService defines the interface for file operations
 type FileStorageService interface {
 	SaveFile(filename string, data []byte) (string, error)
+	SaveOutput(filename string, data []byte) (string, error)
 	GetFile(filepath string) ([]byte, error)
 	DeleteFile(filepath string) error
+	ListFiles() ([]domain.StoredFile, error)
+}
+
+// URLSigner defines the interface for signing and verifying download URLs
+type URLSigner interface {
+	Sign(payload string, expiresAt time.Time) string
+	Verify(payload string, expiresAt time.Time, signature string) bool
+}
+
+// Exporter defines the interface for rendering an SRS into one file format
+type Exporter interface {
+	// Format is the short format name, also used as the file extension
+	Format() string
+	MediaType() string
+	Export(w io.Writer, doc *domain.ExportDocument) error
+}
+
+// TemplateValidator defines the interface for checking uploaded DOCX reference templates
+type TemplateValidator interface {
+	ValidateTemplate(data []byte) error
+}
+
+// IssueExporter defines the interface for writing requirements in an issue-tracker import format
+type IssueExporter interface {
+	Format() string
+	Extension() string
+	MediaType() string
+	Export(w io.Writer, issues []domain.TrackerIssue) error
+}
+
+// IssueTrackerClient defines the interface for creating issues in an external tracker
+type IssueTrackerClient interface {
+	CreateIssue(issue domain.TrackerIssue) (*domain.TrackerIssueRef, error)
+}
+
+// FeatureWriter defines the interface for writing user stories as Gherkin .feature files
+type FeatureWriter interface {
+	WriteFeature(w io.Writer, story domain.UserStory) error
+	WriteArchive(w io.Writer, stories []domain.UserStory) error
+}
+
+// DiagramRenderer defines the interface for rendering a use case model as diagram source text
+type DiagramRenderer interface {
+	// Format names the diagram notation, e.g. "mermaid"
+	Format() string
+	Render(model *domain.UseCaseModel) []domain.Diagram
+}
+
+// APISpecRenderer defines the interface for rendering and validating an OpenAPI document
+type APISpecRenderer interface {
+	// MediaType of the rendered document, e.g. "application/yaml"
+	MediaType() string
+	Render(spec *domain.APISpec) ([]byte, error)
+}
+
+// TokenIssuer defines the interface for issuing and verifying user access tokens
+type TokenIssuer interface {
+	Issue(claims domain.TokenClaims) (string, error)
+	Parse(token string) (*domain.TokenClaims, error)
+}
+
+// SecretCipher defines the interface for encrypting secrets stored in the database
+type SecretCipher interface {
+	Encrypt(plain string) (string, error)
+	Decrypt(encrypted string) (string, error)
+}
+
+// AIClientFactory defines the interface for creating AI clients from tenant credentials
+type AIClientFactory interface {
+	// Providers lists the provider names the factory can create clients for
+	Providers() []string
+	NewClient(provider string, apiKey string, model string) (AIService, error)
+}
+
+// ProgressBus defines the interface for broadcasting document processing progress
+type ProgressBus interface {
+	Publish(event domain.ProgressEvent)
+	// Subscribe returns the events published for a document from now on; the
+	// returned func ends the subscription
+	Subscribe(documentID uint) (<-chan domain.ProgressEvent, func())
+	// Last returns the latest event of a document other than a token
+	Last(documentID uint) (domain.ProgressEvent, bool)
+}
+
+// WebhookSender defines the interface for POSTing webhook payloads to receivers
+type WebhookSender interface {
+	// Send returns the receiver's response status code
+	Send(url string, body []byte, headers map[string]string) (int, error)
+}
+
+// Mailer defines the interface for sending plain-text email
+type Mailer interface {
+	Send(to string, subject string, body string) error
 }
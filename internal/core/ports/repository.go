@@ -1,12 +1,19 @@
 package ports
 
-import "srs-automation/internal/core/domain"
+import (
+	"srs-automation/internal/core/domain"
+	"time"
+)
 
 // DocumentRepository defines the interface for document data access
 type DocumentRepository interface {
 	Create(doc *domain.Document) error
 	FindByID(id uint) (*domain.Document, error)
 	FindAll() ([]domain.Document, error)
+	FindByProject(projectID uint) ([]domain.Document, error)
+	// FindPage returns up to Page.Limit+1 documents, the extra one only
+	// telling that another page follows, and the total matching the filter
+	FindPage(filter domain.DocumentFilter) ([]domain.Document, int64, error)
 	Update(doc *domain.Document) error
 	Delete(id uint) error
 }
@@ -17,6 +24,222 @@ type SRSRepository interface {
 	FindByID(id uint) (*domain.SRS, error)
 	FindByDocumentID(docID uint) ([]domain.SRS, error)
 	FindAll() ([]domain.SRS, error)
+	FindByProject(projectID uint) ([]domain.SRS, error)
+	// FindPage works like DocumentRepository.FindPage
+	FindPage(filter domain.SRSFilter) ([]domain.SRS, int64, error)
+	FindOrphaned() ([]domain.SRS, error)
+	// CountOrphanedDependents and DeleteOrphanedDependents find the rows of
+	// SRS-owned tables whose SRS is gone
+	CountOrphanedDependents() (map[string]int64, error)
+	DeleteOrphanedDependents() (map[string]int64, error)
 	Update(srs *domain.SRS) error
+	// Delete and DeleteByDocumentID also delete the rows that belong to the SRS
 	Delete(id uint) error
+	DeleteByDocumentID(docID uint) error
+}
+
+// FileAccessLogRepository defines the interface for download access logs
+type FileAccessLogRepository interface {
+	Create(log *domain.FileAccessLog) error
+	FindByDocumentID(docID uint) ([]domain.FileAccessLog, error)
+}
+
+// DocxTemplateRepository defines the interface for export template data access
+type DocxTemplateRepository interface {
+	Create(tmpl *domain.DocxTemplate) error
+	FindByID(id uint) (*domain.DocxTemplate, error)
+	FindByProject(projectID uint) ([]domain.DocxTemplate, error)
+	FindDefault(projectID uint) (*domain.DocxTemplate, error)
+	FindAll() ([]domain.DocxTemplate, error)
+	Update(tmpl *domain.DocxTemplate) error
+	ClearDefault(projectID uint) error
+	Delete(id uint) error
+}
+
+// SRSRevisionRepository defines the interface for SRS revision history
+type SRSRevisionRepository interface {
+	Create(rev *domain.SRSRevision) error
+	FindBySRSID(srsID uint) ([]domain.SRSRevision, error)
+	FindByRevision(srsID uint, revision int) (*domain.SRSRevision, error)
+	LatestRevision(srsID uint) (int, error)
+}
+
+// RequirementRepository defines the interface for requirement data access
+type RequirementRepository interface {
+	Create(req *domain.Requirement) error
+	FindBySRSID(srsID uint) ([]domain.Requirement, error)
+	Update(req *domain.Requirement) error
+	Delete(id uint) error
+}
+
+// UserStoryRepository defines the interface for user story data access
+type UserStoryRepository interface {
+	Create(story *domain.UserStory) error
+	FindByID(id uint) (*domain.UserStory, error)
+	FindBySRSID(srsID uint) ([]domain.UserStory, error)
+	Delete(id uint) error
+}
+
+// UseCaseModelRepository defines the interface for use case model data access
+type UseCaseModelRepository interface {
+	// Save creates or replaces the model of an SRS
+	Save(model *domain.UseCaseModel) error
+	FindBySRSID(srsID uint) (*domain.UseCaseModel, error)
+}
+
+// APISpecRepository defines the interface for API spec data access
+type APISpecRepository interface {
+	// Save creates or replaces the spec of an SRS
+	Save(spec *domain.APISpec) error
+	FindBySRSID(srsID uint) (*domain.APISpec, error)
+}
+
+// DataEntityRepository defines the interface for data dictionary access
+type DataEntityRepository interface {
+	Create(entity *domain.DataEntity) error
+	FindByID(id uint) (*domain.DataEntity, error)
+	FindBySRSID(srsID uint) ([]domain.DataEntity, error)
+	Update(entity *domain.DataEntity) error
+	Delete(id uint) error
+}
+
+// GlossaryRepository defines the interface for glossary data access
+type GlossaryRepository interface {
+	Create(term *domain.GlossaryTerm) error
+	FindByID(id uint) (*domain.GlossaryTerm, error)
+	FindByProject(projectID uint) ([]domain.GlossaryTerm, error)
+	FindByStatus(projectID uint, status domain.GlossaryTermStatus) ([]domain.GlossaryTerm, error)
+	Update(term *domain.GlossaryTerm) error
+	Delete(id uint) error
+}
+
+// ProjectRepository defines the interface for project data access
+type ProjectRepository interface {
+	Create(project *domain.Project) error
+	FindByID(id uint) (*domain.Project, error)
+	FindByName(name string) (*domain.Project, error)
+	FindAll() ([]domain.Project, error)
+	Update(project *domain.Project) error
+	Delete(id uint) error
+}
+
+// UserRepository defines the interface for user account data access
+type UserRepository interface {
+	Create(user *domain.User) error
+	FindByID(id uint) (*domain.User, error)
+	FindByEmail(email string) (*domain.User, error)
+	FindAll() ([]domain.User, error)
+	Count() (int64, error)
+	Update(user *domain.User) error
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(key *domain.APIKey) error
+	FindByID(id uint) (*domain.APIKey, error)
+	FindByHash(hash string) (*domain.APIKey, error)
+	FindAll() ([]domain.APIKey, error)
+	Update(key *domain.APIKey) error
+}
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	Create(tenant *domain.Tenant) error
+	FindByID(id uint) (*domain.Tenant, error)
+	FindBySlug(slug string) (*domain.Tenant, error)
+	FindAll() ([]domain.Tenant, error)
+	Update(tenant *domain.Tenant) error
+}
+
+// TenantAICredentialRepository defines the interface for per-tenant AI provider keys
+type TenantAICredentialRepository interface {
+	// Save creates or replaces the tenant's key for the credential's provider
+	Save(credential *domain.TenantAICredential) error
+	FindByTenant(tenantID uint) ([]domain.TenantAICredential, error)
+	Delete(tenantID uint, provider string) error
+}
+
+// TenantAIUsageRepository defines the interface for counting tenant AI requests
+type TenantAIUsageRepository interface {
+	// Increment adds one request to the period and returns the new count
+	Increment(tenantID uint, period string) (int, error)
+	FindByPeriod(tenantID uint, period string) (*domain.TenantAIUsage, error)
+	FindByTenant(tenantID uint) ([]domain.TenantAIUsage, error)
+}
+
+// AuditEventRepository defines the interface for the append-only audit trail
+type AuditEventRepository interface {
+	// Append seals the event onto the end of the chain and stores it
+	Append(event *domain.AuditEvent) error
+	Find(filter domain.AuditFilter) ([]domain.AuditEvent, error)
+	// FindChain returns every event in chain order
+	FindChain() ([]domain.AuditEvent, error)
+}
+
+// WebhookRepository defines the interface for webhook subscription access
+type WebhookRepository interface {
+	Create(webhook *domain.Webhook) error
+	FindByID(id uint) (*domain.Webhook, error)
+	FindAll() ([]domain.Webhook, error)
+	// FindActive returns the active webhooks of the project, including those
+	// subscribed to every project
+	FindActive(projectID uint) ([]domain.Webhook, error)
+	Update(webhook *domain.Webhook) error
+	Delete(id uint) error
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log
+type WebhookDeliveryRepository interface {
+	Create(delivery *domain.WebhookDelivery) error
+	Update(delivery *domain.WebhookDelivery) error
+	FindByWebhook(webhookID uint, limit int) ([]domain.WebhookDelivery, error)
+	// ClaimDue returns pending deliveries whose next attempt is due, with
+	// their webhook, and postpones them by lease so no other worker sends them
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+}
+
+// NotificationRepository defines the interface for the email notification queue
+type NotificationRepository interface {
+	Create(notification *domain.Notification) error
+	Update(notification *domain.Notification) error
+	FindRecent(limit int) ([]domain.Notification, error)
+	// ClaimDue returns pending notifications, sent on their own or in digests,
+	// whose next attempt is due, and postpones them by lease so no other
+	// worker sends them
+	ClaimDue(digest bool, now time.Time, lease time.Duration, limit int) ([]domain.Notification, error)
+}
+
+// NotificationPreferenceRepository defines the interface for users' notification preferences
+type NotificationPreferenceRepository interface {
+	// Save upserts the preference of its user
+	Save(pref *domain.NotificationPreference) error
+	FindByUser(userID uint) (*domain.NotificationPreference, error)
+	FindAll() ([]domain.NotificationPreference, error)
+}
+
+// AICacheRepository defines the interface for stored AI responses
+type AICacheRepository interface {
+	// Find returns the entry for key unless it expired before now
+	Find(key string, now time.Time) (*domain.AICacheEntry, error)
+	// Save upserts the entry of its key
+	Save(entry *domain.AICacheEntry) error
+	AddHit(id uint) error
+	Stats() ([]domain.AICacheMethodStats, error)
+	DeleteAll() error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// AIUsageRepository defines the interface for recorded AI calls
+type AIUsageRepository interface {
+	Create(record *domain.AIUsageRecord) error
+	// Summarize adds up the matching records; an empty group gives one total row
+	Summarize(filter domain.AIUsageFilter, group domain.AIUsageGroup) ([]domain.AIUsageSummary, error)
+	TotalCost(projectID uint, since time.Time) (float64, error)
+}
+
+// RedactionReportRepository defines the interface for redaction reports
+type RedactionReportRepository interface {
+	// Save replaces the report of its document
+	Save(report *domain.RedactionReport) error
+	FindByDocument(documentID uint) (*domain.RedactionReport, error)
 }
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AICache stores a tenant's AI responses in the database, optionally with an
+// in-memory LRU in front, so the same call on the same input is only paid once
+type AICache struct {
+	repo     ports.AICacheRepository
+	memory   *AIResponseLRU
+	tenantID uint
+	ttl      time.Duration
+}
+
+// NewAICache takes the tenant's cache repository and the LRU shared by all
+// tenants, which may be nil. A ttl of 0 disables caching.
+func NewAICache(repo ports.AICacheRepository, memory *AIResponseLRU, tenantID uint, ttl time.Duration) *AICache {
+	return &AICache{repo: repo, memory: memory, tenantID: tenantID, ttl: ttl}
+}
+
+// Wrap puts the cache in front of every client
+func (c *AICache) Wrap(clients map[string]ports.AIService) map[string]ports.AIService {
+	if c.ttl <= 0 {
+		return clients
+	}
+	cached := make(map[string]ports.AIService, len(clients))
+	for name, client := range clients {
+		cached[name] = &cachedAI{next: client, cache: c}
+	}
+	return cached
+}
+
+func (c *AICache) Stats() (*domain.AICacheStats, error) {
+	methods, err := c.repo.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.AICacheStats{Methods: methods}
+	for _, m := range methods {
+		stats.Entries += m.Entries
+		stats.Hits += m.Hits
+	}
+	if calls := stats.Hits + stats.Entries; calls > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(calls)
+	}
+	return stats, nil
+}
+
+// Clear drops every cached response of the tenant
+func (c *AICache) Clear() error {
+	if c.memory != nil {
+		c.memory.RemoveTenant(c.tenantID)
+	}
+	return c.repo.DeleteAll()
+}
+
+// RunPurger deletes expired responses every interval until the process exits.
+// It is run once, on a cache built with an unscoped repository.
+func (c *AICache) RunPurger(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := c.repo.DeleteExpired(time.Now())
+		if err != nil {
+			log.Printf("AI cache purge failed: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("AI cache purge removed %d expired responses", deleted)
+		}
+	}
+}
+
+func (c *AICache) lookup(key string) (string, bool) {
+	now := time.Now()
+	if c.memory != nil {
+		if entry, ok := c.memory.Get(c.tenantID, key); ok && entry.ExpiresAt.After(now) {
+			c.countHit(entry.ID)
+			return entry.Response, true
+		}
+	}
+
+	entry, err := c.repo.Find(key, now)
+	if err != nil {
+		return "", false
+	}
+	if c.memory != nil {
+		c.memory.Add(c.tenantID, entry)
+	}
+	c.countHit(entry.ID)
+	return entry.Response, true
+}
+
+func (c *AICache) countHit(id uint) {
+	if err := c.repo.AddHit(id); err != nil {
+		log.Printf("AI cache hit of entry %d not counted: %v", id, err)
+	}
+}
+
+// store keeps a fresh response; failing to store it only costs a later miss
+func (c *AICache) store(info domain.AIClientInfo, method string, key string, response string) {
+	entry := &domain.AICacheEntry{
+		Key:           key,
+		Provider:      info.Provider,
+		Model:         info.Model,
+		PromptVersion: info.PromptVersion,
+		Method:        method,
+		Response:      response,
+		ExpiresAt:     time.Now().Add(c.ttl),
+	}
+	if err := c.repo.Save(entry); err != nil {
+		log.Printf("AI cache store of %s failed: %v", method, err)
+		return
+	}
+	if c.memory != nil {
+		c.memory.Add(c.tenantID, entry)
+	}
+}
+
+// aiCacheKey hashes everything that determines a response
+func aiCacheKey(info domain.AIClientInfo, method string, input ...string) string {
+	fields := append([]string{info.Provider, info.Model, info.PromptVersion, method}, input...)
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedAI answers repeated AI calls from an AICache
+type cachedAI struct {
+	next  ports.AIService
+	cache *AICache
+}
+
+// call returns the cached response for the method and input, or calls fresh
+// and caches its response. noCache skips the lookup but still stores the
+// fresh response.
+func (c *cachedAI) call(method string, noCache bool, fresh func() (string, error), input ...string) (string, error) {
+	info := c.next.Info()
+	key := aiCacheKey(info, method, input...)
+	if !noCache {
+		if response, ok := c.cache.lookup(key); ok {
+			return response, nil
+		}
+	}
+
+	response, err := fresh()
+	if err != nil {
+		return "", err
+	}
+	c.cache.store(info, method, key, response)
+	return response, nil
+}
+
+func (c *cachedAI) Info() domain.AIClientInfo {
+	return c.next.Info()
+}
+
+// WithUsage reports nothing for cached responses, which cost no tokens
+func (c *cachedAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	return &cachedAI{next: c.next.WithUsage(report), cache: c.cache}
+}
+
+func (c *cachedAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return c.call("GenerateSRS", ctx.NoCache, func() (string, error) {
+		return c.next.GenerateSRS(brdContent, ctx)
+	}, brdContent, ctx.Language, ctx.Glossary)
+}
+
+// StreamSRS shares its cache entries with GenerateSRS; a cached response is
+// passed to onToken in one piece
+func (c *cachedAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	hit := true
+	response, err := c.call("GenerateSRS", ctx.NoCache, func() (string, error) {
+		hit = false
+		return c.next.StreamSRS(brdContent, ctx, onToken)
+	}, brdContent, ctx.Language, ctx.Glossary)
+	if err == nil && hit {
+		onToken(response)
+	}
+	return response, err
+}
+
+func (c *cachedAI) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	return c.call("GenerateUserStories", ctx.NoCache, func() (string, error) {
+		return c.next.GenerateUserStories(requirements, ctx)
+	}, requirements, ctx.Language, ctx.Glossary)
+}
+
+func (c *cachedAI) ExtractGlossary(brdContent string) (string, error) {
+	return c.call("ExtractGlossary", false, func() (string, error) {
+		return c.next.ExtractGlossary(brdContent)
+	}, brdContent)
+}
+
+func (c *cachedAI) ExtractUseCaseModel(srsContent string) (string, error) {
+	return c.call("ExtractUseCaseModel", false, func() (string, error) {
+		return c.next.ExtractUseCaseModel(srsContent)
+	}, srsContent)
+}
+
+func (c *cachedAI) ExtractDataDictionary(srsContent string) (string, error) {
+	return c.call("ExtractDataDictionary", false, func() (string, error) {
+		return c.next.ExtractDataDictionary(srsContent)
+	}, srsContent)
+}
+
+func (c *cachedAI) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return c.call("DraftAPIDesign", false, func() (string, error) {
+		return c.next.DraftAPIDesign(requirements, entities)
+	}, requirements, entities)
+}
+
+// AIResponseLRU keeps the most recently used cache entries of all tenants in
+// memory
+type AIResponseLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type lruItem struct {
+	tenantID uint
+	key      string
+	entry    domain.AICacheEntry
+}
+
+func NewAIResponseLRU(capacity int) *AIResponseLRU {
+	return &AIResponseLRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func lruKey(tenantID uint, key string) string {
+	return fmt.Sprintf("%d:%s", tenantID, key)
+}
+
+func (l *AIResponseLRU) Get(tenantID uint, key string) (domain.AICacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.entries[lruKey(tenantID, key)]
+	if !ok {
+		return domain.AICacheEntry{}, false
+	}
+	l.order.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (l *AIResponseLRU) Add(tenantID uint, entry *domain.AICacheEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	k := lruKey(tenantID, entry.Key)
+	if el, ok := l.entries[k]; ok {
+		el.Value.(*lruItem).entry = *entry
+		l.order.MoveToFront(el)
+		return
+	}
+	l.entries[k] = l.order.PushFront(&lruItem{tenantID: tenantID, key: k, entry: *entry})
+
+	for l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// RemoveTenant drops the entries of one tenant
+func (l *AIResponseLRU) RemoveTenant(tenantID uint) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for el := l.order.Front(); el != nil; {
+		next := el.Next()
+		if item := el.Value.(*lruItem); item.tenantID == tenantID {
+			l.order.Remove(el)
+			delete(l.entries, item.key)
+		}
+		el = next
+	}
+}
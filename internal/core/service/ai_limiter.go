@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// estimatedCompletionTokens is what a call is assumed to generate before the
+// provider reports the real usage
+const estimatedCompletionTokens = 1024
+
+// AILimits configures an AILimiter; zero values mean no limit
+type AILimits struct {
+	// Requests per minute to all providers together
+	RequestsPerMinute int
+	// Keyed by provider name
+	Providers map[string]domain.AIProviderLimit
+	// AI calls in flight at the same time, including those waiting for a
+	// rate limit
+	MaxConcurrent int
+}
+
+// ParseAIProviderLimits parses limits in the form
+// "groq:rpm=30,tpm=6000;gemini:rpm=60". Omitted values are unlimited.
+func ParseAIProviderLimits(raw string) (map[string]domain.AIProviderLimit, error) {
+	limits := map[string]domain.AIProviderLimit{}
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		provider, rules, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid AI provider limit %q", entry)
+		}
+
+		var limit domain.AIProviderLimit
+		for _, rule := range strings.Split(rules, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(rule), "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid AI provider limit rule %q", rule)
+			}
+
+			amount, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || amount < 0 {
+				return nil, fmt.Errorf("invalid AI provider limit %q", value)
+			}
+
+			switch strings.TrimSpace(key) {
+			case "rpm":
+				limit.RequestsPerMinute = amount
+			case "tpm":
+				limit.TokensPerMinute = amount
+			default:
+				return nil, fmt.Errorf("unknown AI provider limit rule %q", key)
+			}
+		}
+
+		limits[strings.ToLower(strings.TrimSpace(provider))] = limit
+	}
+
+	return limits, nil
+}
+
+// AILimiter paces the AI calls of the whole server with token buckets, so
+// bursts of uploads queue up instead of being refused by the providers.
+// Limits are per provider name and also cover clients for tenants' own keys.
+type AILimiter struct {
+	// nil when unlimited
+	global   *rate.Limiter
+	requests map[string]*rate.Limiter
+	tokens   map[string]*rate.Limiter
+	slots    chan struct{}
+}
+
+func NewAILimiter(limits AILimits) *AILimiter {
+	l := &AILimiter{
+		global:   perMinute(limits.RequestsPerMinute),
+		requests: map[string]*rate.Limiter{},
+		tokens:   map[string]*rate.Limiter{},
+	}
+	for provider, limit := range limits.Providers {
+		if limiter := perMinute(limit.RequestsPerMinute); limiter != nil {
+			l.requests[provider] = limiter
+		}
+		if limiter := perMinute(limit.TokensPerMinute); limiter != nil {
+			l.tokens[provider] = limiter
+		}
+	}
+	if limits.MaxConcurrent > 0 {
+		l.slots = make(chan struct{}, limits.MaxConcurrent)
+	}
+	return l
+}
+
+// perMinute is a bucket refilled at n per minute that holds up to a minute's worth
+func perMinute(n int) *rate.Limiter {
+	if n <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(float64(n)/60), n)
+}
+
+// Wrap makes every client wait for the limits before calling its provider
+func (l *AILimiter) Wrap(clients map[string]ports.AIService) map[string]ports.AIService {
+	limited := make(map[string]ports.AIService, len(clients))
+	for name, client := range clients {
+		limited[name] = &limitedAI{next: client, limiter: l}
+	}
+	return limited
+}
+
+// acquire blocks until a call with about estimate tokens may be sent to the
+// provider. The returned release takes the tokens the call really used, so
+// an underestimate is paid back by the following calls.
+func (l *AILimiter) acquire(provider string, estimate int) (release func(used int)) {
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+
+	wait(l.global, 1)
+	wait(l.requests[provider], 1)
+	tokens := l.tokens[provider]
+	wait(tokens, estimate)
+
+	return func(used int) {
+		if tokens != nil && used > estimate {
+			tokens.ReserveN(time.Now(), min(used-estimate, tokens.Burst()))
+		}
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+}
+
+// wait takes n tokens from limiter, which may be nil; n is capped at the
+// bucket size so a large call waits for a full bucket instead of failing
+func wait(limiter *rate.Limiter, n int) {
+	if limiter == nil {
+		return
+	}
+	limiter.WaitN(context.Background(), min(n, limiter.Burst()))
+}
+
+// estimateTokens guesses the tokens of a call from its input at about four
+// characters per token
+func estimateTokens(input ...string) int {
+	chars := 0
+	for _, s := range input {
+		chars += len(s)
+	}
+	return chars/4 + estimatedCompletionTokens
+}
+
+// limitedAI holds each AI call until the AILimiter lets it through
+type limitedAI struct {
+	next    ports.AIService
+	limiter *AILimiter
+	// Also receives the token usage; may be nil
+	report func(usage domain.AITokenUsage)
+}
+
+func (l *limitedAI) call(fn func(client ports.AIService) (string, error), input ...string) (string, error) {
+	estimate := estimateTokens(input...)
+	release := l.limiter.acquire(l.next.Info().Provider, estimate)
+
+	used := 0
+	client := l.next.WithUsage(func(usage domain.AITokenUsage) {
+		used = usage.PromptTokens + usage.CompletionTokens
+		if l.report != nil {
+			l.report(usage)
+		}
+	})
+	response, err := fn(client)
+	release(used)
+	return response, err
+}
+
+func (l *limitedAI) Info() domain.AIClientInfo {
+	return l.next.Info()
+}
+
+func (l *limitedAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	reporting := *l
+	reporting.report = report
+	return &reporting
+}
+
+func (l *limitedAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.GenerateSRS(brdContent, ctx)
+	}, brdContent, ctx.Glossary)
+}
+
+func (l *limitedAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.StreamSRS(brdContent, ctx, onToken)
+	}, brdContent, ctx.Glossary)
+}
+
+func (l *limitedAI) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.GenerateUserStories(requirements, ctx)
+	}, requirements, ctx.Glossary)
+}
+
+func (l *limitedAI) ExtractGlossary(brdContent string) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.ExtractGlossary(brdContent)
+	}, brdContent)
+}
+
+func (l *limitedAI) ExtractUseCaseModel(srsContent string) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.ExtractUseCaseModel(srsContent)
+	}, srsContent)
+}
+
+func (l *limitedAI) ExtractDataDictionary(srsContent string) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.ExtractDataDictionary(srsContent)
+	}, srsContent)
+}
+
+func (l *limitedAI) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return l.call(func(client ports.AIService) (string, error) {
+		return client.DraftAPIDesign(requirements, entities)
+	}, requirements, entities)
+}
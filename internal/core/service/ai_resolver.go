@@ -0,0 +1,67 @@
+package service
+
+import (
+	"sort"
+	"srs-automation/internal/core/ports"
+)
+
+// AIResolver picks the AI provider configured for a project, falling back to
+// the server default when the project has none or names an unknown provider.
+// The returned clients redact sensitive values and record their calls for
+// the project.
+type AIResolver struct {
+	projects  ports.ProjectRepository
+	providers map[string]ports.AIService
+	fallback  string
+	usage     *AIUsageService
+	redaction *RedactionService
+}
+
+func NewAIResolver(projects ports.ProjectRepository, providers map[string]ports.AIService, fallback string, usage *AIUsageService, redaction *RedactionService) *AIResolver {
+	return &AIResolver{
+		projects:  projects,
+		providers: providers,
+		fallback:  fallback,
+		usage:     usage,
+		redaction: redaction,
+	}
+}
+
+func (r *AIResolver) ForProject(projectID uint) ports.AIService {
+	return r.ForDocument(projectID, 0)
+}
+
+// ForDocument is ForProject with the calls also counted for a document
+func (r *AIResolver) ForDocument(projectID uint, documentID uint) ports.AIService {
+	client := r.providers[r.fallback]
+	var terms []string
+	if project, err := r.projects.FindByID(projectID); err == nil {
+		if provider, ok := r.providers[project.AIProvider]; ok {
+			client = provider
+		}
+		terms = project.RedactionTerms
+	}
+	return r.usage.Wrap(r.redaction.Wrap(client, terms, documentID), projectID, documentID)
+}
+
+// CheckBudget fails with ErrAIBudgetExceeded once the project's AI cost this
+// month has reached its budget
+func (r *AIResolver) CheckBudget(projectID uint) error {
+	return r.usage.checkBudget(projectID)
+}
+
+// IsProvider reports whether a provider name can be used in project settings
+func (r *AIResolver) IsProvider(name string) bool {
+	_, ok := r.providers[name]
+	return ok
+}
+
+// Providers lists the configured provider names
+func (r *AIResolver) Providers() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
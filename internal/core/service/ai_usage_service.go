@@ -0,0 +1,266 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAIBudgetExceeded   = errors.New("monthly AI budget of the project is used up")
+	ErrInvalidUsageFilter = errors.New("invalid AI usage filter")
+)
+
+// AIUsageService records the tokens, latency and cost of every AI call and
+// enforces the monthly AI budget of projects
+type AIUsageService struct {
+	repo     ports.AIUsageRepository
+	projects ports.ProjectRepository
+	// Keyed by "provider/model"
+	prices map[string]domain.AIPrice
+}
+
+func NewAIUsageService(repo ports.AIUsageRepository, projects ports.ProjectRepository, prices map[string]domain.AIPrice) *AIUsageService {
+	return &AIUsageService{repo: repo, projects: projects, prices: prices}
+}
+
+// ParseAIPrices parses a price table in the form
+// "groq/llama-3.3-70b-versatile:input=0.59,output=0.79;gemini/gemini-pro:input=0.5,output=1.5",
+// in USD per million tokens. Models without a price cost nothing.
+func ParseAIPrices(raw string) (map[string]domain.AIPrice, error) {
+	prices := map[string]domain.AIPrice{}
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		model, rules, ok := strings.Cut(entry, ":")
+		if !ok || !strings.Contains(model, "/") {
+			return nil, fmt.Errorf("invalid AI price %q", entry)
+		}
+
+		var price domain.AIPrice
+		for _, rule := range strings.Split(rules, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(rule), "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid AI price rule %q", rule)
+			}
+
+			amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || amount < 0 {
+				return nil, fmt.Errorf("invalid AI price %q", value)
+			}
+
+			switch strings.TrimSpace(key) {
+			case "input":
+				price.Input = amount
+			case "output":
+				price.Output = amount
+			default:
+				return nil, fmt.Errorf("unknown AI price rule %q", key)
+			}
+		}
+
+		prices[strings.TrimSpace(model)] = price
+	}
+
+	return prices, nil
+}
+
+// Wrap makes every client record its calls for the project and document
+func (s *AIUsageService) Wrap(client ports.AIService, projectID uint, documentID uint) ports.AIService {
+	return &accountedAI{next: client, usage: s, projectID: projectID, documentID: documentID}
+}
+
+// Report adds up the AI usage matching filter, broken down by group
+func (s *AIUsageService) Report(filter domain.AIUsageFilter, group domain.AIUsageGroup) (*domain.AIUsageReport, error) {
+	if !group.Valid() {
+		return nil, fmt.Errorf("%w: group must be day, project, document or model", ErrInvalidUsageFilter)
+	}
+
+	totals, err := s.repo.Summarize(filter, "")
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.repo.Summarize(filter, group)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.AIUsageReport{Group: group, Groups: groups}
+	if len(totals) > 0 {
+		report.Total = totals[0]
+	}
+	if filter.ProjectID != 0 && filter.DocumentID == 0 {
+		if report.Budget, err = s.Budget(filter.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// Budget reports the project's AI cost in the current month against its budget
+func (s *AIUsageService) Budget(projectID uint) (*domain.AIBudgetStatus, error) {
+	project, err := s.projects.FindByID(projectID)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	now := time.Now()
+	spent, err := s.repo.TotalCost(projectID, monthStart(now))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AIBudgetStatus{
+		Period:   usagePeriod(now),
+		Budget:   project.MonthlyAIBudget,
+		Spent:    spent,
+		Exceeded: project.MonthlyAIBudget > 0 && spent >= project.MonthlyAIBudget,
+	}, nil
+}
+
+func (s *AIUsageService) checkBudget(projectID uint) error {
+	status, err := s.Budget(projectID)
+	if err != nil {
+		return err
+	}
+	if status.Exceeded {
+		return fmt.Errorf("%w: spent %.4f of %.4f USD in %s", ErrAIBudgetExceeded, status.Spent, status.Budget, status.Period)
+	}
+	return nil
+}
+
+func (s *AIUsageService) cost(info domain.AIClientInfo, tokens domain.AITokenUsage) float64 {
+	price := s.prices[info.Provider+"/"+info.Model]
+	return (float64(tokens.PromptTokens)*price.Input + float64(tokens.CompletionTokens)*price.Output) / 1e6
+}
+
+// record stores one call; a lost record only under-reports usage
+func (s *AIUsageService) record(record *domain.AIUsageRecord) {
+	if err := s.repo.Create(record); err != nil {
+		log.Printf("AI usage of %s not recorded: %v", record.Method, err)
+	}
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// accountedAI records the calls of an AI client for a project and refuses
+// them once the project's monthly budget is used up
+type accountedAI struct {
+	next       ports.AIService
+	usage      *AIUsageService
+	projectID  uint
+	documentID uint
+	// Also receives the token usage; may be nil
+	report func(usage domain.AITokenUsage)
+}
+
+// call runs fn on a client that reports its token usage and records the call.
+// Clients report every request they make, as unknown usage when the provider
+// left the counts out, so such calls still count. Calls answered without a request to the provider, such as cache hits or
+// calls refused by the tenant quota, are not recorded.
+func (a *accountedAI) call(method string, fn func(client ports.AIService) (string, error)) (string, error) {
+	if err := a.usage.checkBudget(a.projectID); err != nil {
+		return "", err
+	}
+
+	var tokens domain.AITokenUsage
+	requested := false
+	client := a.next.WithUsage(func(usage domain.AITokenUsage) {
+		tokens = usage
+		requested = true
+		if a.report != nil {
+			a.report(usage)
+		}
+	})
+
+	start := time.Now()
+	response, err := fn(client)
+	if err != nil && !errors.Is(err, ErrAIQuotaExceeded) {
+		requested = true
+	}
+	if !requested {
+		return response, err
+	}
+
+	info := a.next.Info()
+	record := &domain.AIUsageRecord{
+		ProjectID:        a.projectID,
+		DocumentID:       a.documentID,
+		Provider:         info.Provider,
+		Model:            info.Model,
+		Method:           method,
+		PromptTokens:     tokens.PromptTokens,
+		CompletionTokens: tokens.CompletionTokens,
+		LatencyMs:        time.Since(start).Milliseconds(),
+		Cost:             a.usage.cost(info, tokens),
+		UsageUnknown:     tokens.Unknown,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	a.usage.record(record)
+	return response, err
+}
+
+func (a *accountedAI) Info() domain.AIClientInfo {
+	return a.next.Info()
+}
+
+func (a *accountedAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	reporting := *a
+	reporting.report = report
+	return &reporting
+}
+
+func (a *accountedAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return a.call("GenerateSRS", func(client ports.AIService) (string, error) {
+		return client.GenerateSRS(brdContent, ctx)
+	})
+}
+
+func (a *accountedAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return a.call("StreamSRS", func(client ports.AIService) (string, error) {
+		return client.StreamSRS(brdContent, ctx, onToken)
+	})
+}
+
+func (a *accountedAI) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	return a.call("GenerateUserStories", func(client ports.AIService) (string, error) {
+		return client.GenerateUserStories(requirements, ctx)
+	})
+}
+
+func (a *accountedAI) ExtractGlossary(brdContent string) (string, error) {
+	return a.call("ExtractGlossary", func(client ports.AIService) (string, error) {
+		return client.ExtractGlossary(brdContent)
+	})
+}
+
+func (a *accountedAI) ExtractUseCaseModel(srsContent string) (string, error) {
+	return a.call("ExtractUseCaseModel", func(client ports.AIService) (string, error) {
+		return client.ExtractUseCaseModel(srsContent)
+	})
+}
+
+func (a *accountedAI) ExtractDataDictionary(srsContent string) (string, error) {
+	return a.call("ExtractDataDictionary", func(client ports.AIService) (string, error) {
+		return client.ExtractDataDictionary(srsContent)
+	})
+}
+
+func (a *accountedAI) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return a.call("DraftAPIDesign", func(client ports.AIService) (string, error) {
+		return client.DraftAPIDesign(requirements, entities)
+	})
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrAPISpecNotFound = errors.New("API spec not found, generate it first")
+	ErrInvalidAPISpec  = errors.New("generated API spec is not a valid OpenAPI document")
+)
+
+var operationIDInvalidRe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// entityAttributeTypes maps the free-form attribute types of the use case
+// model to the property types of the API design
+var entityAttributeTypes = map[string]string{
+	"int":       "integer",
+	"integer":   "integer",
+	"bigint":    "integer",
+	"float":     "number",
+	"decimal":   "number",
+	"number":    "number",
+	"bool":      "boolean",
+	"boolean":   "boolean",
+	"date":      "date",
+	"datetime":  "date-time",
+	"date-time": "date-time",
+	"timestamp": "date-time",
+}
+
+type APISpecService struct {
+	repo         ports.APISpecRepository
+	srsRepo      ports.SRSRepository
+	modelRepo    ports.UseCaseModelRepository
+	requirements *RequirementService
+	ai           *AIResolver
+	renderer     ports.APISpecRenderer
+}
+
+func NewAPISpecService(
+	repo ports.APISpecRepository,
+	srsRepo ports.SRSRepository,
+	modelRepo ports.UseCaseModelRepository,
+	requirements *RequirementService,
+	ai *AIResolver,
+	renderer ports.APISpecRenderer,
+) *APISpecService {
+	return &APISpecService{
+		repo:         repo,
+		srsRepo:      srsRepo,
+		modelRepo:    modelRepo,
+		requirements: requirements,
+		ai:           ai,
+		renderer:     renderer,
+	}
+}
+
+// aiAPIDesign is the JSON shape requested from the AI provider
+type aiAPIDesign struct {
+	Operations []domain.APIOperation `json:"operations"`
+	Schemas    []domain.APISchema    `json:"schemas"`
+}
+
+// GenerateSpec drafts an OpenAPI document from the functional requirements of
+// an SRS and, when a use case model was extracted, its data entities. Entity
+// schemas take precedence over schemas proposed by the AI provider. Operations
+// that can't be traced to a functional requirement are dropped.
+func (s *APISpecService) GenerateSpec(srsID uint) (*domain.APISpec, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	requirements, err := s.requirements.GetRequirements(srsID)
+	if err != nil {
+		return nil, err
+	}
+	var functional []domain.Requirement
+	codes := map[string]bool{}
+	for _, req := range requirements {
+		if req.Type == domain.RequirementFunctional {
+			functional = append(functional, req)
+			codes[req.Code] = true
+		}
+	}
+	if len(functional) == 0 {
+		return nil, ErrNoRequirements
+	}
+
+	var entities []domain.ModelEntity
+	if model, err := s.modelRepo.FindBySRSID(srsID); err == nil {
+		entities = model.Entities
+	}
+
+	response, err := s.ai.ForDocument(srs.ProjectID, srs.SourceDocumentID).DraftAPIDesign(requirementList(functional), entityList(entities))
+	if err != nil {
+		return nil, fmt.Errorf("gagal generate desain API: %w", err)
+	}
+
+	design, err := parseAPIDesign(response)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &domain.APISpec{
+		SRSID:   srsID,
+		Title:   srs.Title + " API",
+		Version: srs.Version,
+	}
+	for _, entity := range entities {
+		spec.Schemas = append(spec.Schemas, entitySchema(entity))
+	}
+	for _, schema := range design.Schemas {
+		schema.Name = pascalCase(schema.Name)
+		if schema.Name != "" && spec.FindSchema(schema.Name) == nil {
+			spec.Schemas = append(spec.Schemas, schema)
+		}
+	}
+
+	seen := map[string]bool{}
+	usedIDs := map[string]int{}
+	for _, op := range design.Operations {
+		op.Method = strings.ToUpper(strings.TrimSpace(op.Method))
+		op.Path = "/" + strings.Trim(strings.TrimSpace(op.Path), "/")
+		if !isHTTPMethod(op.Method) || seen[op.Method+" "+op.Path] {
+			continue
+		}
+
+		var linked []string
+		for _, code := range op.RequirementCodes {
+			code = strings.ToUpper(strings.TrimSpace(code))
+			if codes[code] && !containsCode(linked, code) {
+				linked = append(linked, code)
+			}
+		}
+		if len(linked) == 0 {
+			continue
+		}
+		op.RequirementCodes = linked
+		op.RequestSchema = pascalCase(op.RequestSchema)
+		op.ResponseSchema = pascalCase(op.ResponseSchema)
+		seen[op.Method+" "+op.Path] = true
+
+		op.OperationID = operationIDInvalidRe.ReplaceAllString(op.OperationID, "")
+		if op.OperationID == "" {
+			op.OperationID = strings.ToLower(op.Method) + pascalCase(op.Path)
+		}
+		if n := usedIDs[op.OperationID]; n > 0 {
+			usedIDs[op.OperationID]++
+			op.OperationID = fmt.Sprintf("%s%d", op.OperationID, n+1)
+		} else {
+			usedIDs[op.OperationID] = 1
+		}
+
+		spec.Operations = append(spec.Operations, op)
+	}
+	if len(spec.Operations) == 0 {
+		return nil, fmt.Errorf("%w: no operation references a functional requirement", ErrInvalidAIResponse)
+	}
+
+	document, err := s.renderer.Render(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAPISpec, err)
+	}
+	spec.Document = string(document)
+
+	if err := s.repo.Save(spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (s *APISpecService) GetSpec(srsID uint) (*domain.APISpec, error) {
+	spec, err := s.repo.FindBySRSID(srsID)
+	if err != nil {
+		return nil, ErrAPISpecNotFound
+	}
+	return spec, nil
+}
+
+// ExportSpec returns the stored OpenAPI document as a file
+func (s *APISpecService) ExportSpec(srsID uint) (*ExportResult, error) {
+	spec, err := s.GetSpec(srsID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{
+		Data:        []byte(spec.Document),
+		Filename:    fmt.Sprintf("SRS-%d-v%s-openapi.yaml", spec.SRSID, spec.Version),
+		ContentType: s.renderer.MediaType(),
+	}, nil
+}
+
+// entityList formats data entities as the prompt input for API design
+func entityList(entities []domain.ModelEntity) string {
+	if len(entities) == 0 {
+		return "(belum ada, turunkan dari requirement)"
+	}
+
+	var b strings.Builder
+	for _, entity := range entities {
+		fmt.Fprintf(&b, "- %s", entity.Name)
+		var attrs []string
+		for _, attr := range entity.Attributes {
+			attr := attr.Name + " " + attr.Type
+			attrs = append(attrs, strings.TrimSpace(attr))
+		}
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, ": %s", strings.Join(attrs, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// entitySchema turns a data entity of the use case model into an API schema
+func entitySchema(entity domain.ModelEntity) domain.APISchema {
+	schema := domain.APISchema{Name: pascalCase(entity.Name)}
+	for _, attr := range entity.Attributes {
+		t, ok := entityAttributeTypes[strings.ToLower(attr.Type)]
+		if !ok {
+			t = "string"
+		}
+		schema.Properties = append(schema.Properties, domain.APIProperty{
+			Name:     attr.Name,
+			Type:     t,
+			Required: attr.Key == "PK",
+		})
+	}
+	return schema
+}
+
+// pascalCase joins the words of a name, each starting with an upper case letter
+func pascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(operationIDInvalidRe.ReplaceAllString(name, " ")) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// parseAPIDesign extracts the JSON object from an AI response, tolerating
+// code fences or text around it
+func parseAPIDesign(response string) (*aiAPIDesign, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, ErrInvalidAIResponse
+	}
+
+	var design aiAPIDesign
+	if err := json.Unmarshal([]byte(response[start:end+1]), &design); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAIResponse, err)
+	}
+	if len(design.Operations) == 0 {
+		return nil, ErrInvalidAIResponse
+	}
+	return &design, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strconv"
+	"time"
+)
+
+// maxAuditEvents caps a single query or export
+const maxAuditEvents = 10000
+
+type AuditService struct {
+	repo ports.AuditEventRepository
+}
+
+func NewAuditService(repo ports.AuditEventRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record appends an event to the audit trail. before and after are state
+// hashes from auditHash.
+func (s *AuditService) Record(actor domain.AuditActor, action domain.AuditAction, entityType string, entityID uint, before string, after string) error {
+	event := &domain.AuditEvent{
+		ActorType:  actor.Type,
+		ActorID:    actor.ID,
+		ActorName:  actor.Name,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		BeforeHash: before,
+		AfterHash:  after,
+		IP:         actor.IP,
+		RequestID:  actor.RequestID,
+		// Postgres keeps microseconds; the hash must match the stored value
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if err := s.repo.Append(event); err != nil {
+		return fmt.Errorf("gagal mencatat audit: %w", err)
+	}
+	return nil
+}
+
+func (s *AuditService) GetEvents(filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if filter.Limit <= 0 || filter.Limit > maxAuditEvents {
+		filter.Limit = maxAuditEvents
+	}
+	return s.repo.Find(filter)
+}
+
+// ExportCSV writes the selected events as CSV, oldest first
+func (s *AuditService) ExportCSV(filter domain.AuditFilter) (*ExportResult, error) {
+	events, err := s.GetEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{
+		"id", "created_at", "actor_type", "actor_id", "actor_name", "action", "entity_type", "entity_id",
+		"before_hash", "after_hash", "ip", "request_id", "prev_hash", "hash",
+	})
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		w.Write([]string{
+			strconv.FormatUint(uint64(e.ID), 10),
+			e.CreatedAt.UTC().Format(time.RFC3339Nano),
+			e.ActorType,
+			strconv.FormatUint(uint64(e.ActorID), 10),
+			e.ActorName,
+			string(e.Action),
+			e.EntityType,
+			strconv.FormatUint(uint64(e.EntityID), 10),
+			e.BeforeHash,
+			e.AfterHash,
+			e.IP,
+			e.RequestID,
+			e.PrevHash,
+			e.Hash,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{
+		Data:        buf.Bytes(),
+		Filename:    fmt.Sprintf("audit-events-%s.csv", time.Now().Format("20060102-150405")),
+		ContentType: "text/csv",
+	}, nil
+}
+
+// Verify recomputes the hash chain and reports the first event that was
+// changed, removed or inserted out of order
+func (s *AuditService) Verify() (*domain.AuditVerification, error) {
+	events, err := s.repo.FindChain()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.AuditVerification{Valid: true, Events: len(events)}
+	prev := ""
+	for i := range events {
+		e := &events[i]
+		switch {
+		case e.PrevHash != prev:
+			result.Reason = "event is not linked to the previous event"
+		case e.Hash != e.ComputeHash():
+			result.Reason = "event content doesn't match its hash"
+		default:
+			prev = e.Hash
+			continue
+		}
+		result.Valid = false
+		result.BrokenAt = &e.ID
+		break
+	}
+	return result, nil
+}
+
+// auditHash fingerprints an entity's state for the audit trail
+func auditHash(entity interface{}) string {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
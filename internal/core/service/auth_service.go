@@ -0,0 +1,342 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUnauthenticated    = errors.New("authentication required")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrForbidden          = errors.New("insufficient permissions")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrDuplicateUser      = errors.New("email already registered")
+	ErrInvalidUser        = errors.New("invalid user")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrInvalidAPIKey      = errors.New("invalid API key")
+)
+
+// apiKeyPrefix marks API keys so they can be told apart from JWTs in the
+// Authorization header
+const apiKeyPrefix = "srs_"
+
+// minPasswordLength applies to passwords set through the API
+const minPasswordLength = 8
+
+type AuthService struct {
+	users ports.UserRepository
+	keys  ports.APIKeyRepository
+	// directory holds the users of every tenant; emails are unique across
+	// tenants because signing in doesn't name a tenant
+	directory ports.UserRepository
+	tokens    ports.TokenIssuer
+	tokenTTL  time.Duration
+}
+
+func NewAuthService(users ports.UserRepository, keys ports.APIKeyRepository, directory ports.UserRepository, tokens ports.TokenIssuer, tokenTTL time.Duration) *AuthService {
+	return &AuthService{
+		users:     users,
+		keys:      keys,
+		directory: directory,
+		tokens:    tokens,
+		tokenTTL:  tokenTTL,
+	}
+}
+
+// UserInput holds the fields of a user account; on update empty fields are
+// left unchanged
+type UserInput struct {
+	// Set when an operator creates a tenant's first admin
+	TenantID uint        `json:"-"`
+	Email    string      `json:"email"`
+	Name     string      `json:"name"`
+	Password string      `json:"password"`
+	Role     domain.Role `json:"role"`
+	Active   *bool       `json:"active"`
+}
+
+// APIKeyInput describes a new API key; a zero ExpiresInDays never expires
+type APIKeyInput struct {
+	Name          string      `json:"name"`
+	Role          domain.Role `json:"role"`
+	ExpiresInDays int         `json:"expires_in_days"`
+}
+
+// LoginResult is the access token returned on a successful login
+type LoginResult struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      *domain.User `json:"user"`
+}
+
+// CreatedAPIKey carries the plain key, which is only available right after creation
+type CreatedAPIKey struct {
+	Key    string         `json:"key"`
+	APIKey *domain.APIKey `json:"api_key"`
+}
+
+// Bootstrap creates the first admin account of a tenant when there are no
+// users yet, so a fresh installation can be signed into
+func (s *AuthService) Bootstrap(tenantID uint, email string, password string) (*domain.User, error) {
+	count, err := s.users.Count()
+	if err != nil || count > 0 || email == "" {
+		return nil, err
+	}
+	return s.CreateUser(UserInput{TenantID: tenantID, Email: email, Name: "Administrator", Password: password, Role: domain.RoleAdmin})
+}
+
+func (s *AuthService) Login(email string, password string) (*LoginResult, error) {
+	user, err := s.directory.FindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil || !user.Active {
+		return nil, ErrInvalidCredentials
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	expiresAt := time.Now().Add(s.tokenTTL)
+	token, err := s.tokens.Issue(domain.TokenClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResult{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
+
+// Authenticate resolves the caller of a request from a bearer credential:
+// an API key (starting with "srs_") or a JWT issued by Login
+func (s *AuthService) Authenticate(credential string) (*domain.Principal, error) {
+	credential = strings.TrimSpace(credential)
+	if credential == "" {
+		return nil, ErrUnauthenticated
+	}
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		return s.authenticateKey(credential)
+	}
+
+	claims, err := s.tokens.Parse(credential)
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	// Role and status are re-read so deactivation and role changes apply to
+	// tokens that were already issued
+	user, err := s.users.FindByID(claims.UserID)
+	if err != nil || !user.Active {
+		return nil, ErrUnauthenticated
+	}
+
+	return &domain.Principal{
+		Method:   domain.AuthMethodJWT,
+		TenantID: user.TenantID,
+		UserID:   user.ID,
+		Name:     user.Email,
+		Role:     user.Role,
+	}, nil
+}
+
+func (s *AuthService) authenticateKey(plain string) (*domain.Principal, error) {
+	key, err := s.keys.FindByHash(hashAPIKey(plain))
+	if err != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	now := time.Now()
+	if !key.Usable(now) {
+		return nil, ErrUnauthenticated
+	}
+
+	key.LastUsedAt = &now
+	if err := s.keys.Update(key); err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		Method:   domain.AuthMethodAPIKey,
+		TenantID: key.TenantID,
+		APIKeyID: key.ID,
+		Name:     key.Name,
+		Role:     key.Role,
+	}, nil
+}
+
+func (s *AuthService) GetUsers() ([]domain.User, error) {
+	return s.users.FindAll()
+}
+
+func (s *AuthService) GetUser(id uint) (*domain.User, error) {
+	user, err := s.users.FindByID(id)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *AuthService) CreateUser(input UserInput) (*domain.User, error) {
+	if err := s.CheckUser(input); err != nil {
+		return nil, err
+	}
+
+	user := &domain.User{
+		TenantID: input.TenantID,
+		Email:    strings.ToLower(strings.TrimSpace(input.Email)),
+		Name:     strings.TrimSpace(input.Name),
+		Role:     input.Role,
+		Active:   true,
+	}
+	if user.Role == "" {
+		user.Role = domain.RoleViewer
+	}
+	if err := setPassword(user, input.Password); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CheckUser validates a new user account without creating it
+func (s *AuthService) CheckUser(input UserInput) error {
+	email := strings.ToLower(strings.TrimSpace(input.Email))
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("%w: email is required", ErrInvalidUser)
+	}
+	if input.Role != "" && !input.Role.Valid() {
+		return fmt.Errorf("%w: unknown role %q", ErrInvalidUser, input.Role)
+	}
+	if len(input.Password) < minPasswordLength {
+		return fmt.Errorf("%w: password must be at least %d characters", ErrInvalidUser, minPasswordLength)
+	}
+	if _, err := s.directory.FindByEmail(email); err == nil {
+		return ErrDuplicateUser
+	}
+	return nil
+}
+
+// UpdateUser changes a user's name, password, role or active flag
+func (s *AuthService) UpdateUser(id uint, input UserInput) (*domain.User, error) {
+	user, err := s.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		user.Name = name
+	}
+	if input.Role != "" {
+		if !input.Role.Valid() {
+			return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidUser, input.Role)
+		}
+		user.Role = input.Role
+	}
+	if input.Active != nil {
+		user.Active = *input.Active
+	}
+	if input.Password != "" {
+		if err := setPassword(user, input.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *AuthService) GetAPIKeys() ([]domain.APIKey, error) {
+	return s.keys.FindAll()
+}
+
+// CreateAPIKey generates a random key for an automation client. Only its
+// SHA-256 hash is stored.
+func (s *AuthService) CreateAPIKey(input APIKeyInput, createdBy *domain.Principal) (*CreatedAPIKey, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+	if !input.Role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", ErrInvalidAPIKey, input.Role)
+	}
+	if input.ExpiresInDays < 0 {
+		return nil, fmt.Errorf("%w: expires_in_days must not be negative", ErrInvalidAPIKey)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	plain := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key := &domain.APIKey{
+		Name:    name,
+		Prefix:  plain[:len(apiKeyPrefix)+6],
+		KeyHash: hashAPIKey(plain),
+		Role:    input.Role,
+	}
+	if createdBy != nil && createdBy.UserID > 0 {
+		key.CreatedBy = &createdBy.UserID
+	}
+	if input.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, input.ExpiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.keys.Create(key); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{Key: plain, APIKey: key}, nil
+}
+
+func (s *AuthService) RevokeAPIKey(id uint) (*domain.APIKey, error) {
+	key, err := s.keys.FindByID(id)
+	if err != nil {
+		return nil, ErrAPIKeyNotFound
+	}
+	if key.RevokedAt != nil {
+		return key, nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = &now
+	if err := s.keys.Update(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func setPassword(user *domain.User, password string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("%w: password must be at least %d characters", ErrInvalidUser, minPasswordLength)
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+	return nil
+}
+
+// hashAPIKey hashes an API key for storage and lookup. Keys are random and
+// long, so a fast hash is sufficient.
+func hashAPIKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrDataEntityNotFound = errors.New("data entity not found")
+	ErrDuplicateEntity    = errors.New("an entity with this name already exists in the SRS")
+	ErrInvalidDataEntity  = errors.New("invalid data entity")
+)
+
+// dataAttributeTypes are the attribute types of the data dictionary
+var dataAttributeTypes = map[string]bool{
+	"string": true, "integer": true, "decimal": true, "boolean": true,
+	"date": true, "date-time": true, "enum": true,
+}
+
+type DataDictionaryService struct {
+	repo    ports.DataEntityRepository
+	srsRepo ports.SRSRepository
+	ai      *AIResolver
+}
+
+func NewDataDictionaryService(repo ports.DataEntityRepository, srsRepo ports.SRSRepository, ai *AIResolver) *DataDictionaryService {
+	return &DataDictionaryService{
+		repo:    repo,
+		srsRepo: srsRepo,
+		ai:      ai,
+	}
+}
+
+// DataEntityInput is the analyst-editable part of a data entity
+type DataEntityInput struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Attributes  []domain.DataAttribute `json:"attributes"`
+	Sources     []string               `json:"sources"`
+}
+
+// Extract asks the AI provider for the data dictionary of an SRS. Entities
+// from an earlier extraction are replaced, except those an analyst has
+// curated; extracted entities with the same name as a curated one are skipped.
+func (s *DataDictionaryService) Extract(srsID uint) ([]domain.DataEntity, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	response, err := s.ai.ForDocument(srs.ProjectID, srs.SourceDocumentID).ExtractDataDictionary(srs.Content)
+	if err != nil {
+		return nil, fmt.Errorf("gagal ekstraksi kamus data: %w", err)
+	}
+
+	extracted, err := parseDataDictionary(response)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindBySRSID(srsID)
+	if err != nil {
+		return nil, err
+	}
+	curated := map[string]bool{}
+	for _, entity := range existing {
+		if entity.Curated {
+			curated[strings.ToLower(entity.Name)] = true
+			continue
+		}
+		if err := s.repo.Delete(entity.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, input := range extracted {
+		for i := range input.Attributes {
+			if !dataAttributeTypes[strings.ToLower(strings.TrimSpace(input.Attributes[i].Type))] {
+				input.Attributes[i].Type = "string"
+			}
+		}
+		entity := domain.DataEntity{SRSID: srsID}
+		if err := applyDataEntityInput(&entity, input); err != nil {
+			// A malformed entity in the AI response doesn't discard the rest
+			continue
+		}
+		if curated[strings.ToLower(entity.Name)] {
+			continue
+		}
+		curated[strings.ToLower(entity.Name)] = true
+		if err := s.repo.Create(&entity); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.FindBySRSID(srsID)
+}
+
+func (s *DataDictionaryService) GetEntities(srsID uint) ([]domain.DataEntity, error) {
+	return s.repo.FindBySRSID(srsID)
+}
+
+func (s *DataDictionaryService) GetEntity(id uint) (*domain.DataEntity, error) {
+	entity, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrDataEntityNotFound
+	}
+	return entity, nil
+}
+
+// CreateEntity adds an analyst-defined entity to the dictionary of an SRS
+func (s *DataDictionaryService) CreateEntity(srsID uint, input DataEntityInput) (*domain.DataEntity, error) {
+	if _, err := s.srsRepo.FindByID(srsID); err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	entity := &domain.DataEntity{SRSID: srsID, Curated: true}
+	if err := applyDataEntityInput(entity, input); err != nil {
+		return nil, err
+	}
+	if err := s.checkUniqueName(entity); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// UpdateEntity replaces the editable fields of an entity and marks it curated
+func (s *DataDictionaryService) UpdateEntity(id uint, input DataEntityInput) (*domain.DataEntity, error) {
+	entity, err := s.GetEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyDataEntityInput(entity, input); err != nil {
+		return nil, err
+	}
+	if err := s.checkUniqueName(entity); err != nil {
+		return nil, err
+	}
+	entity.Curated = true
+
+	if err := s.repo.Update(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *DataDictionaryService) DeleteEntity(id uint) error {
+	if _, err := s.GetEntity(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *DataDictionaryService) checkUniqueName(entity *domain.DataEntity) error {
+	entities, err := s.repo.FindBySRSID(entity.SRSID)
+	if err != nil {
+		return err
+	}
+	for _, other := range entities {
+		if other.ID != entity.ID && strings.EqualFold(other.Name, entity.Name) {
+			return ErrDuplicateEntity
+		}
+	}
+	return nil
+}
+
+// applyDataEntityInput validates and normalizes input onto an entity
+func applyDataEntityInput(entity *domain.DataEntity, input DataEntityInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidDataEntity)
+	}
+
+	attributes := make([]domain.DataAttribute, 0, len(input.Attributes))
+	seen := map[string]bool{}
+	for _, attr := range input.Attributes {
+		attr.Name = strings.TrimSpace(attr.Name)
+		if attr.Name == "" {
+			return fmt.Errorf("%w: attribute name is required", ErrInvalidDataEntity)
+		}
+		if seen[strings.ToLower(attr.Name)] {
+			return fmt.Errorf("%w: duplicate attribute %q", ErrInvalidDataEntity, attr.Name)
+		}
+		seen[strings.ToLower(attr.Name)] = true
+
+		attr.Type = strings.ToLower(strings.TrimSpace(attr.Type))
+		if attr.Type == "" {
+			attr.Type = "string"
+		}
+		if !dataAttributeTypes[attr.Type] {
+			return fmt.Errorf("%w: unknown type %q of attribute %q", ErrInvalidDataEntity, attr.Type, attr.Name)
+		}
+		attributes = append(attributes, attr)
+	}
+
+	entity.Name = name
+	entity.Description = strings.TrimSpace(input.Description)
+	entity.Attributes = attributes
+	entity.Sources = input.Sources
+	return nil
+}
+
+// parseDataDictionary extracts the JSON array from an AI response, tolerating
+// code fences or text around it
+func parseDataDictionary(response string) ([]DataEntityInput, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start < 0 || end < start {
+		return nil, ErrInvalidAIResponse
+	}
+
+	var entities []DataEntityInput
+	if err := json.Unmarshal([]byte(response[start:end+1]), &entities); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAIResponse, err)
+	}
+	if len(entities) == 0 {
+		return nil, ErrInvalidAIResponse
+	}
+	return entities, nil
+}
@@ -2,34 +2,63 @@ package service
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"srs-automation/internal/core/domain"
 	"srs-automation/internal/core/ports"
 	"strings"
+	"time"
 
 	"github.com/ledongthuc/pdf"
 )
 
+var ErrInvalidDocumentType = errors.New("document type must be BRD, SRS or OTHER")
+
 type DocumentService struct {
 	repo           ports.DocumentRepository
-	aiService      ports.AIService
+	srsRepo        ports.SRSRepository
+	ai             *AIResolver
 	storageService ports.FileStorageService
+	projects       *ProjectService
+	audit          *AuditService
+	progress       ports.ProgressBus
+	webhooks       *WebhookService
+	notifications  *NotificationService
+	// renders the draft output file of a processed document
+	draftExporter ports.Exporter
 }
 
 func NewDocumentService(
 	repo ports.DocumentRepository,
-	aiService ports.AIService,
+	srsRepo ports.SRSRepository,
+	ai *AIResolver,
 	storageService ports.FileStorageService,
+	projects *ProjectService,
+	audit *AuditService,
+	progress ports.ProgressBus,
+	webhooks *WebhookService,
+	notifications *NotificationService,
+	draftExporter ports.Exporter,
 ) *DocumentService {
 	return &DocumentService{
 		repo:           repo,
-		aiService:      aiService,
+		srsRepo:        srsRepo,
+		ai:             ai,
 		storageService: storageService,
+		projects:       projects,
+		audit:          audit,
+		progress:       progress,
+		webhooks:       webhooks,
+		notifications:  notifications,
+		draftExporter:  draftExporter,
 	}
 }
 
-func extractTextFromPDF(filePath string) (string, error) {
+// extractTextFromPDF reads the text page by page, calling onPage (if set)
+// after each page
+func extractTextFromPDF(filePath string, onPage func(page int, pages int)) (string, error) {
 	f, r, err := pdf.Open(filePath)
 	if err != nil {
 		return "", err
@@ -37,15 +66,72 @@ func extractTextFromPDF(filePath string) (string, error) {
 	defer f.Close()
 
 	var buf bytes.Buffer
-	b, err := r.GetPlainText()
-	if err != nil {
-		return "", err
+	pages := r.NumPage()
+	// Fonts are cached so their charmaps aren't parsed again for every page
+	fonts := make(map[string]*pdf.Font)
+	for i := 1; i <= pages; i++ {
+		p := r.Page(i)
+		for _, name := range p.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				font := p.Font(name)
+				fonts[name] = &font
+			}
+		}
+		text, err := p.GetPlainText(fonts)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(text)
+		if onPage != nil {
+			onPage(i, pages)
+		}
 	}
-	buf.ReadFrom(b)
 	return buf.String(), nil
 }
 
-func (s *DocumentService) UploadDocument(filename string, docType domain.DocumentType, data []byte) (*domain.Document, error) {
+// readDocumentText extracts the plain text of an uploaded document, cut to a
+// length the AI prompts can take. onChunk (optional) is called as each page
+// of a PDF, or the whole of any other file, has been read.
+func readDocumentText(doc *domain.Document, onChunk func(chunk int, chunks int)) (string, error) {
+	var cleanContent string
+
+	// 1. Ekstraksi (Hanya di RAM, tidak disimpan ke Disk)
+	if strings.HasSuffix(strings.ToLower(doc.Filename), ".pdf") {
+		text, err := extractTextFromPDF(doc.FilePath, onChunk)
+		if err != nil {
+			// Fallback jika gagal baca PDF
+			raw, _ := os.ReadFile(doc.FilePath)
+			cleanContent = string(raw)
+		} else {
+			cleanContent = text
+		}
+	} else {
+		rawBytes, err := os.ReadFile(doc.FilePath)
+		if err != nil {
+			return "", err
+		}
+		cleanContent = string(rawBytes)
+		if onChunk != nil {
+			onChunk(1, 1)
+		}
+	}
+
+	// 2. Potong Teks (Agar Token AI tidak Jebol & Hemat RAM)
+	// Kita ambil 15.000 karakter pertama saja (sekitar 5-7 halaman padat)
+	// Ini biasanya sudah CUKUP untuk SRS (Intro + Functional Req biasanya di awal)
+	if len(cleanContent) > 15000 {
+		log.Printf("document %d: text is %d characters long, only the first 15000 are used", doc.ID, len(cleanContent))
+		cleanContent = cleanContent[:15000]
+	}
+
+	return cleanContent, nil
+}
+
+func (s *DocumentService) UploadDocument(actor domain.AuditActor, projectID uint, filename string, docType domain.DocumentType, data []byte) (*domain.Document, error) {
+	if !docType.Valid() {
+		return nil, ErrInvalidDocumentType
+	}
+
 	// Save file first
 	filePath, err := s.storageService.SaveFile(filename, data)
 	if err != nil {
@@ -54,10 +140,12 @@ func (s *DocumentService) UploadDocument(filename string, docType domain.Documen
 
 	// Create document record
 	doc := &domain.Document{
-		Filename: filename,
-		FilePath: filePath,
-		Type:     domain.DocumentTypeBRD,
-		Status:   domain.StatusUploaded,
+		ProjectID: projectID,
+		Filename:  filename,
+		FilePath:  filePath,
+		Type:      docType,
+		Status:    domain.StatusUploaded,
+		Author:    actor.Name,
 		// Content will be filled later during processing
 	}
 
@@ -65,80 +153,104 @@ func (s *DocumentService) UploadDocument(filename string, docType domain.Documen
 		return nil, err
 	}
 
+	if err := s.audit.Record(actor, domain.AuditActionUpload, domain.AuditEntityDocument, doc.ID, "", auditHash(doc)); err != nil {
+		return nil, err
+	}
+
 	return doc, nil
 }
 
-func (s *DocumentService) ProcessDocument(id uint) error {
+// CheckAIBudget fails with ErrAIBudgetExceeded when the project can't
+// process documents until next month
+func (s *DocumentService) CheckAIBudget(projectID uint) error {
+	return s.ai.CheckBudget(projectID)
+}
+
+// ProcessDocument generates the SRS draft of a document. The outcome, failed
+// or completed, is recorded in the audit trail. noCache asks for a fresh AI
+// response even when a cached one exists. Nothing is processed once the
+// project's monthly AI budget is used up.
+func (s *DocumentService) ProcessDocument(actor domain.AuditActor, id uint, noCache bool) error {
 	doc, err := s.repo.FindByID(id)
 	if err != nil {
 		return err
 	}
+	if err := s.ai.CheckBudget(doc.ProjectID); err != nil {
+		return err
+	}
 
+	before := auditHash(doc)
+	processErr := s.process(doc, noCache)
+	if err := s.audit.Record(actor, domain.AuditActionProcess, domain.AuditEntityDocument, doc.ID, before, auditHash(doc)); err != nil && processErr == nil {
+		return err
+	}
+	return processErr
+}
+
+// process runs the processing steps, publishing each to the progress bus
+// and ending with a completed or failed event, which is also sent to
+// webhooks and notified by email
+func (s *DocumentService) process(doc *domain.Document, noCache bool) error {
+	err := s.runProcess(doc, noCache)
+	notification := domain.NotificationContext{ProjectID: doc.ProjectID, DocumentID: doc.ID, Filename: doc.Filename}
+	if err != nil {
+		s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressFailed, Error: err.Error()})
+		data := webhookDocument(doc)
+		data["error"] = err.Error()
+		s.webhooks.Emit(doc.ProjectID, domain.WebhookDocumentFailed, data)
+		notification.Error = err.Error()
+		s.notifications.Notify(domain.NotifyProcessingFailed, notification)
+		return err
+	}
+	s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressCompleted, Message: "SRS draft selesai dibuat"})
+	s.webhooks.Emit(doc.ProjectID, domain.WebhookDocumentProcessed, webhookDocument(doc))
+	s.notifications.Notify(domain.NotifySRSReady, notification)
+	return nil
+}
+
+func (s *DocumentService) runProcess(doc *domain.Document, noCache bool) error {
 	// Update status to processing
 	doc.Status = domain.StatusProcessing
 	if err := s.repo.Update(doc); err != nil {
 		return err
 	}
 
-	// fileContent, err := os.ReadFile(doc.FilePath)
-	// if err != nil {
-	// 	return fmt.Errorf("gagal baca file fisik: %w", err)
-	// }
-
-	var cleanContent string
-
-	// 1. Ekstraksi (Hanya di RAM, tidak disimpan ke Disk)
-	if strings.HasSuffix(strings.ToLower(doc.Filename), ".pdf") {
-		text, err := extractTextFromPDF(doc.FilePath)
-		if err != nil {
-			// Fallback jika gagal baca PDF
-			raw, _ := os.ReadFile(doc.FilePath)
-			cleanContent = string(raw)
-		} else {
-			cleanContent = text
-		}
-	} else {
-		rawBytes, err := os.ReadFile(doc.FilePath)
-		if err != nil {
-			return err
-		}
-		cleanContent = string(rawBytes)
+	s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressExtracting, Message: "Mengekstrak teks dokumen"})
+	cleanContent, err := readDocumentText(doc, func(chunk int, chunks int) {
+		s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressChunk, Chunk: chunk, Chunks: chunks})
+	})
+	if err != nil {
+		return err
 	}
 
-	// 2. Potong Teks (Agar Token AI tidak Jebol & Hemat RAM)
-	// Kita ambil 15.000 karakter pertama saja (sekitar 5-7 halaman padat)
-	// Ini biasanya sudah CUKUP untuk SRS (Intro + Functional Req biasanya di awal)
-	if len(cleanContent) > 15000 {
-		fmt.Println("⚠️ Teks terlalu panjang, mengambil 15.000 karakter awal...")
-		cleanContent = cleanContent[:15000]
+	// Flagged passages are still sent, delimited as data, for the reviewer to check
+	doc.InjectionFlags = DetectInjection(string(cleanContent))
+	if len(doc.InjectionFlags) > 0 {
+		s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressExtracting, Message: fmt.Sprintf("%d bagian teks menyerupai instruksi untuk AI dan ditandai untuk ditinjau", len(doc.InjectionFlags))})
 	}
 
 	// 3. Generate SRS Menggunakan Gemini AI
 	// Kita kirim konten BRD (doc.Content) ke AI
-	fmt.Println("🤖 Gemini sedang menganalisis...")
-	srsContent, err := s.aiService.GenerateSRS(string(cleanContent))
+	s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressGenerating, Message: "AI sedang menyusun SRS"})
+	promptCtx := s.projects.PromptContext(doc.ProjectID)
+	promptCtx.NoCache = noCache
+	srsContent, err := s.ai.ForDocument(doc.ProjectID, doc.ID).StreamSRS(string(cleanContent), promptCtx, func(token string) {
+		s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressToken, Token: token})
+	})
 	if err != nil {
 		doc.Status = "FAILED"
 		s.repo.Update(doc)
 		return fmt.Errorf("gagal generate SRS: %w", err)
 	}
 
-	// folderID := os.Getenv("GOOGLE_DRIVE_FOLDER_ID")
-
 	title := fmt.Sprintf("SRS Draft - %s", doc.Filename)
 
-	savedPath, err := s.aiService.GenerateDocxFile(srsContent, title)
+	s.publish(domain.ProgressEvent{DocumentID: doc.ID, Stage: domain.ProgressRendering, Message: fmt.Sprintf("Membuat file %s", strings.ToUpper(s.draftExporter.Format()))})
+	savedPath, err := s.saveDraft(doc.ID, title, srsContent)
 	if err != nil {
-		return fmt.Errorf("gagal membuat file docx: %w", err)
+		return fmt.Errorf("gagal membuat file %s: %w", s.draftExporter.Format(), err)
 	}
 
-	// fmt.Println("📄 Membuat dokumen cloud...")
-	// docLink, err := s.aiService.CreateGoogleDoc(title, srsContent, folderID)
-	// if err != nil {
-	// 	fmt.Printf("Error membuat dokumen: %v\n", err)
-	// 	// Kita tidak return error agar data text tetap tersimpan
-	// }
-
 	doc.ExtractedData = []byte(srsContent)
 	doc.GoogleDocLink = savedPath
 	doc.Status = "COMPLETED"
@@ -146,24 +258,138 @@ func (s *DocumentService) ProcessDocument(id uint) error {
 	return s.repo.Update(doc)
 }
 
+// saveDraft renders generated SRS content to the output directory and returns
+// the file path. The file is named after the document ID, so documents with
+// the same filename don't share an output file.
+func (s *DocumentService) saveDraft(documentID uint, title string, content string) (string, error) {
+	sections, requirements := parseSRSStructure(content)
+	doc := &domain.ExportDocument{
+		Meta: domain.ExportMeta{
+			Title:    title,
+			Subtitle: "Software Requirements Specification",
+			Status:   "DRAFT",
+			Date:     time.Now(),
+		},
+		Content:      content,
+		Sections:     sections,
+		Requirements: requirements,
+	}
+
+	var buf bytes.Buffer
+	if err := s.draftExporter.Export(&buf, doc); err != nil {
+		return "", err
+	}
+
+	return s.storageService.SaveOutput(fmt.Sprintf("%d_%s.%s", documentID, title, s.draftExporter.Format()), buf.Bytes())
+}
+
+func (s *DocumentService) publish(event domain.ProgressEvent) {
+	event.Time = time.Now()
+	s.progress.Publish(event)
+}
+
+// FollowProgress returns the current state of a document's processing and a
+// channel of the events that follow; cancel ends the subscription. Without a
+// recent event on the bus the state is derived from the document status.
+func (s *DocumentService) FollowProgress(id uint) (domain.ProgressEvent, <-chan domain.ProgressEvent, func(), error) {
+	doc, err := s.repo.FindByID(id)
+	if err != nil {
+		return domain.ProgressEvent{}, nil, nil, err
+	}
+
+	// Subscribe before reading the last event so nothing is missed in between
+	events, cancel := s.progress.Subscribe(id)
+	current, ok := s.progress.Last(id)
+	if !ok {
+		current = domain.ProgressEvent{DocumentID: id, Stage: statusStage(doc.Status), Message: string(doc.Status), Time: doc.UpdatedAt}
+	}
+	return current, events, cancel, nil
+}
+
+// statusStage maps a stored document status to a progress stage
+func statusStage(status domain.DocumentStatus) domain.ProgressStage {
+	switch status {
+	case domain.StatusCompleted:
+		return domain.ProgressCompleted
+	case domain.StatusFailed:
+		return domain.ProgressFailed
+	case domain.StatusProcessing:
+		return domain.ProgressGenerating
+	default:
+		return domain.ProgressUploaded
+	}
+}
+
 func (s *DocumentService) GetDocument(id uint) (*domain.Document, error) {
 	return s.repo.FindByID(id)
 }
 
-func (s *DocumentService) GetAllDocuments() ([]domain.Document, error) {
-	return s.repo.FindAll()
+// ListDocuments returns one page of the documents matching the filter
+func (s *DocumentService) ListDocuments(filter domain.DocumentFilter) ([]domain.Document, domain.PageInfo, error) {
+	if filter.Type != "" && !filter.Type.Valid() {
+		return nil, domain.PageInfo{}, fmt.Errorf("%w: unknown document type %q", ErrInvalidListQuery, filter.Type)
+	}
+	if err := preparePage(&filter.Page, domain.DocumentSortFields); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	docs, total, err := s.repo.FindPage(filter)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	docs, info := pageInfo(filter.Page, total, docs, func(doc domain.Document) (any, uint) {
+		return documentSortValue(doc, filter.Page.Sort), doc.ID
+	})
+	return docs, info, nil
 }
 
-func (s *DocumentService) DeleteDocument(id uint) error {
+func documentSortValue(doc domain.Document, field string) any {
+	switch field {
+	case "updated_at":
+		return doc.UpdatedAt
+	case "filename":
+		return doc.Filename
+	case "status":
+		return doc.Status
+	case "type":
+		return doc.Type
+	default:
+		return doc.CreatedAt
+	}
+}
+
+func (s *DocumentService) DeleteDocument(actor domain.AuditActor, id uint) error {
 	doc, err := s.repo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// Delete file
-	if err := s.storageService.DeleteFile(doc.FilePath); err != nil {
+	if err := deleteDocumentData(s.repo, s.srsRepo, s.storageService, doc); err != nil {
+		return err
+	}
+
+	return s.audit.Record(actor, domain.AuditActionDelete, domain.AuditEntityDocument, doc.ID, auditHash(doc), "")
+}
+
+// deleteDocumentData removes a document together with its uploaded source,
+// generated output and the SRS rows generated from it
+func deleteDocumentData(
+	docRepo ports.DocumentRepository,
+	srsRepo ports.SRSRepository,
+	storageService ports.FileStorageService,
+	doc *domain.Document,
+) error {
+	for _, path := range []string{doc.FilePath, doc.GoogleDocLink} {
+		if path == "" {
+			continue
+		}
+		if err := storageService.DeleteFile(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	if err := srsRepo.DeleteByDocumentID(doc.ID); err != nil {
 		return err
 	}
 
-	return s.repo.Delete(id)
+	return docRepo.Delete(doc.ID)
 }
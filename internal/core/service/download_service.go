@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"time"
+)
+
+var (
+	ErrInvalidFileKind  = errors.New("invalid file kind")
+	ErrFileNotReady     = errors.New("document has not finished processing")
+	ErrInvalidSignature = errors.New("invalid download signature")
+	ErrLinkExpired      = errors.New("download link has expired")
+	ErrFilePurged       = errors.New("file has been removed by the retention policy")
+)
+
+type DownloadService struct {
+	docRepo ports.DocumentRepository
+	logRepo ports.FileAccessLogRepository
+	signer  ports.URLSigner
+	linkTTL time.Duration
+}
+
+func NewDownloadService(
+	docRepo ports.DocumentRepository,
+	logRepo ports.FileAccessLogRepository,
+	signer ports.URLSigner,
+	linkTTL time.Duration,
+) *DownloadService {
+	return &DownloadService{
+		docRepo: docRepo,
+		logRepo: logRepo,
+		signer:  signer,
+		linkTTL: linkTTL,
+	}
+}
+
+func downloadPayload(docID uint, kind domain.FileKind) string {
+	return fmt.Sprintf("%d:%s", docID, kind)
+}
+
+// filePathFor returns the file on disk backing the given kind of a document
+func filePathFor(doc *domain.Document, kind domain.FileKind) (string, error) {
+	switch kind {
+	case domain.FileKindSource:
+		if doc.SourcePurgedAt != nil {
+			return "", ErrFilePurged
+		}
+		return doc.FilePath, nil
+	case domain.FileKindResult:
+		if doc.ResultPurgedAt != nil {
+			return "", ErrFilePurged
+		}
+		if doc.Status != domain.StatusCompleted || doc.GoogleDocLink == "" {
+			return "", ErrFileNotReady
+		}
+		return doc.GoogleDocLink, nil
+	default:
+		return "", ErrInvalidFileKind
+	}
+}
+
+// CreateLink issues a signed URL path for downloading a document file.
+// baseURL is prepended to the path so handlers can return absolute links.
+func (s *DownloadService) CreateLink(docID uint, kind domain.FileKind, baseURL string) (*domain.DownloadLink, error) {
+	doc, err := s.docRepo.FindByID(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := filePathFor(doc, kind); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.linkTTL).Truncate(time.Second)
+	signature := s.signer.Sign(downloadPayload(docID, kind), expiresAt)
+
+	return &domain.DownloadLink{
+		DocumentID: docID,
+		Kind:       kind,
+		URL: fmt.Sprintf("%s/api/v1/downloads/%d/%s?expires=%d&signature=%s",
+			baseURL, docID, kind, expiresAt.Unix(), signature),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ResolveDownload verifies a signed link and returns the file path and the
+// filename to present to the client. Every attempt is written to the access log.
+func (s *DownloadService) ResolveDownload(docID uint, kind domain.FileKind, expires int64, signature, clientIP, userAgent string) (string, string, error) {
+	entry := &domain.FileAccessLog{
+		DocumentID: docID,
+		Kind:       kind,
+		ClientIP:   clientIP,
+		UserAgent:  userAgent,
+	}
+
+	doc, path, err := s.resolve(docID, kind, expires, signature)
+	if err != nil {
+		entry.Reason = err.Error()
+	} else {
+		entry.Granted = true
+	}
+	// Downloads aren't tied to a signed-in tenant; the log belongs to the
+	// document's tenant when the link resolved to a document
+	if doc != nil {
+		entry.TenantID = doc.TenantID
+	}
+
+	if logErr := s.logRepo.Create(entry); logErr != nil {
+		return "", "", fmt.Errorf("failed to record file access: %w", logErr)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if kind == domain.FileKindSource {
+		return path, doc.Filename, nil
+	}
+	return path, filepath.Base(path), nil
+}
+
+func (s *DownloadService) resolve(docID uint, kind domain.FileKind, expires int64, signature string) (*domain.Document, string, error) {
+	expiresAt := time.Unix(expires, 0)
+	if !s.signer.Verify(downloadPayload(docID, kind), expiresAt, signature) {
+		return nil, "", ErrInvalidSignature
+	}
+
+	if time.Now().After(expiresAt) {
+		return nil, "", ErrLinkExpired
+	}
+
+	doc, err := s.docRepo.FindByID(docID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	path, err := filePathFor(doc, kind)
+	return doc, path, err
+}
+
+func (s *DownloadService) GetAccessLogs(docID uint) ([]domain.FileAccessLog, error) {
+	return s.logRepo.FindByDocumentID(docID)
+}
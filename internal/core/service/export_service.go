@@ -0,0 +1,153 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrSRSNotFound        = errors.New("SRS not found")
+	ErrRevisionNotFound   = errors.New("SRS revision not found")
+	ErrUnsupportedFormat  = errors.New("unsupported export format")
+	ErrTemplateNotAllowed = errors.New("templates are only supported for docx export")
+)
+
+// DefaultExportFormat is used when neither the format parameter nor the Accept header selects one
+const DefaultExportFormat = "docx"
+
+// ExportOptions selects the output format, SRS revision (0 = current) and DOCX template
+type ExportOptions struct {
+	Format     string
+	Revision   int
+	TemplateID uint
+}
+
+// ExportResult is a rendered SRS file
+type ExportResult struct {
+	Data        []byte
+	Filename    string
+	ContentType string
+}
+
+type ExportService struct {
+	srsRepo      ports.SRSRepository
+	revisionRepo ports.SRSRevisionRepository
+	modelRepo    ports.UseCaseModelRepository
+	dataRepo     ports.DataEntityRepository
+	templates    *TemplateService
+	exporters    map[string]ports.Exporter
+	// media types in registration order, for content negotiation
+	mediaTypes []string
+}
+
+func NewExportService(
+	srsRepo ports.SRSRepository,
+	revisionRepo ports.SRSRevisionRepository,
+	modelRepo ports.UseCaseModelRepository,
+	dataRepo ports.DataEntityRepository,
+	templates *TemplateService,
+	exporters ...ports.Exporter,
+) *ExportService {
+	s := &ExportService{
+		srsRepo:      srsRepo,
+		revisionRepo: revisionRepo,
+		modelRepo:    modelRepo,
+		dataRepo:     dataRepo,
+		templates:    templates,
+		exporters:    make(map[string]ports.Exporter),
+	}
+	for _, e := range exporters {
+		s.exporters[e.Format()] = e
+		s.mediaTypes = append(s.mediaTypes, e.MediaType())
+	}
+	return s
+}
+
+// MediaTypes lists the media types of all registered formats
+func (s *ExportService) MediaTypes() []string {
+	return s.mediaTypes
+}
+
+// FormatForMediaType returns the format producing the given media type, or "" if none does
+func (s *ExportService) FormatForMediaType(mediaType string) string {
+	for format, e := range s.exporters {
+		if e.MediaType() == mediaType {
+			return format
+		}
+	}
+	return ""
+}
+
+// Export renders an SRS, or one of its revisions, to the requested format
+func (s *ExportService) Export(srsID uint, opts ExportOptions) (*ExportResult, error) {
+	format := strings.ToLower(opts.Format)
+	if format == "" {
+		format = DefaultExportFormat
+	}
+	exporter, ok := s.exporters[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+	if format != "docx" && opts.TemplateID > 0 {
+		return nil, ErrTemplateNotAllowed
+	}
+
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	revisionLabel := ""
+	if opts.Revision > 0 {
+		rev, err := s.revisionRepo.FindByRevision(srsID, opts.Revision)
+		if err != nil {
+			return nil, ErrRevisionNotFound
+		}
+		srs = srs.AtRevision(rev)
+		revisionLabel = fmt.Sprintf("-r%d", opts.Revision)
+	}
+
+	doc := buildExportDocument(srs)
+	doc.Revision = opts.Revision
+	// Diagrams are optional; an SRS without an extracted model exports without them
+	if model, err := s.modelRepo.FindBySRSID(srsID); err == nil {
+		doc.Diagrams = model.Diagrams
+	}
+	doc.DataDictionary, err = s.dataRepo.FindBySRSID(srsID)
+	if err != nil {
+		return nil, err
+	}
+	if format == "docx" {
+		doc.Template, err = s.templates.ResolveTemplate(srs.ProjectID, opts.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Export(&buf, doc); err != nil {
+		return nil, fmt.Errorf("failed to export %s: %w", format, err)
+	}
+
+	return &ExportResult{
+		Data:        buf.Bytes(),
+		Filename:    fmt.Sprintf("SRS-%d-v%s%s.%s", srs.ID, srs.Version, revisionLabel, format),
+		ContentType: exporter.MediaType(),
+	}, nil
+}
+
+// buildExportDocument collects the cover fields, section tree and requirements of an SRS
+func buildExportDocument(srs *domain.SRS) *domain.ExportDocument {
+	sections, requirements := parseSRSStructure(srs.Content)
+	return &domain.ExportDocument{
+		SRSID:        srs.ID,
+		Meta:         srs.ExportMeta(),
+		Content:      srs.Content,
+		Sections:     sections,
+		Requirements: requirements,
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrGlossaryTermNotFound = errors.New("glossary term not found")
+	ErrDuplicateTerm        = errors.New("term already exists in the project glossary")
+	ErrInvalidGlossaryTerm  = errors.New("invalid glossary term")
+	ErrDocumentNotFound     = errors.New("document not found")
+)
+
+var (
+	acronymRe      = regexp.MustCompile(`\b[A-Z][A-Z0-9]{1,9}\b`)
+	quotedTermRe   = regexp.MustCompile(`["“]([^"“”]{3,60})["”]`)
+	headingTitleRe = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*$`)
+)
+
+// commonAcronyms are technical abbreviations that need no glossary entry
+var commonAcronyms = map[string]bool{
+	"SRS": true, "BRD": true, "API": true, "ID": true, "UI": true, "UX": true,
+	"URL": true, "HTTP": true, "HTTPS": true, "PDF": true, "JSON": true, "XML": true,
+	"CSV": true, "SQL": true, "OK": true, "IT": true, "CRUD": true, "REST": true,
+	"FR": true, "NFR": true, "BR": true, "UC": true, "DRAFT": true, "TBD": true,
+}
+
+type GlossaryService struct {
+	repo    ports.GlossaryRepository
+	docRepo ports.DocumentRepository
+	srsRepo ports.SRSRepository
+	ai      *AIResolver
+}
+
+func NewGlossaryService(
+	repo ports.GlossaryRepository,
+	docRepo ports.DocumentRepository,
+	srsRepo ports.SRSRepository,
+	ai *AIResolver,
+) *GlossaryService {
+	return &GlossaryService{
+		repo:    repo,
+		docRepo: docRepo,
+		srsRepo: srsRepo,
+		ai:      ai,
+	}
+}
+
+// GlossaryTermInput is the user-editable part of a glossary term
+type GlossaryTermInput struct {
+	Term       string                    `json:"term"`
+	Definition string                    `json:"definition"`
+	Synonyms   []string                  `json:"synonyms"`
+	Status     domain.GlossaryTermStatus `json:"status"`
+}
+
+// GetTerms lists the glossary of a project, optionally filtered by status
+func (s *GlossaryService) GetTerms(projectID uint, status domain.GlossaryTermStatus) ([]domain.GlossaryTerm, error) {
+	if status != "" {
+		return s.repo.FindByStatus(projectID, status)
+	}
+	return s.repo.FindByProject(projectID)
+}
+
+func (s *GlossaryService) GetTerm(id uint) (*domain.GlossaryTerm, error) {
+	term, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrGlossaryTermNotFound
+	}
+	return term, nil
+}
+
+// CreateTerm adds a user-defined term; it is approved unless a status is given
+func (s *GlossaryService) CreateTerm(projectID uint, input GlossaryTermInput) (*domain.GlossaryTerm, error) {
+	if input.Status == "" {
+		input.Status = domain.TermApproved
+	}
+
+	term := &domain.GlossaryTerm{ProjectID: projectID}
+	if err := applyGlossaryInput(term, input); err != nil {
+		return nil, err
+	}
+	if err := s.checkUniqueTerm(term); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(term); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+// UpdateTerm curates a term: edits its text, definition and synonyms, or
+// approves / rejects it
+func (s *GlossaryService) UpdateTerm(id uint, input GlossaryTermInput) (*domain.GlossaryTerm, error) {
+	term, err := s.GetTerm(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Status == "" {
+		input.Status = term.Status
+	}
+	if err := applyGlossaryInput(term, input); err != nil {
+		return nil, err
+	}
+	if err := s.checkUniqueTerm(term); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(term); err != nil {
+		return nil, err
+	}
+	return term, nil
+}
+
+func (s *GlossaryService) DeleteTerm(id uint) error {
+	if _, err := s.GetTerm(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// ExtractFromDocument asks the AI provider for the domain terms of a BRD and
+// adds those not yet in the project glossary as proposed terms
+func (s *GlossaryService) ExtractFromDocument(projectID uint, documentID uint) ([]domain.GlossaryTerm, error) {
+	doc, err := s.docRepo.FindByID(documentID)
+	if err != nil || doc.ProjectID != projectID {
+		return nil, ErrDocumentNotFound
+	}
+
+	content, err := readDocumentText(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.ai.ForDocument(projectID, documentID).ExtractGlossary(content)
+	if err != nil {
+		return nil, fmt.Errorf("gagal ekstraksi glosarium: %w", err)
+	}
+
+	extracted, err := parseGlossary(response)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for _, term := range existing {
+		known[strings.ToLower(term.Term)] = true
+	}
+
+	created := []domain.GlossaryTerm{}
+	for _, input := range extracted {
+		input.Status = domain.TermProposed
+		term := domain.GlossaryTerm{ProjectID: projectID, SourceDocumentID: &doc.ID}
+		if err := applyGlossaryInput(&term, input); err != nil {
+			continue
+		}
+		if known[strings.ToLower(term.Term)] {
+			continue
+		}
+		known[strings.ToLower(term.Term)] = true
+
+		if err := s.repo.Create(&term); err != nil {
+			return nil, err
+		}
+		created = append(created, term)
+	}
+	return created, nil
+}
+
+// PromptGlossary formats the approved terms of a project for generation
+// prompts; it is empty when the project has none
+func (s *GlossaryService) PromptGlossary(projectID uint) string {
+	terms, err := s.repo.FindByStatus(projectID, domain.TermApproved)
+	if err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, term := range terms {
+		fmt.Fprintf(&b, "- %s", term.Term)
+		if term.Definition != "" {
+			fmt.Fprintf(&b, ": %s", term.Definition)
+		}
+		if len(term.Synonyms) > 0 {
+			fmt.Fprintf(&b, " (hindari: %s)", strings.Join(term.Synonyms, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// CheckSRS checks an SRS against the approved glossary of its project. It flags
+// synonyms used instead of the preferred term, and acronyms or quoted terms
+// that the glossary doesn't define (each reported at its first occurrence).
+func (s *GlossaryService) CheckSRS(srsID uint) ([]domain.TermIssue, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	terms, err := s.repo.FindByStatus(srs.ProjectID, domain.TermApproved)
+	if err != nil {
+		return nil, err
+	}
+	return checkTermUsage(srs.Content, terms), nil
+}
+
+func checkTermUsage(content string, terms []domain.GlossaryTerm) []domain.TermIssue {
+	type synonymRule struct {
+		re        *regexp.Regexp
+		preferred string
+	}
+
+	known := map[string]bool{}
+	var rules []synonymRule
+	for _, term := range terms {
+		known[strings.ToLower(term.Term)] = true
+		for _, synonym := range term.Synonyms {
+			known[strings.ToLower(synonym)] = true
+			if strings.EqualFold(synonym, term.Term) {
+				continue
+			}
+			rules = append(rules, synonymRule{
+				re:        regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(synonym) + `\b`),
+				preferred: term.Term,
+			})
+		}
+	}
+
+	issues := []domain.TermIssue{}
+	reported := map[string]bool{}
+	section := ""
+	inFence := false
+	for i, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := headingTitleRe.FindStringSubmatch(trimmed); m != nil {
+			section = m[1]
+		}
+
+		for _, rule := range rules {
+			for _, found := range rule.re.FindAllString(line, -1) {
+				issues = append(issues, domain.TermIssue{
+					Kind:       domain.TermIssueSynonym,
+					Found:      found,
+					Line:       i + 1,
+					Section:    section,
+					Suggestion: rule.preferred,
+				})
+			}
+		}
+
+		var candidates []string
+		for _, loc := range acronymRe.FindAllStringIndex(line, -1) {
+			// Requirement IDs such as FR-001 are not terms
+			if loc[1] < len(line) && line[loc[1]] == '-' {
+				continue
+			}
+			candidates = append(candidates, line[loc[0]:loc[1]])
+		}
+		for _, m := range quotedTermRe.FindAllStringSubmatch(line, -1) {
+			if len(strings.Fields(m[1])) <= 4 {
+				candidates = append(candidates, strings.TrimSpace(m[1]))
+			}
+		}
+		for _, candidate := range candidates {
+			key := strings.ToLower(candidate)
+			if known[key] || reported[key] || commonAcronyms[candidate] {
+				continue
+			}
+			reported[key] = true
+			issues = append(issues, domain.TermIssue{
+				Kind:    domain.TermIssueUndefined,
+				Found:   candidate,
+				Line:    i + 1,
+				Section: section,
+			})
+		}
+	}
+	return issues
+}
+
+func (s *GlossaryService) checkUniqueTerm(term *domain.GlossaryTerm) error {
+	terms, err := s.repo.FindByProject(term.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, other := range terms {
+		if other.ID != term.ID && strings.EqualFold(other.Term, term.Term) {
+			return ErrDuplicateTerm
+		}
+	}
+	return nil
+}
+
+// applyGlossaryInput validates and normalizes input onto a term
+func applyGlossaryInput(term *domain.GlossaryTerm, input GlossaryTermInput) error {
+	name := strings.TrimSpace(input.Term)
+	if name == "" {
+		return fmt.Errorf("%w: term is required", ErrInvalidGlossaryTerm)
+	}
+
+	status := domain.GlossaryTermStatus(strings.ToUpper(string(input.Status)))
+	switch status {
+	case domain.TermProposed, domain.TermApproved, domain.TermRejected:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidGlossaryTerm, input.Status)
+	}
+
+	var synonyms []string
+	for _, synonym := range input.Synonyms {
+		synonym = strings.TrimSpace(synonym)
+		if synonym != "" && !strings.EqualFold(synonym, name) && !containsFold(synonyms, synonym) {
+			synonyms = append(synonyms, synonym)
+		}
+	}
+
+	term.Term = name
+	term.Definition = strings.TrimSpace(input.Definition)
+	term.Synonyms = synonyms
+	term.Status = status
+	return nil
+}
+
+func containsFold(items []string, item string) bool {
+	for _, v := range items {
+		if strings.EqualFold(v, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGlossary extracts the JSON array from an AI response, tolerating
+// code fences or text around it
+func parseGlossary(response string) ([]GlossaryTermInput, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start < 0 || end < start {
+		return nil, ErrInvalidAIResponse
+	}
+
+	var terms []GlossaryTermInput
+	if err := json.Unmarshal([]byte(response[start:end+1]), &terms); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAIResponse, err)
+	}
+	if len(terms) == 0 {
+		return nil, ErrInvalidAIResponse
+	}
+	return terms, nil
+}
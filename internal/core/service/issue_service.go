@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var ErrIssueTrackerNotConfigured = errors.New("issue tracker is not configured")
+
+// issueSummaryLength keeps summaries well below Jira's 255 character limit
+const issueSummaryLength = 120
+
+var labelInvalidRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// IssueFilter selects which requirements become issues. Empty fields select everything.
+type IssueFilter struct {
+	Types []domain.RequirementType
+	Codes []string
+}
+
+type IssueService struct {
+	srsRepo      ports.SRSRepository
+	requirements *RequirementService
+	exporters    map[string]ports.IssueExporter
+	// nil when no tracker is configured
+	client ports.IssueTrackerClient
+}
+
+func NewIssueService(
+	srsRepo ports.SRSRepository,
+	requirements *RequirementService,
+	client ports.IssueTrackerClient,
+	exporters ...ports.IssueExporter,
+) *IssueService {
+	s := &IssueService{
+		srsRepo:      srsRepo,
+		requirements: requirements,
+		exporters:    make(map[string]ports.IssueExporter),
+		client:       client,
+	}
+	for _, e := range exporters {
+		s.exporters[e.Format()] = e
+	}
+	return s
+}
+
+// ExportIssues writes the selected requirements of an SRS in an issue-tracker import format
+func (s *IssueService) ExportIssues(srsID uint, format string, filter IssueFilter) (*ExportResult, error) {
+	exporter, ok := s.exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	srs, requirements, err := s.selectRequirements(srsID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]domain.TrackerIssue, 0, len(requirements))
+	for _, req := range requirements {
+		issues = append(issues, trackerIssue(srs, req))
+	}
+
+	var buf bytes.Buffer
+	if err := exporter.Export(&buf, issues); err != nil {
+		return nil, fmt.Errorf("failed to export %s issues: %w", exporter.Format(), err)
+	}
+
+	return &ExportResult{
+		Data:        buf.Bytes(),
+		Filename:    fmt.Sprintf("SRS-%d-v%s-%s-issues.%s", srs.ID, srs.Version, exporter.Format(), exporter.Extension()),
+		ContentType: exporter.MediaType(),
+	}, nil
+}
+
+// PushIssues creates an issue in the configured tracker for every selected
+// requirement that doesn't have one yet and records the returned key on it
+func (s *IssueService) PushIssues(srsID uint, filter IssueFilter) (*domain.IssuePushReport, error) {
+	if s.client == nil {
+		return nil, ErrIssueTrackerNotConfigured
+	}
+
+	srs, requirements, err := s.selectRequirements(srsID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.IssuePushReport{
+		Created: []domain.Requirement{},
+		Skipped: []string{},
+		Failed:  []domain.IssuePushFailure{},
+	}
+	for i := range requirements {
+		req := &requirements[i]
+		if req.ExternalKey != "" {
+			report.Skipped = append(report.Skipped, req.Code)
+			continue
+		}
+
+		ref, err := s.client.CreateIssue(trackerIssue(srs, *req))
+		if err != nil {
+			report.Failed = append(report.Failed, domain.IssuePushFailure{Code: req.Code, Error: err.Error()})
+			continue
+		}
+		if err := s.requirements.RecordExternalIssue(req, ref); err != nil {
+			return report, err
+		}
+		report.Created = append(report.Created, *req)
+	}
+
+	return report, nil
+}
+
+func (s *IssueService) selectRequirements(srsID uint, filter IssueFilter) (*domain.SRS, []domain.Requirement, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, nil, ErrSRSNotFound
+	}
+
+	requirements, err := s.requirements.GetRequirements(srsID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var selected []domain.Requirement
+	for _, req := range requirements {
+		if len(filter.Types) > 0 && !containsType(filter.Types, req.Type) {
+			continue
+		}
+		if len(filter.Codes) > 0 && !containsCode(filter.Codes, req.Code) {
+			continue
+		}
+		selected = append(selected, req)
+	}
+	return srs, selected, nil
+}
+
+// trackerIssue converts a requirement into a story grouped under an epic per SRS section
+func trackerIssue(srs *domain.SRS, req domain.Requirement) domain.TrackerIssue {
+	description := req.Statement
+	source := fmt.Sprintf("Sumber: %s v%s", srs.Title, srs.Version)
+	if req.Section != "" {
+		source += " - " + req.Section
+	}
+	description += "\n\n" + source
+
+	labels := []string{"srs", fmt.Sprintf("srs-%d", srs.ID)}
+	if req.Type != "" {
+		labels = append(labels, labelInvalidRe.ReplaceAllString(strings.ToLower(string(req.Type)), "-"))
+	}
+
+	return domain.TrackerIssue{
+		RequirementCode:    req.Code,
+		Summary:            issueSummary(req),
+		Description:        description,
+		AcceptanceCriteria: req.AcceptanceCriteria,
+		Priority:           trackerPriority(req.Priority),
+		Labels:             labels,
+		Epic:               req.Section,
+	}
+}
+
+func issueSummary(req domain.Requirement) string {
+	statement := strings.Join(strings.Fields(req.Statement), " ")
+	if runes := []rune(statement); len(runes) > issueSummaryLength {
+		cut := string(runes[:issueSummaryLength])
+		if i := strings.LastIndex(cut, " "); i > issueSummaryLength/2 {
+			cut = cut[:i]
+		}
+		statement = cut + "..."
+	}
+	if statement == "" {
+		return req.Code
+	}
+	return req.Code + ": " + statement
+}
+
+// trackerPriority maps SRS priorities (English, Indonesian or MoSCoW) to Jira's default scheme
+func trackerPriority(priority string) string {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "high", "tinggi", "must", "critical", "kritis":
+		return "High"
+	case "low", "rendah", "could", "won't":
+		return "Low"
+	default:
+		return "Medium"
+	}
+}
+
+func containsType(types []domain.RequirementType, t domain.RequirementType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCode(codes []string, code string) bool {
+	for _, candidate := range codes {
+		if strings.EqualFold(candidate, code) {
+			return true
+		}
+	}
+	return false
+}
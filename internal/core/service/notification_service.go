@@ -0,0 +1,381 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var ErrInvalidPreference = errors.New("invalid notification preference")
+
+// maxNotifications caps the notification log returned at once
+const maxNotifications = 500
+
+// notificationTemplate is the subject and body of one notification type
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newNotificationTemplate(subject string, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// notificationTemplates are rendered with a domain.NotificationContext
+var notificationTemplates = map[domain.NotificationType]notificationTemplate{
+	domain.NotifySRSReady: newNotificationTemplate(
+		`[{{.ProjectName}}] Draft SRS siap: {{.Filename}}`,
+		`Dokumen "{{.Filename}}" (ID {{.DocumentID}}) di project {{.ProjectName}} selesai diproses.
+Draft SRS sudah tersedia dan siap digenerate atau direview.
+`),
+	domain.NotifyReviewRequested: newNotificationTemplate(
+		`[{{.ProjectName}}] Review diminta: {{.Title}}`,
+		`{{if .ActorName}}{{.ActorName}} meminta{{else}}Diminta{{end}} review untuk SRS "{{.Title}}" (ID {{.SRSID}}) di project {{.ProjectName}}.
+Silakan review lalu setujui atau minta perubahan.
+`),
+	domain.NotifyChangesRequested: newNotificationTemplate(
+		`[{{.ProjectName}}] Perubahan diminta: {{.Title}}`,
+		`{{if .ActorName}}{{.ActorName}} meminta{{else}}Reviewer meminta{{end}} perubahan pada SRS "{{.Title}}" (ID {{.SRSID}}) di project {{.ProjectName}}.
+`),
+	domain.NotifyApproved: newNotificationTemplate(
+		`[{{.ProjectName}}] SRS disetujui: {{.Title}}`,
+		`SRS "{{.Title}}" (ID {{.SRSID}}) di project {{.ProjectName}} telah disetujui{{if .ActorName}} oleh {{.ActorName}}{{end}}.
+`),
+	domain.NotifyProcessingFailed: newNotificationTemplate(
+		`[{{.ProjectName}}] Gagal memproses dokumen: {{.Filename}}`,
+		`Pemrosesan dokumen "{{.Filename}}" (ID {{.DocumentID}}) di project {{.ProjectName}} gagal.
+
+Error: {{.Error}}
+`),
+}
+
+type NotificationService struct {
+	repo       ports.NotificationRepository
+	prefs      ports.NotificationPreferenceRepository
+	users      ports.UserRepository
+	projects   ports.ProjectRepository
+	dispatcher *NotificationDispatcher
+}
+
+// NewNotificationService takes a nil dispatcher when email is not configured;
+// notifications are then not queued at all
+func NewNotificationService(
+	repo ports.NotificationRepository,
+	prefs ports.NotificationPreferenceRepository,
+	users ports.UserRepository,
+	projects ports.ProjectRepository,
+	dispatcher *NotificationDispatcher,
+) *NotificationService {
+	return &NotificationService{
+		repo:       repo,
+		prefs:      prefs,
+		users:      users,
+		projects:   projects,
+		dispatcher: dispatcher,
+	}
+}
+
+// PreferenceInput sets a user's notification mode and subscribed types
+type PreferenceInput struct {
+	Mode  domain.NotificationMode `json:"mode"`
+	Types []string                `json:"types"`
+}
+
+// GetPreference returns the user's preference, or the default of their role
+func (s *NotificationService) GetPreference(userID uint) (*domain.NotificationPreference, error) {
+	user, err := s.users.FindByID(userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if pref, err := s.prefs.FindByUser(userID); err == nil {
+		return pref, nil
+	}
+	return domain.DefaultNotificationPreference(user), nil
+}
+
+func (s *NotificationService) SetPreference(userID uint, input PreferenceInput) (*domain.NotificationPreference, error) {
+	if _, err := s.users.FindByID(userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+	if !input.Mode.Valid() {
+		return nil, fmt.Errorf("%w: mode must be immediate, digest or off", ErrInvalidPreference)
+	}
+
+	var types []string
+	for _, raw := range input.Types {
+		t := domain.NotificationType(strings.TrimSpace(raw))
+		if _, ok := notificationTemplates[t]; !ok {
+			return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidPreference, raw)
+		}
+		types = append(types, string(t))
+	}
+
+	pref := &domain.NotificationPreference{
+		UserID: userID,
+		Mode:   input.Mode,
+		Types:  strings.Join(types, ","),
+	}
+	if err := s.prefs.Save(pref); err != nil {
+		return nil, err
+	}
+	return pref, nil
+}
+
+// GetNotifications returns the latest queued and sent emails of the tenant
+func (s *NotificationService) GetNotifications(limit int) ([]domain.Notification, error) {
+	if limit <= 0 || limit > maxNotifications {
+		limit = maxNotifications
+	}
+	return s.repo.FindRecent(limit)
+}
+
+// Notify queues an email for every active user subscribed to the type,
+// except the user who caused it. Failures are logged; they never fail the
+// action that caused the notification.
+func (s *NotificationService) Notify(t domain.NotificationType, ctx domain.NotificationContext) {
+	if s.dispatcher == nil {
+		return
+	}
+
+	if ctx.ProjectName == "" {
+		if project, err := s.projects.FindByID(ctx.ProjectID); err == nil {
+			ctx.ProjectName = project.Name
+		}
+	}
+	subject, body, err := renderNotification(t, ctx)
+	if err != nil {
+		log.Printf("notification %s: %v", t, err)
+		return
+	}
+
+	users, err := s.users.FindAll()
+	if err != nil {
+		log.Printf("notification %s: %v", t, err)
+		return
+	}
+	prefs, err := s.prefs.FindAll()
+	if err != nil {
+		log.Printf("notification %s: %v", t, err)
+		return
+	}
+	byUser := make(map[uint]*domain.NotificationPreference, len(prefs))
+	for i := range prefs {
+		byUser[prefs[i].UserID] = &prefs[i]
+	}
+
+	immediate := false
+	for i := range users {
+		user := &users[i]
+		if !user.Active || user.ID == ctx.ActorID {
+			continue
+		}
+		pref, ok := byUser[user.ID]
+		if !ok {
+			pref = domain.DefaultNotificationPreference(user)
+		}
+		if !pref.Subscribes(t) {
+			continue
+		}
+
+		now := time.Now()
+		notification := &domain.Notification{
+			UserID:        user.ID,
+			Email:         user.Email,
+			Type:          t,
+			Subject:       subject,
+			Body:          body,
+			Digest:        pref.Mode == domain.NotifyDigest,
+			Status:        domain.NotificationPending,
+			NextAttemptAt: &now,
+		}
+		if err := s.repo.Create(notification); err != nil {
+			log.Printf("notification %s for user %d: %v", t, user.ID, err)
+			continue
+		}
+		immediate = immediate || !notification.Digest
+	}
+	if immediate {
+		s.dispatcher.Wake()
+	}
+}
+
+func renderNotification(t domain.NotificationType, ctx domain.NotificationContext) (string, string, error) {
+	tmpl, ok := notificationTemplates[t]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification %q", t)
+	}
+	var subject, body bytes.Buffer
+	if err := tmpl.subject.Execute(&subject, ctx); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, ctx); err != nil {
+		return "", "", err
+	}
+	return subject.String(), body.String(), nil
+}
+
+// statusNotification maps a new SRS status to the notification it triggers
+func statusNotification(status string) (domain.NotificationType, bool) {
+	switch status {
+	case domain.SRSStatusInReview:
+		return domain.NotifyReviewRequested, true
+	case domain.SRSStatusChangesRequested:
+		return domain.NotifyChangesRequested, true
+	case domain.SRSStatusApproved:
+		return domain.NotifyApproved, true
+	default:
+		return "", false
+	}
+}
+
+// NotificationDispatcher emails queued notifications for every tenant:
+// immediate ones as soon as possible, the others as one digest per recipient
+// every digest interval
+type NotificationDispatcher struct {
+	repo           ports.NotificationRepository
+	mailer         ports.Mailer
+	digestInterval time.Duration
+	wake           chan struct{}
+}
+
+// notificationMaxAttempts is how often sending an immediate email is tried
+const notificationMaxAttempts = 3
+
+// notificationRetryDelay is the wait before an immediate email is tried
+// again; failed digests are retried with the next digest
+const notificationRetryDelay = time.Minute
+
+// notificationClaimLease hides claimed notifications from other workers
+// while they are being sent
+const notificationClaimLease = 5 * time.Minute
+
+// NewNotificationDispatcher takes an unscoped notification repository
+func NewNotificationDispatcher(repo ports.NotificationRepository, mailer ports.Mailer, digestInterval time.Duration) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		repo:           repo,
+		mailer:         mailer,
+		digestInterval: digestInterval,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Wake makes Run send immediate notifications now instead of at its next tick
+func (d *NotificationDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends immediate notifications every interval, or sooner when woken,
+// and digests every digest interval, until the process exits
+func (d *NotificationDispatcher) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	nextDigest := time.Now().Add(d.digestInterval)
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		d.sendImmediate()
+		if now := time.Now(); !now.Before(nextDigest) {
+			d.sendDigests()
+			nextDigest = now.Add(d.digestInterval)
+		}
+	}
+}
+
+func (d *NotificationDispatcher) sendImmediate() {
+	for {
+		notifications, err := d.repo.ClaimDue(false, time.Now(), notificationClaimLease, 20)
+		if err != nil {
+			log.Printf("notification dispatch failed: %v", err)
+			return
+		}
+		if len(notifications) == 0 {
+			return
+		}
+		for i := range notifications {
+			n := &notifications[i]
+			d.record([]*domain.Notification{n}, d.mailer.Send(n.Email, n.Subject, n.Body), notificationRetryDelay)
+		}
+	}
+}
+
+// sendDigests sends each recipient one email with all their pending digest
+// notifications
+func (d *NotificationDispatcher) sendDigests() {
+	for {
+		notifications, err := d.repo.ClaimDue(true, time.Now(), notificationClaimLease, 500)
+		if err != nil {
+			log.Printf("notification digest failed: %v", err)
+			return
+		}
+		if len(notifications) == 0 {
+			return
+		}
+
+		byEmail := map[string][]*domain.Notification{}
+		var order []string
+		for i := range notifications {
+			n := &notifications[i]
+			if _, ok := byEmail[n.Email]; !ok {
+				order = append(order, n.Email)
+			}
+			byEmail[n.Email] = append(byEmail[n.Email], n)
+		}
+
+		for _, email := range order {
+			batch := byEmail[email]
+			subject, body := digestEmail(batch)
+			d.record(batch, d.mailer.Send(email, subject, body), d.digestInterval)
+		}
+	}
+}
+
+func digestEmail(batch []*domain.Notification) (string, string) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Ringkasan %d notifikasi SRS Automation:\n", len(batch))
+	for _, n := range batch {
+		fmt.Fprintf(&body, "\n== %s (%s) ==\n%s", n.Subject, n.CreatedAt.Format("2006-01-02 15:04"), n.Body)
+	}
+	return fmt.Sprintf("Ringkasan notifikasi SRS (%d)", len(batch)), body.String()
+}
+
+// record stores the outcome of sending notifications; failed ones are tried
+// again after retryDelay
+func (d *NotificationDispatcher) record(batch []*domain.Notification, sendErr error, retryDelay time.Duration) {
+	now := time.Now()
+	for _, n := range batch {
+		n.Attempts++
+		switch {
+		case sendErr == nil:
+			n.Status = domain.NotificationSent
+			n.Error = ""
+			n.SentAt = &now
+			n.NextAttemptAt = nil
+		case !n.Digest && n.Attempts >= notificationMaxAttempts:
+			n.Status = domain.NotificationFailed
+			n.Error = sendErr.Error()
+			n.NextAttemptAt = nil
+		default:
+			retryAt := now.Add(retryDelay)
+			n.Error = sendErr.Error()
+			n.NextAttemptAt = &retryAt
+		}
+		if err := d.repo.Update(n); err != nil {
+			log.Printf("notification %d: %v", n.ID, err)
+		}
+	}
+}
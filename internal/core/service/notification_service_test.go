@@ -0,0 +1,304 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/infra/external"
+)
+
+// memoryNotifications is a notification repository kept in memory
+type memoryNotifications struct {
+	memoryQueue[domain.Notification]
+}
+
+func newMemoryNotifications() *memoryNotifications {
+	return &memoryNotifications{memoryQueue[domain.Notification]{
+		id:          func(n *domain.Notification) *uint { return &n.ID },
+		nextAttempt: func(n *domain.Notification) **time.Time { return &n.NextAttemptAt },
+	}}
+}
+
+func (m *memoryNotifications) Create(notification *domain.Notification) error {
+	notification.CreatedAt = time.Now()
+	m.add(notification)
+	return nil
+}
+
+func (m *memoryNotifications) Update(notification *domain.Notification) error {
+	m.update(notification)
+	return nil
+}
+
+// FindRecent is only used by the notification log endpoint
+func (m *memoryNotifications) FindRecent(limit int) ([]domain.Notification, error) {
+	return nil, nil
+}
+
+func (m *memoryNotifications) ClaimDue(digest bool, now time.Time, lease time.Duration, limit int) ([]domain.Notification, error) {
+	return m.claimDue(now, lease, limit, func(n *domain.Notification) bool {
+		return n.Digest == digest && n.Status == domain.NotificationPending
+	}), nil
+}
+
+// queueNotification stores a pending notification and returns its ID
+func queueNotification(t *testing.T, repo *memoryNotifications, email string, subject string, digest bool) uint {
+	t.Helper()
+
+	n := &domain.Notification{
+		UserID:  1,
+		Email:   email,
+		Type:    domain.NotifySRSReady,
+		Subject: subject,
+		Body:    "Isi " + subject,
+		Digest:  digest,
+		Status:  domain.NotificationPending,
+	}
+	if err := repo.Create(n); err != nil {
+		t.Fatalf("queue notification: %v", err)
+	}
+	return n.ID
+}
+
+// smtpMessage is one email accepted by the stub
+type smtpMessage struct {
+	to   string
+	data string
+}
+
+// smtpStub is a minimal SMTP server. It rejects the first failures emails
+// it is sent and accepts the rest.
+type smtpStub struct {
+	mu       sync.Mutex
+	failures int
+	messages []smtpMessage
+}
+
+// newSMTPStub listens on a local port and points SMTP_HOST and SMTP_PORT at it
+func newSMTPStub(t *testing.T, failures int) *smtpStub {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	t.Setenv("SMTP_HOST", host)
+	t.Setenv("SMTP_PORT", port)
+	t.Setenv("SMTP_USERNAME", "")
+
+	stub := &smtpStub{failures: failures}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	return stub
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 stub ESMTP")
+
+	var to string
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		switch verb, arg, _ := strings.Cut(line, " "); strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 stub")
+		case "MAIL":
+			to = ""
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			if s.failures > 0 {
+				s.failures--
+				s.mu.Unlock()
+				tp.PrintfLine("554 Transaction failed")
+				continue
+			}
+			s.messages = append(s.messages, smtpMessage{to: to, data: string(data)})
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 Bye")
+			return
+		default:
+			tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (s *smtpStub) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func TestNotificationImmediateSent(t *testing.T) {
+	stub := newSMTPStub(t, 0)
+	repo := newMemoryNotifications()
+	first := queueNotification(t, repo, "ana@example.com", "SRS dibuat", false)
+	second := queueNotification(t, repo, "budi@example.com", "SRS disetujui", false)
+
+	NewNotificationDispatcher(repo, external.NewMailer(), time.Hour).sendImmediate()
+
+	messages := stub.received()
+	if len(messages) != 2 {
+		t.Fatalf("stub received %d emails, want 2", len(messages))
+	}
+	for i, want := range []struct{ to, body string }{
+		{"ana@example.com", "Isi SRS dibuat"},
+		{"budi@example.com", "Isi SRS disetujui"},
+	} {
+		if messages[i].to != want.to || !strings.Contains(messages[i].data, want.body) {
+			t.Errorf("email %d to %s:\n%s\nwant to %s containing %q", i, messages[i].to, messages[i].data, want.to, want.body)
+		}
+	}
+	for _, id := range []uint{first, second} {
+		if n := repo.get(id); n.Status != domain.NotificationSent || n.SentAt == nil || n.Attempts != 1 {
+			t.Errorf("notification %d = %s after %d attempts, want sent after 1", id, n.Status, n.Attempts)
+		}
+	}
+}
+
+func TestNotificationImmediateSentInBatches(t *testing.T) {
+	stub := newSMTPStub(t, 0)
+	repo := newMemoryNotifications()
+	for i := 0; i < 25; i++ {
+		queueNotification(t, repo, fmt.Sprintf("user%d@example.com", i), "SRS dibuat", false)
+	}
+
+	NewNotificationDispatcher(repo, external.NewMailer(), time.Hour).sendImmediate()
+
+	if batches := repo.claimedBatches(); !slices.Equal(batches, []int{20, 5}) {
+		t.Errorf("claimed batches %v, want [20 5]", batches)
+	}
+	if n := len(stub.received()); n != 25 {
+		t.Errorf("stub received %d emails, want 25", n)
+	}
+}
+
+func TestNotificationDigestOneEmailPerRecipient(t *testing.T) {
+	stub := newSMTPStub(t, 0)
+	repo := newMemoryNotifications()
+	digests := []uint{
+		queueNotification(t, repo, "ana@example.com", "Dokumen diproses", true),
+		queueNotification(t, repo, "budi@example.com", "SRS dibuat", true),
+		queueNotification(t, repo, "ana@example.com", "SRS dibuat", true),
+		queueNotification(t, repo, "ana@example.com", "SRS disetujui", true),
+	}
+	immediate := queueNotification(t, repo, "ana@example.com", "Dokumen gagal", false)
+
+	NewNotificationDispatcher(repo, external.NewMailer(), time.Hour).sendDigests()
+
+	messages := stub.received()
+	if len(messages) != 2 {
+		t.Fatalf("stub received %d emails, want one per recipient", len(messages))
+	}
+	byRecipient := map[string]string{}
+	for _, m := range messages {
+		byRecipient[m.to] = m.data
+	}
+	for to, bodies := range map[string][]string{
+		"ana@example.com":  {"Isi Dokumen diproses", "Isi SRS dibuat", "Isi SRS disetujui"},
+		"budi@example.com": {"Isi SRS dibuat"},
+	} {
+		data, ok := byRecipient[to]
+		if !ok {
+			t.Errorf("no digest sent to %s", to)
+			continue
+		}
+		for _, body := range bodies {
+			if !strings.Contains(data, body) {
+				t.Errorf("digest to %s is missing %q:\n%s", to, body, data)
+			}
+		}
+		if strings.Contains(data, "Isi Dokumen gagal") {
+			t.Errorf("digest to %s includes an immediate notification", to)
+		}
+	}
+
+	for _, id := range digests {
+		if n := repo.get(id); n.Status != domain.NotificationSent {
+			t.Errorf("digest notification %d = %s, want sent", id, n.Status)
+		}
+	}
+	if n := repo.get(immediate); n.Status != domain.NotificationPending {
+		t.Errorf("immediate notification = %s after the digest, want pending", n.Status)
+	}
+}
+
+func TestNotificationFailedSendRetried(t *testing.T) {
+	stub := newSMTPStub(t, 1)
+	repo := newMemoryNotifications()
+	id := queueNotification(t, repo, "ana@example.com", "SRS dibuat", false)
+	d := NewNotificationDispatcher(repo, external.NewMailer(), time.Hour)
+
+	sent := time.Now()
+	d.sendImmediate()
+	n := repo.get(id)
+	if n.Status != domain.NotificationPending || n.Attempts != 1 || n.Error == "" {
+		t.Fatalf("after a rejected send: %s after %d attempts, error %q; want pending with an error", n.Status, n.Attempts, n.Error)
+	}
+	if n.NextAttemptAt == nil || n.NextAttemptAt.Before(sent.Add(notificationRetryDelay)) {
+		t.Errorf("retry scheduled at %v, want %v after the attempt", n.NextAttemptAt, notificationRetryDelay)
+	}
+
+	// Not retried before the delay is over
+	d.sendImmediate()
+	if len(stub.received()) != 0 || repo.get(id).Attempts != 1 {
+		t.Fatal("notification retried before its retry delay")
+	}
+
+	repo.makeDue(id)
+	d.sendImmediate()
+	if n := repo.get(id); n.Status != domain.NotificationSent || n.Attempts != 2 || n.Error != "" {
+		t.Errorf("after the retry: %s after %d attempts, error %q; want sent after 2", n.Status, n.Attempts, n.Error)
+	}
+	if messages := stub.received(); len(messages) != 1 || messages[0].to != "ana@example.com" {
+		t.Errorf("stub received %v, want one email to ana@example.com", messages)
+	}
+}
+
+func TestNotificationFailedAfterMaxAttempts(t *testing.T) {
+	newSMTPStub(t, notificationMaxAttempts)
+	repo := newMemoryNotifications()
+	id := queueNotification(t, repo, "ana@example.com", "SRS dibuat", false)
+	d := NewNotificationDispatcher(repo, external.NewMailer(), time.Hour)
+
+	for i := 0; i < notificationMaxAttempts; i++ {
+		repo.makeDue(id)
+		d.sendImmediate()
+	}
+
+	n := repo.get(id)
+	if n.Status != domain.NotificationFailed || n.Attempts != notificationMaxAttempts || n.NextAttemptAt != nil {
+		t.Errorf("notification = %s after %d attempts, want failed after %d", n.Status, n.Attempts, notificationMaxAttempts)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"time"
+)
+
+var ErrInvalidListQuery = errors.New("invalid list query")
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// pageCursor is the position after the last row of a page. It repeats the
+// sort, so a cursor can't be used with a different order.
+type pageCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// preparePage applies the default limit and sort and decodes the cursor.
+// Without a sort, a cursor continues in its own order; otherwise the first
+// of fields is used, newest first for a time field.
+func preparePage(page *domain.PageRequest, fields []domain.SortField) error {
+	switch {
+	case page.Limit == 0:
+		page.Limit = defaultPageSize
+	case page.Limit < 0 || page.Limit > maxPageSize:
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListQuery, maxPageSize)
+	}
+	if page.Offset < 0 {
+		return fmt.Errorf("%w: negative offset", ErrInvalidListQuery)
+	}
+
+	var cursor *pageCursor
+	if page.Cursor != "" {
+		if page.Offset > 0 {
+			return fmt.Errorf("%w: use either offset or cursor", ErrInvalidListQuery)
+		}
+		var err error
+		if cursor, err = decodeCursor(page.Cursor); err != nil {
+			return err
+		}
+		if page.Sort == "" {
+			page.Sort, page.Desc = cursor.Sort, cursor.Desc
+		}
+	}
+	if page.Sort == "" {
+		page.Sort, page.Desc = fields[0].Name, fields[0].Time
+	}
+	field, ok := sortField(fields, page.Sort)
+	if !ok {
+		return fmt.Errorf("%w: cannot sort by %q", ErrInvalidListQuery, page.Sort)
+	}
+	if cursor == nil {
+		return nil
+	}
+
+	invalid := fmt.Errorf("%w: invalid cursor", ErrInvalidListQuery)
+	if cursor.Sort != page.Sort || cursor.Desc != page.Desc {
+		return invalid
+	}
+	page.After = &domain.PagePosition{Value: cursor.Value, ID: cursor.ID}
+	if field.Time {
+		at, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return invalid
+		}
+		page.After.Value = at
+	}
+	return nil
+}
+
+func decodeCursor(encoded string) (*pageCursor, error) {
+	var cursor pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(raw, &cursor)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrInvalidListQuery)
+	}
+	return &cursor, nil
+}
+
+func sortField(fields []domain.SortField, name string) (domain.SortField, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return domain.SortField{}, false
+}
+
+// pageInfo describes a page loaded with one row beyond the limit. When that
+// row exists, the cursor of the page's last row leads to the next page.
+func pageInfo[T any](page domain.PageRequest, total int64, rows []T, sortValue func(row T) (any, uint)) ([]T, domain.PageInfo) {
+	info := domain.PageInfo{Total: total, Limit: page.Limit, Offset: page.Offset, Sort: page.Sort}
+	if page.Desc {
+		info.Sort = "-" + page.Sort
+	}
+	if len(rows) <= page.Limit {
+		return rows, info
+	}
+
+	rows = rows[:page.Limit]
+	value, id := sortValue(rows[len(rows)-1])
+	cursor := pageCursor{Sort: page.Sort, Desc: page.Desc, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		cursor.Value = v.UTC().Format(time.RFC3339Nano)
+	default:
+		cursor.Value = fmt.Sprint(v)
+	}
+	raw, _ := json.Marshal(cursor)
+	info.NextCursor = base64.RawURLEncoding.EncodeToString(raw)
+	return rows, info
+}
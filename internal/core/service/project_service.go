@@ -0,0 +1,335 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrDuplicateProject = errors.New("project name already exists")
+	ErrInvalidProject   = errors.New("invalid project")
+	ErrProjectNotEmpty  = errors.New("project still has documents, SRS, templates or glossary terms")
+	ErrNotInProject     = errors.New("resource not found in this project")
+)
+
+// projectLanguages are the output languages a project can generate documents in
+var projectLanguages = map[string]bool{"id": true, "en": true}
+
+// ProjectResource names a project-owned resource for ownership checks
+type ProjectResource string
+
+const (
+	ResourceDocument     ProjectResource = "document"
+	ResourceSRS          ProjectResource = "srs"
+	ResourceTemplate     ProjectResource = "template"
+	ResourceGlossaryTerm ProjectResource = "glossary_term"
+	ResourceUserStory    ProjectResource = "user_story"
+	ResourceDataEntity   ProjectResource = "data_entity"
+)
+
+type ProjectService struct {
+	repo          ports.ProjectRepository
+	docRepo       ports.DocumentRepository
+	srsRepo       ports.SRSRepository
+	templateRepo  ports.DocxTemplateRepository
+	glossaryRepo  ports.GlossaryRepository
+	userStoryRepo ports.UserStoryRepository
+	dataRepo      ports.DataEntityRepository
+	templates     *TemplateService
+	glossary      *GlossaryService
+	ai            *AIResolver
+}
+
+func NewProjectService(
+	repo ports.ProjectRepository,
+	docRepo ports.DocumentRepository,
+	srsRepo ports.SRSRepository,
+	templateRepo ports.DocxTemplateRepository,
+	glossaryRepo ports.GlossaryRepository,
+	userStoryRepo ports.UserStoryRepository,
+	dataRepo ports.DataEntityRepository,
+	templates *TemplateService,
+	glossary *GlossaryService,
+	ai *AIResolver,
+) *ProjectService {
+	return &ProjectService{
+		repo:          repo,
+		docRepo:       docRepo,
+		srsRepo:       srsRepo,
+		templateRepo:  templateRepo,
+		glossaryRepo:  glossaryRepo,
+		userStoryRepo: userStoryRepo,
+		dataRepo:      dataRepo,
+		templates:     templates,
+		glossary:      glossary,
+		ai:            ai,
+	}
+}
+
+// ProjectInput holds the editable project fields; on update empty fields are
+// left unchanged
+type ProjectInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Language    string `json:"language"`
+	AIProvider  string `json:"ai_provider"`
+}
+
+// ProjectSettings holds the project-level generation settings; nil fields are
+// left unchanged
+type ProjectSettings struct {
+	DefaultTemplateID *uint   `json:"default_template_id"`
+	Language          *string `json:"language"`
+	AIProvider        *string `json:"ai_provider"`
+	// USD per month, 0 for no limit
+	MonthlyAIBudget *float64 `json:"monthly_ai_budget"`
+	// Replaces the project's custom redaction terms
+	RedactionTerms *[]string `json:"redaction_terms"`
+}
+
+func (s *ProjectService) CreateProject(input ProjectInput) (*domain.Project, error) {
+	project := &domain.Project{
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+		Language:    strings.ToLower(strings.TrimSpace(input.Language)),
+		AIProvider:  strings.TrimSpace(input.AIProvider),
+	}
+	if project.Language == "" {
+		project.Language = "id"
+	}
+	if err := s.validate(project); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *ProjectService) GetProjects() ([]domain.Project, error) {
+	projects, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		s.fillDefaultTemplate(&projects[i])
+	}
+	return projects, nil
+}
+
+func (s *ProjectService) GetProject(id uint) (*domain.Project, error) {
+	project, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	s.fillDefaultTemplate(project)
+	return project, nil
+}
+
+func (s *ProjectService) UpdateProject(id uint, input ProjectInput) (*domain.Project, error) {
+	project, err := s.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		project.Name = name
+	}
+	if description := strings.TrimSpace(input.Description); description != "" {
+		project.Description = description
+	}
+	if language := strings.TrimSpace(input.Language); language != "" {
+		project.Language = strings.ToLower(language)
+	}
+	if provider := strings.TrimSpace(input.AIProvider); provider != "" {
+		project.AIProvider = provider
+	}
+	if err := s.validate(project); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// UpdateSettings changes the default export template, output language, AI
+// provider, monthly AI budget or redaction terms of a project. An AI provider
+// of "" returns to the server default.
+func (s *ProjectService) UpdateSettings(id uint, settings ProjectSettings) (*domain.Project, error) {
+	project, err := s.GetProject(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.DefaultTemplateID != nil {
+		if err := s.CheckOwnership(id, ResourceTemplate, *settings.DefaultTemplateID); err != nil {
+			return nil, ErrTemplateNotFound
+		}
+	}
+	if settings.Language != nil {
+		project.Language = strings.ToLower(strings.TrimSpace(*settings.Language))
+	}
+	if settings.AIProvider != nil {
+		project.AIProvider = strings.TrimSpace(*settings.AIProvider)
+	}
+	if settings.MonthlyAIBudget != nil {
+		project.MonthlyAIBudget = *settings.MonthlyAIBudget
+	}
+	if settings.RedactionTerms != nil {
+		project.RedactionTerms = redactionTerms(*settings.RedactionTerms)
+	}
+	if err := s.validate(project); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(project); err != nil {
+		return nil, err
+	}
+	if settings.DefaultTemplateID != nil {
+		if err := s.templates.SetDefault(*settings.DefaultTemplateID); err != nil {
+			return nil, err
+		}
+		project.DefaultTemplateID = settings.DefaultTemplateID
+	}
+	return project, nil
+}
+
+// redactionTerms trims the terms and drops empty and repeated ones
+func redactionTerms(terms []string) []string {
+	seen := map[string]bool{}
+	cleaned := []string{}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" || seen[strings.ToLower(term)] {
+			continue
+		}
+		seen[strings.ToLower(term)] = true
+		cleaned = append(cleaned, term)
+	}
+	return cleaned
+}
+
+// DeleteProject removes an empty project; its content has to be deleted first
+func (s *ProjectService) DeleteProject(id uint) error {
+	if _, err := s.GetProject(id); err != nil {
+		return err
+	}
+
+	docs, err := s.docRepo.FindByProject(id)
+	if err != nil {
+		return err
+	}
+	srsList, err := s.srsRepo.FindByProject(id)
+	if err != nil {
+		return err
+	}
+	templates, err := s.templateRepo.FindByProject(id)
+	if err != nil {
+		return err
+	}
+	terms, err := s.glossaryRepo.FindByProject(id)
+	if err != nil {
+		return err
+	}
+	if len(docs)+len(srsList)+len(templates)+len(terms) > 0 {
+		return ErrProjectNotEmpty
+	}
+
+	return s.repo.Delete(id)
+}
+
+// PromptContext collects the project settings that shape generation prompts
+func (s *ProjectService) PromptContext(projectID uint) domain.PromptContext {
+	ctx := domain.PromptContext{Glossary: s.glossary.PromptGlossary(projectID)}
+	if project, err := s.repo.FindByID(projectID); err == nil {
+		ctx.Language = project.Language
+	}
+	return ctx
+}
+
+// CheckOwnership returns ErrNotInProject unless the resource exists and
+// belongs to the project
+func (s *ProjectService) CheckOwnership(projectID uint, resource ProjectResource, id uint) error {
+	owner, err := s.ownerOf(resource, id)
+	if err != nil || owner != projectID {
+		return ErrNotInProject
+	}
+	return nil
+}
+
+func (s *ProjectService) ownerOf(resource ProjectResource, id uint) (uint, error) {
+	switch resource {
+	case ResourceDocument:
+		doc, err := s.docRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return doc.ProjectID, nil
+	case ResourceSRS:
+		srs, err := s.srsRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return srs.ProjectID, nil
+	case ResourceTemplate:
+		tmpl, err := s.templateRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return tmpl.ProjectID, nil
+	case ResourceGlossaryTerm:
+		term, err := s.glossaryRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return term.ProjectID, nil
+	case ResourceUserStory:
+		story, err := s.userStoryRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return s.ownerOf(ResourceSRS, story.SRSID)
+	case ResourceDataEntity:
+		entity, err := s.dataRepo.FindByID(id)
+		if err != nil {
+			return 0, err
+		}
+		return s.ownerOf(ResourceSRS, entity.SRSID)
+	default:
+		return 0, fmt.Errorf("unknown project resource %q", resource)
+	}
+}
+
+func (s *ProjectService) validate(project *domain.Project) error {
+	if project.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidProject)
+	}
+	if !projectLanguages[project.Language] {
+		return fmt.Errorf("%w: language must be \"id\" or \"en\"", ErrInvalidProject)
+	}
+	if project.MonthlyAIBudget < 0 {
+		return fmt.Errorf("%w: monthly_ai_budget must not be negative", ErrInvalidProject)
+	}
+	if project.AIProvider != "" && !s.ai.IsProvider(project.AIProvider) {
+		return fmt.Errorf("%w: unknown AI provider %q (available: %s)",
+			ErrInvalidProject, project.AIProvider, strings.Join(s.ai.Providers(), ", "))
+	}
+
+	if existing, err := s.repo.FindByName(project.Name); err == nil && existing.ID != project.ID {
+		return ErrDuplicateProject
+	}
+	return nil
+}
+
+func (s *ProjectService) fillDefaultTemplate(project *domain.Project) {
+	if tmpl, err := s.templateRepo.FindDefault(project.ID); err == nil && tmpl != nil {
+		project.DefaultTemplateID = &tmpl.ID
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"srs-automation/pkg/markdown"
+	"strings"
+)
+
+var ErrInvalidSRSOutput = errors.New("AI response does not have the expected SRS structure")
+
+const (
+	// Flags kept per document; a document full of them is suspicious enough
+	maxInjectionFlags = 20
+	// Characters of the flagged line kept as the excerpt
+	injectionExcerptLength = 160
+	// Headings an SRS has at the least, e.g. introduction, functional and
+	// non-functional requirements
+	minSRSSections = 3
+)
+
+// injectionRules match text addressed to the AI instead of describing the
+// business: attempts to cancel the prompt, to give the model a new role, or
+// chat markup that imitates a message boundary
+var injectionRules = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"override_instructions", regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+|the\s+)?(previous|prior|above|earlier|preceding|system)\s+(instructions?|prompts?|rules?|directions?)`)},
+	{"override_instructions", regexp.MustCompile(`(?i)\b(abaikan|lupakan|jangan\s+ikuti)\s+(semua\s+|seluruh\s+)?(instruksi|perintah|aturan|prompt)\b`)},
+	{"role_change", regexp.MustCompile(`(?i)\b(you\s+are\s+now|pretend\s+to\s+be|from\s+now\s+on,?\s+you|act\s+as\s+(an?\s+)?(ai|assistant|chatbot|language\s+model))\b`)},
+	{"role_change", regexp.MustCompile(`(?i)\b((kamu|anda)\s+sekarang\s+(adalah|berperan|bertindak)|berperanlah\s+sebagai|bertindaklah\s+sebagai)\b`)},
+	{"prompt_reference", regexp.MustCompile(`(?i)\b(system\s+prompt|prompt\s+sistem|jailbreak|developer\s+mode)\b`)},
+	{"output_override", regexp.MustCompile(`(?i)\b(do\s+not|don't)\s+(generate|write|produce)\s+(the\s+|an?\s+)?(srs|specification)\b|\bjangan\s+(membuat|buat|menulis|tulis|hasilkan)\s+srs\b`)},
+	{"chat_markup", regexp.MustCompile(`(?i)<\|?(im_start|im_end|system|endoftext)\|?>|\[/?INST\]|</?system>|<<<\s*(DATA|END)\s*:|^\s*assistant\s*:`)},
+}
+
+// DetectInjection flags the lines of extracted document text that match an
+// injection rule, one flag per line
+func DetectInjection(text string) []domain.InjectionFlag {
+	var flags []domain.InjectionFlag
+	for i, line := range strings.Split(text, "\n") {
+		for _, rule := range injectionRules {
+			if !rule.pattern.MatchString(line) {
+				continue
+			}
+			flags = append(flags, domain.InjectionFlag{Rule: rule.name, Line: i + 1, Excerpt: injectionExcerpt(line)})
+			if len(flags) == maxInjectionFlags {
+				return flags
+			}
+			break
+		}
+	}
+	return flags
+}
+
+func injectionExcerpt(line string) string {
+	excerpt := []rune(strings.TrimSpace(line))
+	if len(excerpt) > injectionExcerptLength {
+		return string(excerpt[:injectionExcerptLength]) + "…"
+	}
+	return string(excerpt)
+}
+
+// validateSRSOutput rejects a generated SRS that is not the Markdown document
+// asked for: too few headings or no functional requirements section. The
+// injection rules only apply to the document text, since an SRS for a chatbot
+// or LLM product legitimately mentions system prompts and jailbreaks.
+func validateSRSOutput(content string) error {
+	var headings int
+	functional := false
+	var walk func(sections []markdown.Section)
+	walk = func(sections []markdown.Section) {
+		for _, sec := range sections {
+			if sec.Title != "" {
+				headings++
+				if functionalRe.MatchString(sec.Title) && !nonFunctionalRe.MatchString(sec.Title) {
+					functional = true
+				}
+			}
+			walk(sec.Children)
+		}
+	}
+	walk(markdown.Outline(content))
+
+	switch {
+	case headings < minSRSSections:
+		return fmt.Errorf("%w: %d section headings, at least %d expected", ErrInvalidSRSOutput, headings, minSRSSections)
+	case !functional:
+		return fmt.Errorf("%w: no functional requirements section", ErrInvalidSRSOutput)
+	}
+	return nil
+}
+
+// GuardSRSOutput validates the SRS responses of every client. It goes inside
+// the cache so rejected responses are never cached.
+func GuardSRSOutput(clients map[string]ports.AIService) map[string]ports.AIService {
+	guarded := make(map[string]ports.AIService, len(clients))
+	for name, client := range clients {
+		guarded[name] = &guardedAI{AIService: client}
+	}
+	return guarded
+}
+
+// guardedAI passes every call through and checks the structure of generated
+// SRS documents; the JSON methods are already checked by their parsers
+type guardedAI struct {
+	ports.AIService
+}
+
+func (g *guardedAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	return &guardedAI{AIService: g.AIService.WithUsage(report)}
+}
+
+func (g *guardedAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return checkSRSOutput(g.AIService.GenerateSRS(brdContent, ctx))
+}
+
+// StreamSRS can only check the response once it is complete; the tokens
+// already passed to onToken are superseded by the failed result
+func (g *guardedAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return checkSRSOutput(g.AIService.StreamSRS(brdContent, ctx, onToken))
+}
+
+func checkSRSOutput(content string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if err := validateSRSOutput(content); err != nil {
+		return "", err
+	}
+	return content, nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryQueue stands in for the repositories of rows sent by a background
+// dispatcher, such as webhook deliveries and notifications. Rows are numbered
+// from 1 in the order they are added.
+type memoryQueue[T any] struct {
+	mu   sync.Mutex
+	rows []T
+	// Sizes of the batches claimed so far
+	batches []int
+	// Pointers to the ID and next attempt fields of a row
+	id          func(row *T) *uint
+	nextAttempt func(row *T) **time.Time
+}
+
+func (q *memoryQueue[T]) add(row *T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	*q.id(row) = uint(len(q.rows) + 1)
+	q.rows = append(q.rows, *row)
+}
+
+func (q *memoryQueue[T]) update(row *T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.rows[*q.id(row)-1] = *row
+}
+
+func (q *memoryQueue[T]) get(id uint) T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.rows[id-1]
+}
+
+// claimDue claims like the database repositories: the first limit rows that
+// are pending and due, hidden from later claims for the lease
+func (q *memoryQueue[T]) claimDue(now time.Time, lease time.Duration, limit int, pending func(row *T) bool) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var due []T
+	for i := range q.rows {
+		if len(due) == limit {
+			break
+		}
+		row := &q.rows[i]
+		if next := *q.nextAttempt(row); !pending(row) || (next != nil && next.After(now)) {
+			continue
+		}
+		hidden := now.Add(lease)
+		*q.nextAttempt(row) = &hidden
+		due = append(due, *row)
+	}
+	if len(due) > 0 {
+		q.batches = append(q.batches, len(due))
+	}
+	return due
+}
+
+// makeDue moves the next attempt of a row to now, as if its retry delay had passed
+func (q *memoryQueue[T]) makeDue(id uint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := time.Now()
+	*q.nextAttempt(&q.rows[id-1]) = &now
+}
+
+func (q *memoryQueue[T]) claimedBatches() []int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]int(nil), q.batches...)
+}
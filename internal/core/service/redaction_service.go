@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var ErrRedactionReportNotFound = errors.New("redaction report not found")
+
+// redactionRule finds one kind of sensitive value; group is the submatch
+// holding the value, 0 for the whole match
+type redactionRule struct {
+	kind    domain.RedactionKind
+	pattern *regexp.Regexp
+	group   int
+}
+
+var redactionRules = map[domain.RedactionKind]redactionRule{
+	domain.RedactEmail: {kind: domain.RedactEmail, pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+	// 99.999.999.9-999.999, or the same 15 digits unformatted
+	domain.RedactNPWP: {kind: domain.RedactNPWP, pattern: regexp.MustCompile(`\b\d{2}\.\d{3}\.\d{3}\.\d-\d{3}\.\d{3}\b|\b\d{15}\b`)},
+	// A number following "rekening", "rek.", "account" or "a/c", optionally
+	// with "no"/"nomor" and a bank name in between
+	domain.RedactBankAccount: {kind: domain.RedactBankAccount, pattern: regexp.MustCompile(`(?i)\b(?:rekening|rek\.|account|a/c)(?:\s+(?:no\.?|nomor|number))?(?:\s+[a-z]{2,12})?\s*[:.#]?\s*(\d[\d\- ]{6,20}\d)`), group: 1},
+	// 16 digit Nomor Induk Kependudukan
+	domain.RedactNIK: {kind: domain.RedactNIK, pattern: regexp.MustCompile(`\b\d{16}\b`)},
+	// Mobile numbers: 08xx, 628xx or +628xx, optionally grouped with spaces or dashes
+	domain.RedactPhone: {kind: domain.RedactPhone, pattern: regexp.MustCompile(`(?:\+62|\b62|\b0)[\s\-]?8\d{1,2}[\s\-]?\d{3,4}[\s\-]?\d{2,5}\b`)},
+}
+
+// ParseRedactionRules parses a comma separated list of built-in rules, e.g.
+// "nik,npwp,phone". An empty value enables all rules and "none" disables them.
+func ParseRedactionRules(raw string) ([]domain.RedactionKind, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return domain.RedactionKinds, nil
+	}
+	if strings.EqualFold(raw, "none") {
+		return nil, nil
+	}
+
+	enabled := map[domain.RedactionKind]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		kind := domain.RedactionKind(strings.ToLower(strings.TrimSpace(name)))
+		if _, ok := redactionRules[kind]; !ok {
+			return nil, fmt.Errorf("unknown redaction rule %q", name)
+		}
+		enabled[kind] = true
+	}
+
+	// Keep the order of domain.RedactionKinds, which resolves overlaps
+	var kinds []domain.RedactionKind
+	for _, kind := range domain.RedactionKinds {
+		if enabled[kind] {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds, nil
+}
+
+// RedactionService replaces sensitive values in the text sent to AI providers
+// with placeholders and puts the values back into the responses
+type RedactionService struct {
+	reports ports.RedactionReportRepository
+	rules   []redactionRule
+}
+
+func NewRedactionService(reports ports.RedactionReportRepository, kinds []domain.RedactionKind) *RedactionService {
+	s := &RedactionService{reports: reports}
+	for _, kind := range kinds {
+		s.rules = append(s.rules, redactionRules[kind])
+	}
+	return s
+}
+
+// Wrap redacts the calls of a client with the built-in rules and the
+// project's terms. Reports of SRS generation are saved for documentID.
+func (s *RedactionService) Wrap(client ports.AIService, terms []string, documentID uint) ports.AIService {
+	rules := append([]redactionRule{}, s.rules...)
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			rules = append(rules, redactionRule{
+				kind:    domain.RedactTerm,
+				pattern: regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`),
+			})
+		}
+	}
+	if len(rules) == 0 {
+		return client
+	}
+	return &redactingAI{next: client, service: s, rules: rules, documentID: documentID}
+}
+
+func (s *RedactionService) GetReport(documentID uint) (*domain.RedactionReport, error) {
+	report, err := s.reports.FindByDocument(documentID)
+	if err != nil {
+		return nil, ErrRedactionReportNotFound
+	}
+	return report, nil
+}
+
+func (s *RedactionService) saveReport(documentID uint, r *redactions) {
+	report := &domain.RedactionReport{
+		DocumentID: documentID,
+		Counts:     map[string]int{},
+		Findings:   r.findings,
+	}
+	for _, finding := range r.findings {
+		report.Total += finding.Occurrences
+		report.Counts[string(finding.Kind)] += finding.Occurrences
+	}
+	if err := s.reports.Save(report); err != nil {
+		log.Printf("Redaction report of document %d not saved: %v", documentID, err)
+	}
+}
+
+// redactions maps the values hidden in one AI call to their placeholders.
+// A value that appears several times gets the same placeholder.
+type redactions struct {
+	placeholders map[string]int // value -> index into findings
+	values       map[string]string
+	findings     []domain.RedactionFinding
+	counts       map[domain.RedactionKind]int
+}
+
+func newRedactions() *redactions {
+	return &redactions{
+		placeholders: map[string]int{},
+		values:       map[string]string{},
+		counts:       map[domain.RedactionKind]int{},
+	}
+}
+
+// redact replaces every match of the rules in text, rule by rule
+func (r *redactions) redact(text string, rules []redactionRule) string {
+	for _, rule := range rules {
+		text = r.apply(text, rule)
+	}
+	return text
+}
+
+func (r *redactions) apply(text string, rule redactionRule) string {
+	var out strings.Builder
+	last := 0
+	for _, match := range rule.pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2*rule.group], match[2*rule.group+1]
+		if start < 0 {
+			continue
+		}
+		out.WriteString(text[last:start])
+		out.WriteString(r.placeholder(rule.kind, text[start:end]))
+		last = end
+	}
+	if last == 0 {
+		return text
+	}
+	out.WriteString(text[last:])
+	return out.String()
+}
+
+func (r *redactions) placeholder(kind domain.RedactionKind, value string) string {
+	key := string(kind) + "\x00" + strings.ToLower(value)
+	if i, ok := r.placeholders[key]; ok {
+		r.findings[i].Occurrences++
+		return r.findings[i].Placeholder
+	}
+
+	r.counts[kind]++
+	placeholder := fmt.Sprintf("[%s_%d]", strings.ToUpper(string(kind)), r.counts[kind])
+	r.placeholders[key] = len(r.findings)
+	r.values[placeholder] = value
+	r.findings = append(r.findings, domain.RedactionFinding{
+		Placeholder: placeholder,
+		Kind:        kind,
+		Masked:      maskValue(value),
+		Occurrences: 1,
+	})
+	return placeholder
+}
+
+// restore puts the original values back in place of the placeholders
+func (r *redactions) restore(text string) string {
+	if len(r.values) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, 2*len(r.values))
+	for placeholder, value := range r.values {
+		pairs = append(pairs, placeholder, value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// maskValue keeps the first and last two characters of a value, or only the
+// first of short values
+func maskValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 6 {
+		return string(runes[:1]) + strings.Repeat("*", len(runes)-1)
+	}
+	return string(runes[:2]) + strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-2:])
+}
+
+// maxPlaceholderLength is how long a streamed "[" may stay open before it is
+// no longer taken for the start of a placeholder
+const maxPlaceholderLength = 24
+
+// tokenRestorer restores placeholders in streamed output. A placeholder may
+// be split across tokens, so text from an unclosed "[" on is held back until
+// the placeholder is complete.
+type tokenRestorer struct {
+	redactions *redactions
+	onToken    func(token string)
+	pending    string
+}
+
+func (t *tokenRestorer) write(token string) {
+	text := t.pending + token
+	cut := len(text)
+	if open := strings.LastIndex(text, "["); open >= 0 && !strings.Contains(text[open:], "]") && len(text)-open < maxPlaceholderLength {
+		cut = open
+	}
+	t.pending = text[cut:]
+	if cut > 0 {
+		t.onToken(t.redactions.restore(text[:cut]))
+	}
+}
+
+func (t *tokenRestorer) flush() {
+	if t.pending != "" {
+		t.onToken(t.redactions.restore(t.pending))
+		t.pending = ""
+	}
+}
+
+// redactingAI hides sensitive values from an AI client
+type redactingAI struct {
+	next       ports.AIService
+	service    *RedactionService
+	rules      []redactionRule
+	documentID uint
+}
+
+func (r *redactingAI) Info() domain.AIClientInfo {
+	return r.next.Info()
+}
+
+func (r *redactingAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	return &redactingAI{next: r.next.WithUsage(report), service: r.service, rules: r.rules, documentID: r.documentID}
+}
+
+// generateSRS redacts the BRD and reports what was hidden for the document
+func (r *redactingAI) generateSRS(brdContent string, ctx domain.PromptContext, generate func(brdContent string, ctx domain.PromptContext, red *redactions) (string, error)) (string, error) {
+	red := newRedactions()
+	brdContent = red.redact(brdContent, r.rules)
+	ctx.Glossary = red.redact(ctx.Glossary, r.rules)
+	ctx.Redacted = len(red.findings) > 0
+
+	if r.documentID != 0 {
+		r.service.saveReport(r.documentID, red)
+	}
+
+	response, err := generate(brdContent, ctx, red)
+	if err != nil {
+		return "", err
+	}
+	return red.restore(response), nil
+}
+
+func (r *redactingAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return r.generateSRS(brdContent, ctx, func(brdContent string, ctx domain.PromptContext, _ *redactions) (string, error) {
+		return r.next.GenerateSRS(brdContent, ctx)
+	})
+}
+
+func (r *redactingAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return r.generateSRS(brdContent, ctx, func(brdContent string, ctx domain.PromptContext, red *redactions) (string, error) {
+		restorer := &tokenRestorer{redactions: red, onToken: onToken}
+		response, err := r.next.StreamSRS(brdContent, ctx, restorer.write)
+		restorer.flush()
+		return response, err
+	})
+}
+
+func (r *redactingAI) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	red := newRedactions()
+	requirements = red.redact(requirements, r.rules)
+	ctx.Glossary = red.redact(ctx.Glossary, r.rules)
+	ctx.Redacted = len(red.findings) > 0
+
+	response, err := r.next.GenerateUserStories(requirements, ctx)
+	if err != nil {
+		return "", err
+	}
+	return red.restore(response), nil
+}
+
+// call redacts the inputs of a call without a prompt context
+func (r *redactingAI) call(fn func(input []string) (string, error), input ...string) (string, error) {
+	red := newRedactions()
+	for i := range input {
+		input[i] = red.redact(input[i], r.rules)
+	}
+
+	response, err := fn(input)
+	if err != nil {
+		return "", err
+	}
+	return red.restore(response), nil
+}
+
+func (r *redactingAI) ExtractGlossary(brdContent string) (string, error) {
+	return r.call(func(input []string) (string, error) {
+		return r.next.ExtractGlossary(input[0])
+	}, brdContent)
+}
+
+func (r *redactingAI) ExtractUseCaseModel(srsContent string) (string, error) {
+	return r.call(func(input []string) (string, error) {
+		return r.next.ExtractUseCaseModel(input[0])
+	}, srsContent)
+}
+
+func (r *redactingAI) ExtractDataDictionary(srsContent string) (string, error) {
+	return r.call(func(input []string) (string, error) {
+		return r.next.ExtractDataDictionary(input[0])
+	}, srsContent)
+}
+
+func (r *redactingAI) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return r.call(func(input []string) (string, error) {
+		return r.next.DraftAPIDesign(input[0], input[1])
+	}, requirements, entities)
+}
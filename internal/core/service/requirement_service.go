@@ -0,0 +1,87 @@
+package service
+
+import (
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"time"
+)
+
+type RequirementService struct {
+	repo    ports.RequirementRepository
+	srsRepo ports.SRSRepository
+}
+
+func NewRequirementService(repo ports.RequirementRepository, srsRepo ports.SRSRepository) *RequirementService {
+	return &RequirementService{
+		repo:    repo,
+		srsRepo: srsRepo,
+	}
+}
+
+// Sync re-extracts the requirements of an SRS from its content. Requirements
+// are matched by code so external issue keys survive edits; requirements no
+// longer present in the content are removed.
+func (s *RequirementService) Sync(srs *domain.SRS) ([]domain.Requirement, error) {
+	existing, err := s.repo.FindBySRSID(srs.ID)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]domain.Requirement, len(existing))
+	for _, req := range existing {
+		byCode[req.Code] = req
+	}
+
+	_, parsed := parseSRSStructure(srs.Content)
+	requirements := make([]domain.Requirement, 0, len(parsed))
+	for _, req := range parsed {
+		req.SRSID = srs.ID
+
+		if old, ok := byCode[req.Code]; ok {
+			delete(byCode, req.Code)
+			req.ID = old.ID
+			req.ExternalKey = old.ExternalKey
+			req.ExternalURL = old.ExternalURL
+			req.PushedAt = old.PushedAt
+			req.CreatedAt = old.CreatedAt
+			if err := s.repo.Update(&req); err != nil {
+				return nil, err
+			}
+		} else if err := s.repo.Create(&req); err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, req)
+	}
+
+	for _, stale := range byCode {
+		if err := s.repo.Delete(stale.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return requirements, nil
+}
+
+// GetRequirements returns the requirements of an SRS, extracting them first
+// for SRS records created before requirements were tracked
+func (s *RequirementService) GetRequirements(srsID uint) ([]domain.Requirement, error) {
+	requirements, err := s.repo.FindBySRSID(srsID)
+	if err != nil || len(requirements) > 0 {
+		return requirements, err
+	}
+
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+	return s.Sync(srs)
+}
+
+// RecordExternalIssue stores the key and URL of the tracker issue created for a requirement
+func (s *RequirementService) RecordExternalIssue(req *domain.Requirement, ref *domain.TrackerIssueRef) error {
+	now := time.Now()
+	req.ExternalKey = ref.Key
+	req.ExternalURL = ref.URL
+	req.PushedAt = &now
+	return s.repo.Update(req)
+}
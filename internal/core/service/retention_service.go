@@ -0,0 +1,320 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// orphanGracePeriod protects files written just before their document row is created
+const orphanGracePeriod = time.Hour
+
+type RetentionService struct {
+	docRepo        ports.DocumentRepository
+	srsRepo        ports.SRSRepository
+	templateRepo   ports.DocxTemplateRepository
+	storageService ports.FileStorageService
+	policies       map[domain.DocumentType]domain.RetentionPolicy
+}
+
+func NewRetentionService(
+	docRepo ports.DocumentRepository,
+	srsRepo ports.SRSRepository,
+	templateRepo ports.DocxTemplateRepository,
+	storageService ports.FileStorageService,
+	policies []domain.RetentionPolicy,
+) *RetentionService {
+	byType := make(map[domain.DocumentType]domain.RetentionPolicy, len(policies))
+	for _, p := range policies {
+		byType[p.DocumentType] = p
+	}
+
+	return &RetentionService{
+		docRepo:        docRepo,
+		srsRepo:        srsRepo,
+		templateRepo:   templateRepo,
+		storageService: storageService,
+		policies:       byType,
+	}
+}
+
+// ParseRetentionPolicies parses policies in the form
+// "BRD:source=90,result=365,records=0;OTHER:source=30".
+// Omitted values and 0 mean the artifact is kept forever.
+func ParseRetentionPolicies(raw string) ([]domain.RetentionPolicy, error) {
+	var policies []domain.RetentionPolicy
+
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		docType, rules, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid retention policy %q", entry)
+		}
+
+		policy := domain.RetentionPolicy{DocumentType: domain.DocumentType(strings.ToUpper(strings.TrimSpace(docType)))}
+		for _, rule := range strings.Split(rules, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(rule), "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid retention rule %q", rule)
+			}
+
+			days, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || days < 0 {
+				return nil, fmt.Errorf("invalid retention days %q", value)
+			}
+
+			switch strings.TrimSpace(key) {
+			case "source":
+				policy.SourceDays = days
+			case "result":
+				policy.ResultDays = days
+			case "records":
+				policy.RecordDays = days
+			default:
+				return nil, fmt.Errorf("unknown retention rule %q", key)
+			}
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+func (s *RetentionService) Policies() []domain.RetentionPolicy {
+	policies := make([]domain.RetentionPolicy, 0, len(s.policies))
+	for _, p := range s.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].DocumentType < policies[j].DocumentType
+	})
+	return policies
+}
+
+// RunSweeper purges expired and orphaned data every interval until the process exits
+func (s *RetentionService) RunSweeper(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		report, err := s.Sweep(false)
+		if err != nil {
+			log.Printf("retention sweep failed: %v", err)
+			continue
+		}
+		log.Printf("retention sweep finished: %d actions, %d bytes freed", len(report.Actions), report.FreedBytes)
+	}
+}
+
+// Sweep applies the retention policies and removes orphaned files and rows.
+// With dryRun set, nothing is deleted and the report lists what would be purged.
+func (s *RetentionService) Sweep(dryRun bool) (*domain.RetentionReport, error) {
+	now := time.Now()
+	report := &domain.RetentionReport{
+		DryRun:      dryRun,
+		GeneratedAt: now,
+		Policies:    s.Policies(),
+		Actions:     []domain.RetentionAction{},
+	}
+
+	docs, err := s.docRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.storageService.ListFiles()
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(files))
+	for _, f := range files {
+		sizes[filepath.Clean(f.Path)] = f.Size
+	}
+
+	referenced := make(map[string]bool)
+	for i := range docs {
+		doc := &docs[i]
+		kept := s.applyPolicy(doc, now, dryRun, sizes, report)
+		for _, path := range kept {
+			referenced[filepath.Clean(path)] = true
+		}
+	}
+
+	// Export templates are kept until they are deleted through the API
+	templates, err := s.templateRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range templates {
+		referenced[filepath.Clean(tmpl.FilePath)] = true
+	}
+
+	// Files on disk that nothing points to
+	for _, f := range files {
+		path := filepath.Clean(f.Path)
+		if referenced[path] || now.Sub(f.ModTime) < orphanGracePeriod {
+			continue
+		}
+
+		action := domain.RetentionAction{
+			Action: domain.RetentionDeleteOrphanFile,
+			Path:   f.Path,
+			Size:   f.Size,
+			Reason: "file is not referenced by any document or template",
+		}
+		if !dryRun {
+			if err := s.storageService.DeleteFile(f.Path); err != nil && !os.IsNotExist(err) {
+				action.Error = err.Error()
+			}
+		}
+		report.Add(action)
+	}
+
+	// SRS rows whose source document is gone
+	orphans, err := s.srsRepo.FindOrphaned()
+	if err != nil {
+		return nil, err
+	}
+	for _, srs := range orphans {
+		action := domain.RetentionAction{
+			Action:     domain.RetentionDeleteOrphanSRS,
+			DocumentID: srs.SourceDocumentID,
+			SRSID:      srs.ID,
+			Reason:     "source document no longer exists",
+		}
+		if !dryRun {
+			if err := s.srsRepo.Delete(srs.ID); err != nil {
+				action.Error = err.Error()
+			}
+		}
+		report.Add(action)
+	}
+
+	// Rows of SRS-owned tables whose SRS was deleted without them
+	if err := s.sweepOrphanedSRSData(dryRun, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *RetentionService) sweepOrphanedSRSData(dryRun bool, report *domain.RetentionReport) error {
+	find := s.srsRepo.DeleteOrphanedDependents
+	if dryRun {
+		find = s.srsRepo.CountOrphanedDependents
+	}
+	counts, err := find()
+	if err != nil {
+		return err
+	}
+
+	tables := make([]string, 0, len(counts))
+	for table := range counts {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		report.Add(domain.RetentionAction{
+			Action: domain.RetentionDeleteOrphanSRSData,
+			Table:  table,
+			Rows:   counts[table],
+			Reason: "the SRS these rows belong to no longer exists",
+		})
+	}
+	return nil
+}
+
+// applyPolicy purges the expired artifacts of a document and returns the file
+// paths that are still referenced afterwards.
+func (s *RetentionService) applyPolicy(doc *domain.Document, now time.Time, dryRun bool, sizes map[string]int64, report *domain.RetentionReport) []string {
+	policy, ok := s.policies[doc.Type]
+	age := now.Sub(doc.CreatedAt)
+	expired := func(days int) bool {
+		return ok && days > 0 && age > time.Duration(days)*24*time.Hour
+	}
+
+	if expired(policy.RecordDays) {
+		action := domain.RetentionAction{
+			Action:     domain.RetentionDeleteDocument,
+			DocumentID: doc.ID,
+			Size:       sizes[filepath.Clean(doc.FilePath)] + sizes[filepath.Clean(doc.GoogleDocLink)],
+			Reason:     fmt.Sprintf("%s records are kept for %d days", doc.Type, policy.RecordDays),
+		}
+		if !dryRun {
+			if err := deleteDocumentData(s.docRepo, s.srsRepo, s.storageService, doc); err != nil {
+				action.Error = err.Error()
+			}
+		}
+		report.Add(action)
+		return nil
+	}
+
+	var kept []string
+	changed := false
+
+	if doc.FilePath != "" && doc.SourcePurgedAt == nil {
+		if expired(policy.SourceDays) {
+			action := domain.RetentionAction{
+				Action:     domain.RetentionDeleteSource,
+				DocumentID: doc.ID,
+				Path:       doc.FilePath,
+				Size:       sizes[filepath.Clean(doc.FilePath)],
+				Reason:     fmt.Sprintf("%s source files are kept for %d days", doc.Type, policy.SourceDays),
+			}
+			if !dryRun {
+				if err := s.storageService.DeleteFile(doc.FilePath); err != nil && !os.IsNotExist(err) {
+					action.Error = err.Error()
+				} else {
+					doc.SourcePurgedAt = &now
+					changed = true
+				}
+			}
+			report.Add(action)
+		} else {
+			kept = append(kept, doc.FilePath)
+		}
+	}
+
+	if doc.GoogleDocLink != "" && doc.ResultPurgedAt == nil {
+		if expired(policy.ResultDays) {
+			action := domain.RetentionAction{
+				Action:     domain.RetentionDeleteResult,
+				DocumentID: doc.ID,
+				Path:       doc.GoogleDocLink,
+				Size:       sizes[filepath.Clean(doc.GoogleDocLink)],
+				Reason:     fmt.Sprintf("%s generated files are kept for %d days", doc.Type, policy.ResultDays),
+			}
+			if !dryRun {
+				if err := s.storageService.DeleteFile(doc.GoogleDocLink); err != nil && !os.IsNotExist(err) {
+					action.Error = err.Error()
+				} else {
+					doc.ResultPurgedAt = &now
+					changed = true
+				}
+			}
+			report.Add(action)
+		} else {
+			kept = append(kept, doc.GoogleDocLink)
+		}
+	}
+
+	if changed {
+		if err := s.docRepo.Update(doc); err != nil {
+			log.Printf("retention: failed to update document %d: %v", doc.ID, err)
+		}
+	}
+
+	return kept
+}
@@ -3,71 +3,132 @@ package service
 import (
 	"encoding/json"
 	"errors"
+	"log"
 	"srs-automation/internal/core/domain"
 	"srs-automation/internal/core/ports"
+	"time"
 )
 
 type SRSService struct {
-	srsRepo   ports.SRSRepository
-	docRepo   ports.DocumentRepository
-	aiService ports.AIService
+	srsRepo       ports.SRSRepository
+	revisionRepo  ports.SRSRevisionRepository
+	docRepo       ports.DocumentRepository
+	ai            *AIResolver
+	requirements  *RequirementService
+	projects      *ProjectService
+	audit         *AuditService
+	webhooks      *WebhookService
+	notifications *NotificationService
+	// Extracts the data dictionary of each generated SRS
+	dataDictionary *DataDictionaryService
 }
 
 func NewSRSService(
 	srsRepo ports.SRSRepository,
+	revisionRepo ports.SRSRevisionRepository,
 	docRepo ports.DocumentRepository,
-	aiService ports.AIService,
+	ai *AIResolver,
+	requirements *RequirementService,
+	projects *ProjectService,
+	audit *AuditService,
+	webhooks *WebhookService,
+	notifications *NotificationService,
+	dataDictionary *DataDictionaryService,
 ) *SRSService {
 	return &SRSService{
-		srsRepo:   srsRepo,
-		docRepo:   docRepo,
-		aiService: aiService,
+		srsRepo:        srsRepo,
+		revisionRepo:   revisionRepo,
+		docRepo:        docRepo,
+		ai:             ai,
+		requirements:   requirements,
+		projects:       projects,
+		audit:          audit,
+		webhooks:       webhooks,
+		notifications:  notifications,
+		dataDictionary: dataDictionary,
 	}
 }
 
-func (s *SRSService) GenerateSRS(documentID uint, title string) (*domain.SRS, error) {
+func (s *SRSService) GenerateSRS(actor domain.AuditActor, projectID uint, documentID uint, title string, author string) (*domain.SRS, error) {
 	// Get source document
 	doc, err := s.docRepo.FindByID(documentID)
 	if err != nil {
 		return nil, err
 	}
+	if doc.ProjectID != projectID {
+		return nil, ErrNotInProject
+	}
 
 	if doc.Status != domain.StatusCompleted {
 		return nil, errors.New("document must be processed first")
 	}
 
-	// Generate SRS using AI
-	srsContent, err := s.aiService.GenerateSRS("")
+	brdContent, err := readDocumentText(doc, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Parse sections (simplified)
-	sections := []domain.SRSSection{
-		{Title: "Introduction", Content: "Generated introduction"},
-		{Title: "Functional Requirements", Content: "Generated requirements"},
-		{Title: "Non-Functional Requirements", Content: "Generated non-functional requirements"},
+	// Generate SRS using AI. The redaction report of the document belongs to
+	// its processing run, so this call doesn't record one.
+	srsContent, err := s.ai.ForProject(doc.ProjectID).GenerateSRS(brdContent, s.projects.PromptContext(doc.ProjectID))
+	if err != nil {
+		return nil, err
 	}
 
-	sectionsJSON, _ := json.Marshal(sections)
+	sections, err := srsSections(srsContent)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create SRS record
 	srs := &domain.SRS{
+		ProjectID:        projectID,
 		SourceDocumentID: documentID,
 		Title:            title,
 		Version:          "1.0",
 		Content:          srsContent,
-		Sections:         string(sectionsJSON),
+		Sections:         sections,
 		Status:           "DRAFT",
+		Author:           author,
 	}
 
 	if err := s.srsRepo.Create(srs); err != nil {
 		return nil, err
 	}
 
+	if err := s.revisionRepo.Create(srs.Snapshot(1)); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requirements.Sync(srs); err != nil {
+		return nil, err
+	}
+
+	if err := s.audit.Record(actor, domain.AuditActionGenerate, domain.AuditEntitySRS, srs.ID, "", auditHash(srs)); err != nil {
+		return nil, err
+	}
+	s.webhooks.Emit(srs.ProjectID, domain.WebhookSRSGenerated, webhookSRS(srs))
+	go s.extractDataDictionary(srs.ID)
+
 	return srs, nil
 }
 
+// srsSections is the section tree of SRS content, as stored in SRS.Sections
+func srsSections(content string) (string, error) {
+	sections, _ := parseSRSStructure(content)
+	data, err := json.Marshal(sections)
+	return string(data), err
+}
+
+// extractDataDictionary runs the data dictionary step of the pipeline for a
+// new SRS. A failure is only logged; the extraction can be repeated from the
+// data dictionary endpoint.
+func (s *SRSService) extractDataDictionary(srsID uint) {
+	if _, err := s.dataDictionary.Extract(srsID); err != nil {
+		log.Printf("data dictionary of SRS %d not extracted: %v", srsID, err)
+	}
+}
+
 func (s *SRSService) GetSRS(id uint) (*domain.SRS, error) {
 	return s.srsRepo.FindByID(id)
 }
@@ -76,26 +137,143 @@ func (s *SRSService) GetSRSByDocument(docID uint) ([]domain.SRS, error) {
 	return s.srsRepo.FindByDocumentID(docID)
 }
 
-func (s *SRSService) GetAllSRS() ([]domain.SRS, error) {
-	return s.srsRepo.FindAll()
+// ListSRS returns one page of the SRS documents matching the filter
+func (s *SRSService) ListSRS(filter domain.SRSFilter) ([]domain.SRS, domain.PageInfo, error) {
+	if err := preparePage(&filter.Page, domain.SRSSortFields); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	srsList, total, err := s.srsRepo.FindPage(filter)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	srsList, info := pageInfo(filter.Page, total, srsList, func(srs domain.SRS) (any, uint) {
+		return srsSortValue(srs, filter.Page.Sort), srs.ID
+	})
+	return srsList, info, nil
 }
 
-func (s *SRSService) UpdateSRS(id uint, content string, status string) error {
+func srsSortValue(srs domain.SRS, field string) any {
+	switch field {
+	case "updated_at":
+		return srs.UpdatedAt
+	case "title":
+		return srs.Title
+	case "status":
+		return srs.Status
+	case "author":
+		return srs.Author
+	default:
+		return srs.CreatedAt
+	}
+}
+
+// UpdateSRSInput holds the SRS fields that can be edited; empty fields are left unchanged
+type UpdateSRSInput struct {
+	Content    string
+	Status     string
+	Author     string
+	ApprovedBy string
+}
+
+func (s *SRSService) UpdateSRS(actor domain.AuditActor, id uint, input UpdateSRSInput) error {
 	srs, err := s.srsRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
+	before := auditHash(srs)
 
-	if content != "" {
-		srs.Content = content
+	// A status change is a review decision; anything else is an edit
+	action := domain.AuditActionEdit
+	if input.Status == domain.SRSStatusApproved && srs.Status != domain.SRSStatusApproved {
+		action = domain.AuditActionApprove
+	} else if input.Status != "" && input.Status != srs.Status {
+		action = domain.AuditActionChangeStatus
 	}
-	if status != "" {
-		srs.Status = status
+
+	changed := (input.Content != "" && input.Content != srs.Content) ||
+		(input.Status != "" && input.Status != srs.Status)
+
+	if input.Content != "" && input.Content != srs.Content {
+		sections, err := srsSections(input.Content)
+		if err != nil {
+			return err
+		}
+		srs.Content = input.Content
+		srs.Sections = sections
+	}
+	if input.Author != "" {
+		srs.Author = input.Author
+	}
+	if input.Status != "" {
+		srs.Status = input.Status
+
+		// Approval is recorded for the document control table in exports
+		if input.Status == domain.SRSStatusApproved {
+			now := time.Now()
+			srs.ApprovedBy = input.ApprovedBy
+			srs.ApprovedAt = &now
+		} else {
+			srs.ApprovedBy = ""
+			srs.ApprovedAt = nil
+		}
+	}
+
+	if err := s.srsRepo.Update(srs); err != nil {
+		return err
 	}
 
-	return s.srsRepo.Update(srs)
+	if err := s.audit.Record(actor, action, domain.AuditEntitySRS, srs.ID, before, auditHash(srs)); err != nil {
+		return err
+	}
+	switch action {
+	case domain.AuditActionApprove:
+		s.webhooks.Emit(srs.ProjectID, domain.WebhookSRSApproved, webhookSRS(srs))
+	case domain.AuditActionChangeStatus:
+		s.webhooks.Emit(srs.ProjectID, domain.WebhookSRSStatusChanged, webhookSRS(srs))
+	}
+	if t, ok := statusNotification(srs.Status); ok && action != domain.AuditActionEdit {
+		notification := domain.NotificationContext{
+			ProjectID: srs.ProjectID,
+			SRSID:     srs.ID,
+			Title:     srs.Title,
+			Status:    srs.Status,
+			ActorName: actor.Name,
+		}
+		if actor.Type == string(domain.AuthMethodJWT) {
+			notification.ActorID = actor.ID
+		}
+		s.notifications.Notify(t, notification)
+	}
+
+	if !changed {
+		return nil
+	}
+
+	latest, err := s.revisionRepo.LatestRevision(id)
+	if err != nil {
+		return err
+	}
+	if err := s.revisionRepo.Create(srs.Snapshot(latest + 1)); err != nil {
+		return err
+	}
+
+	_, err = s.requirements.Sync(srs)
+	return err
 }
 
-func (s *SRSService) DeleteSRS(id uint) error {
-	return s.srsRepo.Delete(id)
+func (s *SRSService) GetRevisions(id uint) ([]domain.SRSRevision, error) {
+	return s.revisionRepo.FindBySRSID(id)
+}
+
+func (s *SRSService) DeleteSRS(actor domain.AuditActor, id uint) error {
+	srs, err := s.srsRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.srsRepo.Delete(id); err != nil {
+		return err
+	}
+
+	return s.audit.Record(actor, domain.AuditActionDelete, domain.AuditEntitySRS, srs.ID, auditHash(srs), "")
 }
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/pkg/markdown"
+	"strings"
+)
+
+var (
+	priorityRe       = regexp.MustCompile(`(?i)\b(?:prioritas|priority)\s*[:=]?\s*\(?\s*(high|medium|low|tinggi|sedang|rendah|must|should|could|won't)\b`)
+	statementColumn  = regexp.MustCompile(`(?i)deskripsi|description|kebutuhan|persyaratan|requirement|pernyataan`)
+	priorityColumn   = regexp.MustCompile(`(?i)prioritas|priority`)
+	criteriaColumn   = regexp.MustCompile(`(?i)kriteria|acceptance|criteria`)
+	criteriaSplitRe  = regexp.MustCompile(`(?i)<br\s*/?>|;`)
+	nonFunctionalRe  = regexp.MustCompile(`(?i)non[- ]?fungsional|non[- ]?functional`)
+	functionalRe     = regexp.MustCompile(`(?i)fungsional|functional|fitur|feature`)
+	statementTrimSet = " \t:;.-–—)|"
+)
+
+// parseSRSStructure derives the section tree and the identified requirements
+// (paragraphs, list items, table rows or headings starting with a requirement
+// ID such as FR-001) from SRS Markdown
+func parseSRSStructure(content string) ([]domain.SRSSection, []domain.Requirement) {
+	outline := markdown.Outline(content)
+
+	seen := map[string]bool{}
+	var requirements []domain.Requirement
+
+	add := func(req domain.Requirement) {
+		if req.Code == "" || seen[req.Code] {
+			return
+		}
+		seen[req.Code] = true
+		req.Type = requirementType(req.Code, req.Section)
+		if req.Priority == "" {
+			if m := priorityRe.FindStringSubmatch(req.Statement); m != nil {
+				req.Priority = strings.ToUpper(m[1][:1]) + strings.ToLower(m[1][1:])
+			}
+		}
+		requirements = append(requirements, req)
+	}
+
+	// parent is the title of the nearest section that isn't itself a requirement
+	var walk func(sections []markdown.Section, parent string) []domain.SRSSection
+	walk = func(sections []markdown.Section, parent string) []domain.SRSSection {
+		var result []domain.SRSSection
+		for _, sec := range sections {
+			sectionTitle := sec.Title
+			if code, rest := leadingRequirementID(sec.Title); code != "" {
+				sectionTitle = parent
+				// A requirement heading is usually a short name followed by the statement
+				if statement := firstParagraph(sec.Body); statement != "" {
+					rest = statement
+				}
+				add(domain.Requirement{
+					Code:               code,
+					Statement:          rest,
+					AcceptanceCriteria: plainListItems(sec.Body),
+					Section:            sectionTitle,
+				})
+			}
+			for _, req := range requirementsInBody(sec.Body) {
+				req.Section = sectionTitle
+				add(req)
+			}
+
+			result = append(result, domain.SRSSection{
+				Title:       sec.Title,
+				Content:     sec.Body,
+				Subsections: walk(sec.Children, sectionTitle),
+			})
+		}
+		return result
+	}
+
+	return walk(outline, ""), requirements
+}
+
+// requirementsInBody finds requirements among the blocks of a section body
+func requirementsInBody(body string) []domain.Requirement {
+	var found []domain.Requirement
+
+	for _, block := range markdown.Parse(body) {
+		switch block.Kind {
+		case markdown.BlockParagraph:
+			if code, rest := leadingRequirementID(block.Text); code != "" {
+				found = append(found, domain.Requirement{Code: code, Statement: rest})
+			}
+
+		case markdown.BlockList:
+			// Items nested below a requirement are its acceptance criteria
+			current, depth := -1, 0
+			for _, item := range block.Items {
+				if code, rest := leadingRequirementID(item.Text); code != "" {
+					found = append(found, domain.Requirement{Code: code, Statement: rest})
+					current, depth = len(found)-1, item.Depth
+					continue
+				}
+				if current >= 0 && item.Depth > depth {
+					found[current].AcceptanceCriteria = append(found[current].AcceptanceCriteria, markdown.PlainText(item.Text))
+				} else {
+					current = -1
+				}
+			}
+
+		case markdown.BlockTable:
+			found = append(found, requirementsInTable(block.Header, block.Rows)...)
+		}
+	}
+
+	return found
+}
+
+// requirementsInTable reads rows whose first matching cell is a requirement ID,
+// taking the statement and priority from the matching columns when present
+func requirementsInTable(header []string, rows [][]string) []domain.Requirement {
+	statementCol, priorityCol, criteriaCol := -1, -1, -1
+	for i, h := range header {
+		switch {
+		case priorityCol < 0 && priorityColumn.MatchString(h):
+			priorityCol = i
+		case criteriaCol < 0 && criteriaColumn.MatchString(h):
+			criteriaCol = i
+		case statementCol < 0 && statementColumn.MatchString(h):
+			statementCol = i
+		}
+	}
+
+	var found []domain.Requirement
+	for _, row := range rows {
+		idCol := -1
+		var code string
+		for i, cell := range row {
+			if c, rest := leadingRequirementID(cell); c != "" && rest == "" {
+				idCol, code = i, c
+				break
+			}
+		}
+		if idCol < 0 {
+			continue
+		}
+
+		req := domain.Requirement{Code: code}
+		if statementCol >= 0 && statementCol < len(row) && statementCol != idCol {
+			req.Statement = markdown.PlainText(row[statementCol])
+		} else {
+			// Without a recognisable header, the longest other cell is the statement
+			for i, cell := range row {
+				if i != idCol && i != priorityCol && i != criteriaCol && len(cell) > len(req.Statement) {
+					req.Statement = markdown.PlainText(cell)
+				}
+			}
+		}
+		if priorityCol >= 0 && priorityCol < len(row) {
+			req.Priority = markdown.PlainText(row[priorityCol])
+		}
+		if criteriaCol >= 0 && criteriaCol < len(row) {
+			for _, criterion := range criteriaSplitRe.Split(row[criteriaCol], -1) {
+				if criterion = strings.TrimSpace(markdown.PlainText(criterion)); criterion != "" {
+					req.AcceptanceCriteria = append(req.AcceptanceCriteria, criterion)
+				}
+			}
+		}
+		found = append(found, req)
+	}
+
+	return found
+}
+
+// leadingRequirementID returns the requirement ID a text starts with and the
+// remaining text, or an empty code when it doesn't start with one
+func leadingRequirementID(text string) (string, string) {
+	plain := strings.TrimSpace(markdown.PlainText(text))
+	plain = strings.TrimLeft(plain, "[(")
+
+	loc := markdown.RequirementIDPattern.FindStringIndex(plain)
+	if loc == nil || loc[0] != 0 {
+		return "", ""
+	}
+
+	code := strings.ToUpper(strings.ReplaceAll(plain[loc[0]:loc[1]], "_", "-"))
+	rest := strings.Trim(plain[loc[1]:], statementTrimSet)
+	return code, rest
+}
+
+func firstParagraph(body string) string {
+	for _, block := range markdown.Parse(body) {
+		if block.Kind == markdown.BlockParagraph {
+			return markdown.PlainText(block.Text)
+		}
+	}
+	return ""
+}
+
+// plainListItems returns the list items of a body that are not requirements themselves
+func plainListItems(body string) []string {
+	var items []string
+	for _, block := range markdown.Parse(body) {
+		if block.Kind != markdown.BlockList {
+			continue
+		}
+		for _, item := range block.Items {
+			if code, _ := leadingRequirementID(item.Text); code == "" {
+				items = append(items, markdown.PlainText(item.Text))
+			}
+		}
+	}
+	return items
+}
+
+func requirementType(code string, section string) domain.RequirementType {
+	prefix := strings.ToUpper(code)
+	switch {
+	case strings.HasPrefix(prefix, "NFR"):
+		return domain.RequirementNonFunctional
+	case strings.HasPrefix(prefix, "FR"):
+		return domain.RequirementFunctional
+	case strings.HasPrefix(prefix, "BR"):
+		return domain.RequirementBusiness
+	case nonFunctionalRe.MatchString(section):
+		return domain.RequirementNonFunctional
+	case functionalRe.MatchString(section):
+		return domain.RequirementFunctional
+	default:
+		return domain.RequirementOther
+	}
+}
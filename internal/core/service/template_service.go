@@ -0,0 +1,128 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrUnsupportedTemplate = errors.New("template must be a .docx or .dotx file")
+	ErrTemplateNotFound    = errors.New("template not found")
+)
+
+type TemplateService struct {
+	repo           ports.DocxTemplateRepository
+	storageService ports.FileStorageService
+	validator      ports.TemplateValidator
+}
+
+func NewTemplateService(
+	repo ports.DocxTemplateRepository,
+	storageService ports.FileStorageService,
+	validator ports.TemplateValidator,
+) *TemplateService {
+	return &TemplateService{
+		repo:           repo,
+		storageService: storageService,
+		validator:      validator,
+	}
+}
+
+func (s *TemplateService) UploadTemplate(projectID uint, name string, filename string, data []byte, isDefault bool) (*domain.DocxTemplate, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext != ".docx" && ext != ".dotx" {
+		return nil, ErrUnsupportedTemplate
+	}
+	if err := s.validator.ValidateTemplate(data); err != nil {
+		return nil, err
+	}
+
+	filePath, err := s.storageService.SaveFile(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+
+	if isDefault {
+		if err := s.repo.ClearDefault(projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	tmpl := &domain.DocxTemplate{
+		ProjectID: projectID,
+		Name:      name,
+		Filename:  filename,
+		FilePath:  filePath,
+		IsDefault: isDefault,
+	}
+
+	if err := s.repo.Create(tmpl); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+func (s *TemplateService) GetTemplate(id uint) (*domain.DocxTemplate, error) {
+	return s.repo.FindByID(id)
+}
+
+func (s *TemplateService) GetTemplatesByProject(projectID uint) ([]domain.DocxTemplate, error) {
+	return s.repo.FindByProject(projectID)
+}
+
+func (s *TemplateService) SetDefault(id uint) error {
+	tmpl, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.ClearDefault(tmpl.ProjectID); err != nil {
+		return err
+	}
+
+	tmpl.IsDefault = true
+	return s.repo.Update(tmpl)
+}
+
+func (s *TemplateService) DeleteTemplate(id uint) error {
+	tmpl, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storageService.DeleteFile(tmpl.FilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+// ResolveTemplate loads the requested template of the project, falling back to
+// the project default. It returns nil when the export should use the built-in layout.
+func (s *TemplateService) ResolveTemplate(projectID uint, templateID uint) ([]byte, error) {
+	var tmpl *domain.DocxTemplate
+	var err error
+
+	if templateID > 0 {
+		tmpl, err = s.repo.FindByID(templateID)
+		if err != nil || tmpl.ProjectID != projectID {
+			return nil, ErrTemplateNotFound
+		}
+	} else {
+		tmpl, err = s.repo.FindDefault(projectID)
+	}
+	if err != nil || tmpl == nil {
+		return nil, err
+	}
+
+	return s.storageService.GetFile(tmpl.FilePath)
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrTenantNotFound    = errors.New("tenant not found")
+	ErrDuplicateTenant   = errors.New("tenant slug already exists")
+	ErrInvalidTenant     = errors.New("invalid tenant")
+	ErrAIQuotaExceeded   = errors.New("monthly AI quota of the tenant is exhausted")
+	ErrInvalidCredential = errors.New("invalid AI credential")
+)
+
+// tenantSlugPattern keeps slugs usable as directory names
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+type TenantService struct {
+	repo        ports.TenantRepository
+	credentials ports.TenantAICredentialRepository
+	usage       ports.TenantAIUsageRepository
+	projects    ports.ProjectRepository
+	auth        *AuthService
+	cipher      ports.SecretCipher
+	factory     ports.AIClientFactory
+
+	mu       sync.Mutex
+	onChange []func(tenantID uint)
+}
+
+func NewTenantService(
+	repo ports.TenantRepository,
+	credentials ports.TenantAICredentialRepository,
+	usage ports.TenantAIUsageRepository,
+	projects ports.ProjectRepository,
+	auth *AuthService,
+	cipher ports.SecretCipher,
+	factory ports.AIClientFactory,
+) *TenantService {
+	return &TenantService{
+		repo:        repo,
+		credentials: credentials,
+		usage:       usage,
+		projects:    projects,
+		auth:        auth,
+		cipher:      cipher,
+		factory:     factory,
+	}
+}
+
+// TenantInput creates a tenant together with its first admin account
+type TenantInput struct {
+	Slug           string `json:"slug"`
+	Name           string `json:"name"`
+	AIMonthlyQuota int    `json:"ai_monthly_quota"`
+	AdminEmail     string `json:"admin_email"`
+	AdminPassword  string `json:"admin_password"`
+}
+
+// TenantUpdate holds the editable tenant fields; nil fields are left unchanged
+type TenantUpdate struct {
+	Name           *string `json:"name"`
+	AIMonthlyQuota *int    `json:"ai_monthly_quota"`
+}
+
+// AICredentialInput is a tenant's key for one AI provider
+type AICredentialInput struct {
+	APIKey string `json:"api_key"`
+	Model  string `json:"model"`
+}
+
+// TenantUsage reports a tenant's AI requests against its monthly quota
+type TenantUsage struct {
+	Quota   int                    `json:"ai_monthly_quota"`
+	Current domain.TenantAIUsage   `json:"current"`
+	History []domain.TenantAIUsage `json:"history"`
+}
+
+// OnChange registers a callback run after a tenant's settings or AI
+// credentials change, so clients built from them can be rebuilt
+func (s *TenantService) OnChange(fn func(tenantID uint)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onChange = append(s.onChange, fn)
+}
+
+func (s *TenantService) changed(tenantID uint) {
+	s.mu.Lock()
+	callbacks := append([]func(uint){}, s.onChange...)
+	s.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(tenantID)
+	}
+}
+
+// CreateTenant creates a tenant with its default project and first admin
+func (s *TenantService) CreateTenant(input TenantInput) (*domain.Tenant, *domain.User, error) {
+	tenant := &domain.Tenant{
+		Slug:           strings.ToLower(strings.TrimSpace(input.Slug)),
+		Name:           strings.TrimSpace(input.Name),
+		AIMonthlyQuota: input.AIMonthlyQuota,
+	}
+	if !tenantSlugPattern.MatchString(tenant.Slug) {
+		return nil, nil, fmt.Errorf("%w: slug must be 2-63 lowercase letters, digits or dashes", ErrInvalidTenant)
+	}
+	if tenant.Name == "" {
+		return nil, nil, fmt.Errorf("%w: name is required", ErrInvalidTenant)
+	}
+	if tenant.AIMonthlyQuota < 0 {
+		return nil, nil, fmt.Errorf("%w: ai_monthly_quota must not be negative", ErrInvalidTenant)
+	}
+	if _, err := s.repo.FindBySlug(tenant.Slug); err == nil {
+		return nil, nil, ErrDuplicateTenant
+	}
+
+	admin := UserInput{Email: input.AdminEmail, Name: "Administrator", Password: input.AdminPassword, Role: domain.RoleAdmin}
+	if err := s.auth.CheckUser(admin); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.Create(tenant); err != nil {
+		return nil, nil, err
+	}
+	if err := s.projects.Create(&domain.Project{
+		TenantID: tenant.ID,
+		Name:     domain.DefaultProjectName,
+		Language: "id",
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	admin.TenantID = tenant.ID
+	user, err := s.auth.CreateUser(admin)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tenant, user, nil
+}
+
+func (s *TenantService) GetTenants() ([]domain.Tenant, error) {
+	return s.repo.FindAll()
+}
+
+func (s *TenantService) GetTenant(id uint) (*domain.Tenant, error) {
+	tenant, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrTenantNotFound
+	}
+	return tenant, nil
+}
+
+func (s *TenantService) UpdateTenant(id uint, input TenantUpdate) (*domain.Tenant, error) {
+	tenant, err := s.GetTenant(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name is required", ErrInvalidTenant)
+		}
+		tenant.Name = name
+	}
+	if input.AIMonthlyQuota != nil {
+		if *input.AIMonthlyQuota < 0 {
+			return nil, fmt.Errorf("%w: ai_monthly_quota must not be negative", ErrInvalidTenant)
+		}
+		tenant.AIMonthlyQuota = *input.AIMonthlyQuota
+	}
+
+	if err := s.repo.Update(tenant); err != nil {
+		return nil, err
+	}
+	s.changed(tenant.ID)
+	return tenant, nil
+}
+
+// IsOperator reports whether the tenant's admins may manage other tenants
+func (s *TenantService) IsOperator(tenantID uint) bool {
+	tenant, err := s.repo.FindByID(tenantID)
+	return err == nil && tenant.Operator
+}
+
+func (s *TenantService) GetCredentials(tenantID uint) ([]domain.TenantAICredential, error) {
+	if _, err := s.GetTenant(tenantID); err != nil {
+		return nil, err
+	}
+	return s.credentials.FindByTenant(tenantID)
+}
+
+// SetCredential stores the tenant's own key for an AI provider, replacing the
+// server-wide key for the tenant's projects. The key is encrypted at rest.
+func (s *TenantService) SetCredential(tenantID uint, provider string, input AICredentialInput) (*domain.TenantAICredential, error) {
+	if _, err := s.GetTenant(tenantID); err != nil {
+		return nil, err
+	}
+	if !s.isProvider(provider) {
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidCredential, provider)
+	}
+	apiKey := strings.TrimSpace(input.APIKey)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%w: api_key is required", ErrInvalidCredential)
+	}
+
+	// Building a client checks the key's format before it is stored
+	if _, err := s.factory.NewClient(provider, apiKey, input.Model); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredential, err)
+	}
+	encrypted, err := s.cipher.Encrypt(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &domain.TenantAICredential{
+		TenantID:     tenantID,
+		Provider:     provider,
+		EncryptedKey: encrypted,
+		Model:        strings.TrimSpace(input.Model),
+	}
+	if err := s.credentials.Save(credential); err != nil {
+		return nil, err
+	}
+	s.changed(tenantID)
+	return credential, nil
+}
+
+func (s *TenantService) DeleteCredential(tenantID uint, provider string) error {
+	if _, err := s.GetTenant(tenantID); err != nil {
+		return err
+	}
+	if err := s.credentials.Delete(tenantID, provider); err != nil {
+		return err
+	}
+	s.changed(tenantID)
+	return nil
+}
+
+func (s *TenantService) GetUsage(tenantID uint) (*TenantUsage, error) {
+	tenant, err := s.GetTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.usage.FindByPeriod(tenantID, usagePeriod(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	history, err := s.usage.FindByTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &TenantUsage{Quota: tenant.AIMonthlyQuota, Current: *current, History: history}, nil
+}
+
+// AIProviders returns the AI clients a tenant's projects use: the server-wide
+// clients, replaced by clients for the tenant's own credentials. Every call
+// through them counts against the tenant's monthly quota.
+func (s *TenantService) AIProviders(tenantID uint, global map[string]ports.AIService) (map[string]ports.AIService, error) {
+	credentials, err := s.credentials.FindByTenant(tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make(map[string]ports.AIService, len(global))
+	for name, client := range global {
+		clients[name] = client
+	}
+	for _, credential := range credentials {
+		apiKey, err := s.cipher.Decrypt(credential.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("AI credential %s: %w", credential.Provider, err)
+		}
+		client, err := s.factory.NewClient(credential.Provider, apiKey, credential.Model)
+		if err != nil {
+			return nil, fmt.Errorf("AI credential %s: %w", credential.Provider, err)
+		}
+		clients[credential.Provider] = client
+	}
+
+	metered := make(map[string]ports.AIService, len(clients))
+	for name, client := range clients {
+		metered[name] = &meteredAI{next: client, tenants: s, tenantID: tenantID}
+	}
+	return metered, nil
+}
+
+// reserve counts one AI request for the tenant, refusing it once the monthly
+// quota is used up
+func (s *TenantService) reserve(tenantID uint) error {
+	tenant, err := s.repo.FindByID(tenantID)
+	if err != nil {
+		return ErrTenantNotFound
+	}
+
+	period := usagePeriod(time.Now())
+	if tenant.AIMonthlyQuota > 0 {
+		usage, err := s.usage.FindByPeriod(tenantID, period)
+		if err != nil {
+			return err
+		}
+		if usage.Requests >= tenant.AIMonthlyQuota {
+			return ErrAIQuotaExceeded
+		}
+	}
+
+	_, err = s.usage.Increment(tenantID, period)
+	return err
+}
+
+func (s *TenantService) isProvider(name string) bool {
+	for _, provider := range s.factory.Providers() {
+		if provider == name {
+			return true
+		}
+	}
+	return false
+}
+
+func usagePeriod(t time.Time) string {
+	return t.Format("2006-01")
+}
+
+// meteredAI counts every call of an AI client against a tenant's quota
+type meteredAI struct {
+	next     ports.AIService
+	tenants  *TenantService
+	tenantID uint
+}
+
+func (m *meteredAI) Info() domain.AIClientInfo {
+	return m.next.Info()
+}
+
+func (m *meteredAI) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	return &meteredAI{next: m.next.WithUsage(report), tenants: m.tenants, tenantID: m.tenantID}
+}
+
+func (m *meteredAI) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.GenerateSRS(brdContent, ctx)
+}
+
+func (m *meteredAI) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.StreamSRS(brdContent, ctx, onToken)
+}
+
+func (m *meteredAI) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.GenerateUserStories(requirements, ctx)
+}
+
+func (m *meteredAI) ExtractGlossary(brdContent string) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.ExtractGlossary(brdContent)
+}
+
+func (m *meteredAI) ExtractUseCaseModel(srsContent string) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.ExtractUseCaseModel(srsContent)
+}
+
+func (m *meteredAI) ExtractDataDictionary(srsContent string) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.ExtractDataDictionary(srsContent)
+}
+
+func (m *meteredAI) DraftAPIDesign(requirements string, entities string) (string, error) {
+	if err := m.tenants.reserve(m.tenantID); err != nil {
+		return "", err
+	}
+	return m.next.DraftAPIDesign(requirements, entities)
+}
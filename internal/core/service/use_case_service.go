@@ -0,0 +1,153 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var ErrUseCaseModelNotFound = errors.New("use case model not found, extract it first")
+
+type UseCaseService struct {
+	repo      ports.UseCaseModelRepository
+	srsRepo   ports.SRSRepository
+	ai        *AIResolver
+	renderers []ports.DiagramRenderer
+}
+
+func NewUseCaseService(
+	repo ports.UseCaseModelRepository,
+	srsRepo ports.SRSRepository,
+	ai *AIResolver,
+	renderers ...ports.DiagramRenderer,
+) *UseCaseService {
+	return &UseCaseService{
+		repo:      repo,
+		srsRepo:   srsRepo,
+		ai:        ai,
+		renderers: renderers,
+	}
+}
+
+// ExtractModel asks the AI provider for the actors, use cases and entities of
+// an SRS, renders the diagrams and stores the model, replacing any previous one
+func (s *UseCaseService) ExtractModel(srsID uint) (*domain.UseCaseModel, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	response, err := s.ai.ForDocument(srs.ProjectID, srs.SourceDocumentID).ExtractUseCaseModel(srs.Content)
+	if err != nil {
+		return nil, fmt.Errorf("gagal ekstraksi model use case: %w", err)
+	}
+
+	model, err := parseUseCaseModel(response)
+	if err != nil {
+		return nil, err
+	}
+	model.SRSID = srsID
+	normalizeUseCaseModel(model)
+
+	model.Diagrams = nil
+	for _, r := range s.renderers {
+		model.Diagrams = append(model.Diagrams, r.Render(model)...)
+	}
+
+	if err := s.repo.Save(model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+func (s *UseCaseService) GetModel(srsID uint) (*domain.UseCaseModel, error) {
+	model, err := s.repo.FindBySRSID(srsID)
+	if err != nil {
+		return nil, ErrUseCaseModelNotFound
+	}
+	return model, nil
+}
+
+// GetDiagrams returns the stored diagrams of an SRS, optionally filtered by
+// notation ("mermaid", "plantuml") and kind ("usecase", "sequence", "er")
+func (s *UseCaseService) GetDiagrams(srsID uint, format string, kind string) ([]domain.Diagram, error) {
+	model, err := s.GetModel(srsID)
+	if err != nil {
+		return nil, err
+	}
+
+	diagrams := []domain.Diagram{}
+	for _, d := range model.Diagrams {
+		if format != "" && !strings.EqualFold(d.Format, format) {
+			continue
+		}
+		if kind != "" && !strings.EqualFold(string(d.Kind), kind) {
+			continue
+		}
+		diagrams = append(diagrams, d)
+	}
+	return diagrams, nil
+}
+
+// parseUseCaseModel extracts the JSON object from an AI response, tolerating
+// code fences or text around it
+func parseUseCaseModel(response string) (*domain.UseCaseModel, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return nil, ErrInvalidAIResponse
+	}
+
+	var model domain.UseCaseModel
+	if err := json.Unmarshal([]byte(response[start:end+1]), &model); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAIResponse, err)
+	}
+	if len(model.Actors) == 0 && len(model.UseCases) == 0 && len(model.Entities) == 0 {
+		return nil, ErrInvalidAIResponse
+	}
+
+	// Fields owned by the database must not come from the AI response
+	model.ID = 0
+	return &model, nil
+}
+
+// normalizeUseCaseModel trims names, numbers use cases without an ID and
+// upper-cases requirement codes so they match the extracted requirements
+func normalizeUseCaseModel(model *domain.UseCaseModel) {
+	for i := range model.Actors {
+		model.Actors[i].Name = strings.TrimSpace(model.Actors[i].Name)
+		model.Actors[i].Kind = domain.ActorKind(strings.ToLower(strings.TrimSpace(string(model.Actors[i].Kind))))
+	}
+
+	for i := range model.UseCases {
+		uc := &model.UseCases[i]
+		uc.ID = strings.ToUpper(strings.TrimSpace(uc.ID))
+		if uc.ID == "" {
+			uc.ID = fmt.Sprintf("UC-%02d", i+1)
+		}
+		uc.Name = strings.TrimSpace(uc.Name)
+		for j := range uc.Actors {
+			uc.Actors[j] = strings.TrimSpace(uc.Actors[j])
+		}
+		for j := range uc.RequirementCodes {
+			uc.RequirementCodes[j] = strings.ToUpper(strings.TrimSpace(uc.RequirementCodes[j]))
+		}
+		for j := range uc.Includes {
+			uc.Includes[j] = strings.ToUpper(strings.TrimSpace(uc.Includes[j]))
+		}
+		for j := range uc.Extends {
+			uc.Extends[j] = strings.ToUpper(strings.TrimSpace(uc.Extends[j]))
+		}
+	}
+
+	for i := range model.Entities {
+		model.Entities[i].Name = strings.TrimSpace(model.Entities[i].Name)
+		for j := range model.Entities[i].Attributes {
+			attr := &model.Entities[i].Attributes[j]
+			attr.Key = strings.ToUpper(strings.TrimSpace(attr.Key))
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+)
+
+var (
+	ErrNoRequirements    = errors.New("no matching requirements found")
+	ErrInvalidAIResponse = errors.New("AI response is not a valid user story list")
+	ErrUserStoryNotFound = errors.New("user story not found")
+	ErrNoUserStories     = errors.New("SRS has no user stories")
+)
+
+type UserStoryService struct {
+	repo         ports.UserStoryRepository
+	srsRepo      ports.SRSRepository
+	requirements *RequirementService
+	ai           *AIResolver
+	features     ports.FeatureWriter
+	projects     *ProjectService
+}
+
+func NewUserStoryService(
+	repo ports.UserStoryRepository,
+	srsRepo ports.SRSRepository,
+	requirements *RequirementService,
+	ai *AIResolver,
+	features ports.FeatureWriter,
+	projects *ProjectService,
+) *UserStoryService {
+	return &UserStoryService{
+		repo:         repo,
+		srsRepo:      srsRepo,
+		requirements: requirements,
+		ai:           ai,
+		features:     features,
+		projects:     projects,
+	}
+}
+
+// aiUserStory is the JSON shape requested from the AI provider
+type aiUserStory struct {
+	RequirementIDs []string                 `json:"requirement_ids"`
+	Title          string                   `json:"title"`
+	AsA            string                   `json:"as_a"`
+	IWant          string                   `json:"i_want"`
+	SoThat         string                   `json:"so_that"`
+	Scenarios      []domain.GherkinScenario `json:"scenarios"`
+}
+
+// GenerateStories asks the AI provider for user stories covering the given
+// requirement codes (all functional requirements when none are given).
+// Stories previously generated for those requirements are replaced.
+func (s *UserStoryService) GenerateStories(srsID uint, codes []string) ([]domain.UserStory, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	requirements, err := s.requirements.GetRequirements(srsID)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []domain.Requirement
+	for _, req := range requirements {
+		if (len(codes) > 0 && containsCode(codes, req.Code)) ||
+			(len(codes) == 0 && req.Type == domain.RequirementFunctional) {
+			selected = append(selected, req)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ErrNoRequirements
+	}
+
+	response, err := s.ai.ForDocument(srs.ProjectID, srs.SourceDocumentID).GenerateUserStories(requirementList(selected), s.projects.PromptContext(srs.ProjectID))
+	if err != nil {
+		return nil, fmt.Errorf("gagal generate user story: %w", err)
+	}
+
+	generated, err := parseUserStories(response)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedCodes := make(map[string]bool, len(selected))
+	for _, req := range selected {
+		selectedCodes[req.Code] = true
+	}
+
+	if err := s.deleteStoriesFor(srsID, selectedCodes); err != nil {
+		return nil, err
+	}
+
+	stories := make([]domain.UserStory, 0, len(generated))
+	for _, g := range generated {
+		story := domain.UserStory{
+			SRSID:     srsID,
+			Title:     strings.TrimSpace(g.Title),
+			Role:      strings.TrimSpace(g.AsA),
+			Goal:      strings.TrimSpace(g.IWant),
+			Benefit:   strings.TrimSpace(g.SoThat),
+			Scenarios: g.Scenarios,
+		}
+		for _, code := range g.RequirementIDs {
+			code = strings.ToUpper(strings.TrimSpace(code))
+			if selectedCodes[code] && !story.LinksTo(code) {
+				story.RequirementCodes = append(story.RequirementCodes, code)
+			}
+		}
+		// Stories that can't be traced to a requested requirement are dropped,
+		// unless only one requirement was requested
+		if len(story.RequirementCodes) == 0 {
+			if len(selected) != 1 {
+				continue
+			}
+			story.RequirementCodes = []string{selected[0].Code}
+		}
+		if story.Title == "" {
+			story.Title = story.Goal
+		}
+
+		if err := s.repo.Create(&story); err != nil {
+			return nil, err
+		}
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
+
+func (s *UserStoryService) GetStories(srsID uint) ([]domain.UserStory, error) {
+	return s.repo.FindBySRSID(srsID)
+}
+
+func (s *UserStoryService) GetStory(id uint) (*domain.UserStory, error) {
+	story, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrUserStoryNotFound
+	}
+	return story, nil
+}
+
+func (s *UserStoryService) DeleteStory(id uint) error {
+	if _, err := s.GetStory(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// ExportFeature renders a single story as a Gherkin .feature file
+func (s *UserStoryService) ExportFeature(id uint) (*ExportResult, error) {
+	story, err := s.GetStory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := s.features.WriteFeature(&buf, *story); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{
+		Data:        buf.Bytes(),
+		Filename:    story.FeatureFilename(),
+		ContentType: "text/plain",
+	}, nil
+}
+
+// ExportFeatures packs every story of an SRS as .feature files into a zip archive
+func (s *UserStoryService) ExportFeatures(srsID uint) (*ExportResult, error) {
+	srs, err := s.srsRepo.FindByID(srsID)
+	if err != nil {
+		return nil, ErrSRSNotFound
+	}
+
+	stories, err := s.repo.FindBySRSID(srsID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stories) == 0 {
+		return nil, ErrNoUserStories
+	}
+
+	var buf bytes.Buffer
+	if err := s.features.WriteArchive(&buf, stories); err != nil {
+		return nil, err
+	}
+
+	return &ExportResult{
+		Data:        buf.Bytes(),
+		Filename:    fmt.Sprintf("SRS-%d-v%s-features.zip", srs.ID, srs.Version),
+		ContentType: "application/zip",
+	}, nil
+}
+
+// deleteStoriesFor removes stories linked to any of the given requirement codes
+func (s *UserStoryService) deleteStoriesFor(srsID uint, codes map[string]bool) error {
+	existing, err := s.repo.FindBySRSID(srsID)
+	if err != nil {
+		return err
+	}
+
+	for _, story := range existing {
+		for _, code := range story.RequirementCodes {
+			if codes[code] {
+				if err := s.repo.Delete(story.ID); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// requirementList formats requirements as the prompt input for story generation
+func requirementList(requirements []domain.Requirement) string {
+	var b strings.Builder
+	for _, req := range requirements {
+		fmt.Fprintf(&b, "- %s", req.Code)
+		if req.Section != "" {
+			fmt.Fprintf(&b, " (%s)", req.Section)
+		}
+		fmt.Fprintf(&b, ": %s\n", req.Statement)
+		if len(req.AcceptanceCriteria) > 0 {
+			fmt.Fprintf(&b, "  Kriteria penerimaan: %s\n", strings.Join(req.AcceptanceCriteria, "; "))
+		}
+	}
+	return b.String()
+}
+
+// parseUserStories extracts the JSON array from an AI response, tolerating
+// code fences or text around it
+func parseUserStories(response string) ([]aiUserStory, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start < 0 || end < start {
+		return nil, ErrInvalidAIResponse
+	}
+
+	var stories []aiUserStory
+	if err := json.Unmarshal([]byte(response[start:end+1]), &stories); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAIResponse, err)
+	}
+	if len(stories) == 0 {
+		return nil, ErrInvalidAIResponse
+	}
+	return stories, nil
+}
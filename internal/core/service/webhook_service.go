@@ -0,0 +1,492 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+// maxWebhookDeliveries caps the delivery log returned for one webhook
+const maxWebhookDeliveries = 500
+
+type WebhookService struct {
+	repo       ports.WebhookRepository
+	deliveries ports.WebhookDeliveryRepository
+	projects   ports.ProjectRepository
+	cipher     ports.SecretCipher
+	dispatcher *WebhookDispatcher
+}
+
+func NewWebhookService(
+	repo ports.WebhookRepository,
+	deliveries ports.WebhookDeliveryRepository,
+	projects ports.ProjectRepository,
+	cipher ports.SecretCipher,
+	dispatcher *WebhookDispatcher,
+) *WebhookService {
+	return &WebhookService{
+		repo:       repo,
+		deliveries: deliveries,
+		projects:   projects,
+		cipher:     cipher,
+		dispatcher: dispatcher,
+	}
+}
+
+// WebhookInput creates a webhook. Without a secret, a random one is generated.
+type WebhookInput struct {
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Secret    string   `json:"secret"`
+	ProjectID uint     `json:"project_id"`
+}
+
+// WebhookUpdate holds the editable webhook fields; nil fields are left unchanged
+type WebhookUpdate struct {
+	URL       *string  `json:"url"`
+	Events    []string `json:"events"`
+	Secret    *string  `json:"secret"`
+	ProjectID *uint    `json:"project_id"`
+	Active    *bool    `json:"active"`
+}
+
+// CreatedWebhook carries the signing secret, which is only shown on creation
+type CreatedWebhook struct {
+	Secret  string          `json:"secret"`
+	Webhook *domain.Webhook `json:"webhook"`
+}
+
+func (s *WebhookService) GetWebhooks() ([]domain.Webhook, error) {
+	return s.repo.FindAll()
+}
+
+func (s *WebhookService) GetWebhook(id uint) (*domain.Webhook, error) {
+	webhook, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (s *WebhookService) CreateWebhook(input WebhookInput) (*CreatedWebhook, error) {
+	webhook := &domain.Webhook{Active: true}
+	if err := s.setURL(webhook, input.URL); err != nil {
+		return nil, err
+	}
+	if err := s.setEvents(webhook, input.Events); err != nil {
+		return nil, err
+	}
+	if err := s.setProject(webhook, input.ProjectID); err != nil {
+		return nil, err
+	}
+
+	secret := strings.TrimSpace(input.Secret)
+	if secret == "" {
+		raw := make([]byte, 32)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		secret = hex.EncodeToString(raw)
+	}
+	if err := s.setSecret(webhook, secret); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(webhook); err != nil {
+		return nil, err
+	}
+	return &CreatedWebhook{Secret: secret, Webhook: webhook}, nil
+}
+
+func (s *WebhookService) UpdateWebhook(id uint, input WebhookUpdate) (*domain.Webhook, error) {
+	webhook, err := s.GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := s.setURL(webhook, *input.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.Events != nil {
+		if err := s.setEvents(webhook, input.Events); err != nil {
+			return nil, err
+		}
+	}
+	if input.ProjectID != nil {
+		if err := s.setProject(webhook, *input.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if input.Secret != nil {
+		secret := strings.TrimSpace(*input.Secret)
+		if secret == "" {
+			return nil, fmt.Errorf("%w: secret must not be empty", ErrInvalidWebhook)
+		}
+		if err := s.setSecret(webhook, secret); err != nil {
+			return nil, err
+		}
+	}
+	if input.Active != nil {
+		webhook.Active = *input.Active
+	}
+
+	if err := s.repo.Update(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// DeleteWebhook removes the webhook together with its delivery log
+func (s *WebhookService) DeleteWebhook(id uint) error {
+	if _, err := s.GetWebhook(id); err != nil {
+		return err
+	}
+	return s.repo.Delete(id)
+}
+
+// GetDeliveries returns the delivery log of a webhook, newest first
+func (s *WebhookService) GetDeliveries(id uint, limit int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxWebhookDeliveries {
+		limit = maxWebhookDeliveries
+	}
+	return s.deliveries.FindByWebhook(id, limit)
+}
+
+// Ping queues a ping event for one webhook, to check the receiver and its
+// signature verification
+func (s *WebhookService) Ping(id uint) (*domain.WebhookDelivery, error) {
+	webhook, err := s.GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.queue(webhook, domain.WebhookPing, webhook.ProjectID, webhookData{"webhook_id": webhook.ID})
+	if err != nil {
+		return nil, err
+	}
+	s.dispatcher.Wake()
+	return delivery, nil
+}
+
+// Emit queues the event for every active webhook subscribed to it. Failures
+// are logged; they never fail the action that caused the event.
+func (s *WebhookService) Emit(projectID uint, event domain.WebhookEvent, data interface{}) {
+	webhooks, err := s.repo.FindActive(projectID)
+	if err != nil {
+		log.Printf("webhook %s: %v", event, err)
+		return
+	}
+
+	queued := false
+	for i := range webhooks {
+		if !webhooks[i].Subscribes(event) {
+			continue
+		}
+		if _, err := s.queue(&webhooks[i], event, projectID, data); err != nil {
+			log.Printf("webhook %d %s: %v", webhooks[i].ID, event, err)
+			continue
+		}
+		queued = true
+	}
+	if queued {
+		s.dispatcher.Wake()
+	}
+}
+
+// queue stores a pending delivery. Its payload carries the delivery ID, so
+// the row is created first and only becomes due once the payload is set.
+func (s *WebhookService) queue(webhook *domain.Webhook, event domain.WebhookEvent, projectID uint, data interface{}) (*domain.WebhookDelivery, error) {
+	delivery := &domain.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     event,
+		Status:    domain.DeliveryPending,
+	}
+	if err := s.deliveries.Create(delivery); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(domain.WebhookPayload{
+		ID:        delivery.ID,
+		Event:     event,
+		ProjectID: projectID,
+		CreatedAt: delivery.CreatedAt,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	delivery.Payload = string(payload)
+	delivery.NextAttemptAt = &now
+	if err := s.deliveries.Update(delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *WebhookService) setURL(webhook *domain.Webhook, raw string) error {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	webhook.URL = raw
+	return nil
+}
+
+func (s *WebhookService) setEvents(webhook *domain.Webhook, events []string) error {
+	if len(events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	seen := map[domain.WebhookEvent]bool{}
+	var list []string
+	for _, raw := range events {
+		event := domain.WebhookEvent(strings.TrimSpace(raw))
+		if !isWebhookEvent(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, raw)
+		}
+		if !seen[event] {
+			seen[event] = true
+			list = append(list, string(event))
+		}
+	}
+	webhook.Events = strings.Join(list, ",")
+	return nil
+}
+
+func (s *WebhookService) setProject(webhook *domain.Webhook, projectID uint) error {
+	if projectID != 0 {
+		if _, err := s.projects.FindByID(projectID); err != nil {
+			return fmt.Errorf("%w: project %d not found", ErrInvalidWebhook, projectID)
+		}
+	}
+	webhook.ProjectID = projectID
+	return nil
+}
+
+func (s *WebhookService) setSecret(webhook *domain.Webhook, secret string) error {
+	encrypted, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return err
+	}
+	webhook.EncryptedSecret = encrypted
+	return nil
+}
+
+func isWebhookEvent(event domain.WebhookEvent) bool {
+	for _, e := range domain.WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookData is the "data" object of a webhook payload
+type webhookData map[string]interface{}
+
+// webhookDocument is the payload data of document events
+func webhookDocument(doc *domain.Document) webhookData {
+	return webhookData{
+		"id":         doc.ID,
+		"filename":   doc.Filename,
+		"type":       doc.Type,
+		"status":     doc.Status,
+		"updated_at": doc.UpdatedAt,
+	}
+}
+
+// webhookSRS is the payload data of SRS events; the content is left out
+func webhookSRS(srs *domain.SRS) webhookData {
+	return webhookData{
+		"id":                 srs.ID,
+		"source_document_id": srs.SourceDocumentID,
+		"title":              srs.Title,
+		"version":            srs.Version,
+		"status":             srs.Status,
+		"author":             srs.Author,
+		"approved_by":        srs.ApprovedBy,
+		"approved_at":        srs.ApprovedAt,
+		"updated_at":         srs.UpdatedAt,
+	}
+}
+
+// WebhookDispatcher sends queued deliveries for every tenant, retrying
+// failed ones with exponential backoff
+type WebhookDispatcher struct {
+	deliveries  ports.WebhookDeliveryRepository
+	sender      ports.WebhookSender
+	cipher      ports.SecretCipher
+	maxAttempts int
+	retryBase   time.Duration
+	wake        chan struct{}
+}
+
+// NewWebhookDispatcher takes an unscoped delivery repository. A delivery is
+// given up after maxAttempts; the wait before retry n is retryBase * 2^(n-1).
+func NewWebhookDispatcher(
+	deliveries ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	cipher ports.SecretCipher,
+	maxAttempts int,
+	retryBase time.Duration,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		deliveries:  deliveries,
+		sender:      sender,
+		cipher:      cipher,
+		maxAttempts: maxAttempts,
+		retryBase:   retryBase,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// webhookClaimLease is how long a claimed delivery is hidden from other
+// workers; longer than a batch of sends takes
+const webhookClaimLease = 5 * time.Minute
+
+// Wake makes Run look for deliveries now instead of at its next tick
+func (d *WebhookDispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends due deliveries every interval, or sooner when woken, until the
+// process exits
+func (d *WebhookDispatcher) Run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		}
+		d.deliverDue()
+	}
+}
+
+func (d *WebhookDispatcher) deliverDue() {
+	for {
+		deliveries, err := d.deliveries.ClaimDue(time.Now(), webhookClaimLease, 20)
+		if err != nil {
+			log.Printf("webhook dispatch failed: %v", err)
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+		for i := range deliveries {
+			d.attempt(&deliveries[i])
+		}
+	}
+}
+
+// attempt sends a delivery once and records the outcome in the delivery log
+func (d *WebhookDispatcher) attempt(delivery *domain.WebhookDelivery) {
+	webhook := delivery.Webhook
+	now := time.Now()
+	delivery.Attempts++
+
+	status, err := d.send(delivery)
+	delivery.ResponseStatus = status
+	switch {
+	case err == nil && status >= 200 && status < 300:
+		delivery.Status = domain.DeliverySucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &now
+		delivery.NextAttemptAt = nil
+	default:
+		if err == nil {
+			err = fmt.Errorf("receiver responded with status %d", status)
+		}
+		delivery.Error = err.Error()
+		if delivery.Attempts >= d.maxAttempts || webhook == nil || !webhook.Active {
+			delivery.Status = domain.DeliveryFailed
+			delivery.NextAttemptAt = nil
+		} else {
+			next := now.Add(d.retryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &next
+		}
+	}
+
+	if err := d.deliveries.Update(delivery); err != nil {
+		log.Printf("webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// maxWebhookRetryDelay caps the backoff, which would otherwise overflow into
+// a negative delay for a large WEBHOOK_MAX_ATTEMPTS
+const maxWebhookRetryDelay = 24 * time.Hour
+
+// retryDelay is the wait after the given number of failed attempts:
+// retryBase * 2^(attempts-1), at most maxWebhookRetryDelay
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.retryBase
+	for i := 1; i < attempts && delay < maxWebhookRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxWebhookRetryDelay {
+		return maxWebhookRetryDelay
+	}
+	return delay
+}
+
+func (d *WebhookDispatcher) send(delivery *domain.WebhookDelivery) (int, error) {
+	webhook := delivery.Webhook
+	if webhook == nil {
+		return 0, ErrWebhookNotFound
+	}
+	if !webhook.Active {
+		return 0, errors.New("webhook is disabled")
+	}
+
+	secret, err := d.cipher.Decrypt(webhook.EncryptedSecret)
+	if err != nil {
+		return 0, err
+	}
+
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	return d.sender.Send(webhook.URL, body, map[string]string{
+		"Content-Type":        "application/json",
+		"User-Agent":          "srs-automation-webhooks",
+		"X-Webhook-Event":     string(delivery.Event),
+		"X-Webhook-Delivery":  strconv.FormatUint(uint64(delivery.ID), 10),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + SignWebhook(secret, timestamp, body),
+	})
+}
+
+// SignWebhook is the hex HMAC-SHA256 of "timestamp.body" keyed with the
+// webhook secret. Receivers recompute it to verify a payload, and reject old
+// timestamps to stop replays.
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/infra/external"
+)
+
+// memoryDeliveries is a webhook delivery repository kept in memory
+type memoryDeliveries struct {
+	memoryQueue[domain.WebhookDelivery]
+}
+
+func newMemoryDeliveries() *memoryDeliveries {
+	return &memoryDeliveries{memoryQueue[domain.WebhookDelivery]{
+		id:          func(d *domain.WebhookDelivery) *uint { return &d.ID },
+		nextAttempt: func(d *domain.WebhookDelivery) **time.Time { return &d.NextAttemptAt },
+	}}
+}
+
+func (m *memoryDeliveries) Create(delivery *domain.WebhookDelivery) error {
+	m.add(delivery)
+	return nil
+}
+
+func (m *memoryDeliveries) Update(delivery *domain.WebhookDelivery) error {
+	m.update(delivery)
+	return nil
+}
+
+// FindByWebhook is only used by the delivery log endpoint
+func (m *memoryDeliveries) FindByWebhook(webhookID uint, limit int) ([]domain.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *memoryDeliveries) ClaimDue(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	return m.claimDue(now, lease, limit, func(d *domain.WebhookDelivery) bool {
+		return d.Status == domain.DeliveryPending
+	}), nil
+}
+
+// plainCipher stores secrets as they are
+type plainCipher struct{}
+
+func (plainCipher) Encrypt(plain string) (string, error)     { return plain, nil }
+func (plainCipher) Decrypt(encrypted string) (string, error) { return encrypted, nil }
+
+// receivedWebhook is one request the test receiver got
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver starts a webhook receiver that answers the nth request with
+// statuses[n], repeating the last status once they run out
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, func() []receivedWebhook) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var received []receivedWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedWebhook{header: r.Header.Clone(), body: body})
+		status := statuses[min(len(received), len(statuses))-1]
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedWebhook {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedWebhook(nil), received...)
+	}
+}
+
+// queueDelivery stores a pending delivery to the receiver
+func queueDelivery(t *testing.T, repo *memoryDeliveries, url string) uint {
+	t.Helper()
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID: 1,
+		Event:     domain.WebhookSRSGenerated,
+		Payload:   `{"event":"srs.generated","data":{"id":7}}`,
+		Status:    domain.DeliveryPending,
+		Webhook:   &domain.Webhook{ID: 1, URL: url, EncryptedSecret: "s3cret", Active: true},
+	}
+	if err := repo.Create(delivery); err != nil {
+		t.Fatalf("queue delivery: %v", err)
+	}
+	return delivery.ID
+}
+
+// deliverUntilDone runs the dispatcher, waiting out each retry, until the
+// delivery is no longer pending
+func deliverUntilDone(t *testing.T, d *WebhookDispatcher, repo *memoryDeliveries, id uint) domain.WebhookDelivery {
+	t.Helper()
+
+	for i := 0; i <= d.maxAttempts; i++ {
+		d.deliverDue()
+		delivery := repo.get(id)
+		if delivery.Status != domain.DeliveryPending {
+			return delivery
+		}
+		time.Sleep(time.Until(*delivery.NextAttemptAt))
+	}
+	t.Fatalf("delivery still pending after %d rounds", d.maxAttempts+1)
+	return domain.WebhookDelivery{}
+}
+
+func TestWebhookSignatureVerifiesWithSecret(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+	repo := newMemoryDeliveries()
+	id := queueDelivery(t, repo, server.URL)
+
+	d := NewWebhookDispatcher(repo, external.NewWebhookSender(), plainCipher{}, 3, time.Millisecond)
+	delivery := deliverUntilDone(t, d, repo, id)
+	if delivery.Status != domain.DeliverySucceeded || delivery.Attempts != 1 {
+		t.Fatalf("delivery = %s after %d attempts, want succeeded after 1", delivery.Status, delivery.Attempts)
+	}
+
+	requests := received()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+	if string(req.body) != delivery.Payload {
+		t.Errorf("body = %s, want %s", req.body, delivery.Payload)
+	}
+
+	// Recompute the signature the way a receiver does
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(req.header.Get("X-Webhook-Timestamp") + "."))
+	mac.Write(req.body)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got := req.header.Get("X-Webhook-Signature"); got != want {
+		t.Errorf("X-Webhook-Signature = %s, want %s", got, want)
+	}
+	if got := req.header.Get("X-Webhook-Event"); got != string(domain.WebhookSRSGenerated) {
+		t.Errorf("X-Webhook-Event = %s, want %s", got, domain.WebhookSRSGenerated)
+	}
+}
+
+func TestWebhookRetriesNon2xxWithBackoff(t *testing.T) {
+	server, received := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+	repo := newMemoryDeliveries()
+	id := queueDelivery(t, repo, server.URL)
+
+	base := 20 * time.Millisecond
+	d := NewWebhookDispatcher(repo, external.NewWebhookSender(), plainCipher{}, 5, base)
+
+	for attempt, status := range []int{http.StatusInternalServerError, http.StatusBadGateway} {
+		sent := time.Now()
+		d.deliverDue()
+
+		delivery := repo.get(id)
+		if delivery.Status != domain.DeliveryPending || delivery.ResponseStatus != status {
+			t.Fatalf("after attempt %d: %s with status %d, want pending with %d", attempt+1, delivery.Status, delivery.ResponseStatus, status)
+		}
+		wait := delivery.NextAttemptAt.Sub(sent)
+		if want := base << attempt; wait < want || wait > want+time.Second {
+			t.Errorf("retry %d scheduled after %v, want %v", attempt+1, wait, want)
+		}
+
+		// Not retried before the backoff is over
+		d.deliverDue()
+		if n := len(received()); n != attempt+1 {
+			t.Fatalf("receiver got %d requests before the retry was due, want %d", n, attempt+1)
+		}
+		time.Sleep(time.Until(*delivery.NextAttemptAt))
+	}
+
+	delivery := deliverUntilDone(t, d, repo, id)
+	if delivery.Status != domain.DeliverySucceeded || delivery.Attempts != 3 || delivery.DeliveredAt == nil {
+		t.Errorf("delivery = %s after %d attempts, want succeeded after 3", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestWebhookDeadAfterMaxAttempts(t *testing.T) {
+	server, received := newReceiver(t, http.StatusServiceUnavailable)
+	repo := newMemoryDeliveries()
+	id := queueDelivery(t, repo, server.URL)
+
+	d := NewWebhookDispatcher(repo, external.NewWebhookSender(), plainCipher{}, 3, time.Millisecond)
+	delivery := deliverUntilDone(t, d, repo, id)
+
+	if delivery.Status != domain.DeliveryFailed || delivery.Attempts != 3 {
+		t.Errorf("delivery = %s after %d attempts, want failed after 3", delivery.Status, delivery.Attempts)
+	}
+	if delivery.NextAttemptAt != nil {
+		t.Errorf("failed delivery still scheduled at %v", delivery.NextAttemptAt)
+	}
+	if delivery.ResponseStatus != http.StatusServiceUnavailable || delivery.Error == "" {
+		t.Errorf("failed delivery records status %d, error %q", delivery.ResponseStatus, delivery.Error)
+	}
+
+	d.deliverDue()
+	if n := len(received()); n != 3 {
+		t.Errorf("receiver got %d requests, want 3", n)
+	}
+}
+
+func TestWebhookDeliversDueInBatches(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+	repo := newMemoryDeliveries()
+	for i := 0; i < 45; i++ {
+		queueDelivery(t, repo, server.URL)
+	}
+
+	NewWebhookDispatcher(repo, external.NewWebhookSender(), plainCipher{}, 3, time.Millisecond).deliverDue()
+
+	if batches := repo.claimedBatches(); !slices.Equal(batches, []int{20, 20, 5}) {
+		t.Errorf("claimed batches %v, want [20 20 5]", batches)
+	}
+	if n := len(received()); n != 45 {
+		t.Errorf("receiver got %d requests, want 45", n)
+	}
+	for id := uint(1); id <= 45; id++ {
+		if delivery := repo.get(id); delivery.Status != domain.DeliverySucceeded {
+			t.Errorf("delivery %d = %s, want succeeded", id, delivery.Status)
+		}
+	}
+}
+
+func TestWebhookRetryDelayIsCapped(t *testing.T) {
+	d := NewWebhookDispatcher(nil, nil, nil, 1000, time.Minute)
+
+	if got := d.retryDelay(3); got != 4*time.Minute {
+		t.Errorf("retryDelay(3) = %v, want 4m", got)
+	}
+	for _, attempts := range []int{20, 64, 100, 1000} {
+		if got := d.retryDelay(attempts); got != maxWebhookRetryDelay {
+			t.Errorf("retryDelay(%d) = %v, want %v", attempts, got, maxWebhookRetryDelay)
+		}
+	}
+}
@@ -24,12 +24,148 @@ func NewPostgresDB() (*gorm.DB, error) {
 		return nil, err
 	}
 
+	if err := RegisterTenantScope(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
+// tenantModels are the tables whose rows belong to a tenant
+var tenantModels = []interface{}{
+	&domain.Project{},
+	&domain.Document{},
+	&domain.SRS{},
+	&domain.FileAccessLog{},
+	&domain.DocxTemplate{},
+	&domain.SRSRevision{},
+	&domain.Requirement{},
+	&domain.UserStory{},
+	&domain.UseCaseModel{},
+	&domain.APISpec{},
+	&domain.DataEntity{},
+	&domain.GlossaryTerm{},
+	&domain.User{},
+	&domain.APIKey{},
+	&domain.AuditEvent{},
+	&domain.Webhook{},
+	&domain.WebhookDelivery{},
+	&domain.Notification{},
+	&domain.NotificationPreference{},
+	&domain.AICacheEntry{},
+	&domain.AIUsageRecord{},
+	&domain.RedactionReport{},
+}
+
 func RunMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&domain.Document{},
-		&domain.SRS{},
-	)
+	models := append([]interface{}{
+		&domain.Tenant{},
+		&domain.TenantAICredential{},
+		&domain.TenantAIUsage{},
+	}, tenantModels...)
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+
+	// Project names used to be unique across the server; they are now unique per tenant
+	if db.Migrator().HasIndex(&domain.Project{}, "idx_projects_name") {
+		if err := db.Migrator().DropIndex(&domain.Project{}, "idx_projects_name"); err != nil {
+			return err
+		}
+	}
+
+	if err := migrateAuditAppendOnly(db); err != nil {
+		return err
+	}
+
+	tenant, err := migrateDefaultTenant(db)
+	if err != nil {
+		return err
+	}
+
+	return migrateDefaultProject(db, tenant.ID)
+}
+
+// migrateDefaultTenant assigns rows created before tenants existed (tenant_id
+// 0) to the default tenant, creating it on first run. The default tenant is
+// the operator tenant whose admins manage the other tenants.
+func migrateDefaultTenant(db *gorm.DB) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(domain.Tenant{Slug: domain.DefaultTenantSlug}).
+			Attrs(domain.Tenant{Name: "Default", Operator: true}).
+			FirstOrCreate(&tenant).Error; err != nil {
+			return err
+		}
+
+		for _, model := range tenantModels {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+			if err := tx.Exec(
+				fmt.Sprintf("UPDATE %s SET tenant_id = ? WHERE tenant_id = 0 OR tenant_id IS NULL", stmt.Schema.Table),
+				tenant.ID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return &tenant, err
+}
+
+// migrateDefaultProject moves rows of the default tenant that don't belong to
+// an existing project (all rows created before projects existed have
+// project_id 0) into its default project, creating it on first run
+func migrateDefaultProject(db *gorm.DB, tenantID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var project domain.Project
+		if err := tx.Where(domain.Project{TenantID: tenantID, Name: domain.DefaultProjectName}).
+			Attrs(domain.Project{Description: "Project bawaan untuk data yang dibuat sebelum fitur project", Language: "id"}).
+			FirstOrCreate(&project).Error; err != nil {
+			return err
+		}
+
+		for _, table := range []string{"documents", "srs", "docx_templates"} {
+			if err := tx.Exec(
+				fmt.Sprintf("UPDATE %s SET project_id = ? WHERE tenant_id = ? AND (project_id IS NULL OR project_id NOT IN (SELECT id FROM projects))", table),
+				project.ID, tenantID,
+			).Error; err != nil {
+				return err
+			}
+		}
+
+		// Terms already defined in the default project keep the existing entry
+		return tx.Exec(`UPDATE glossary_terms SET project_id = ?
+			WHERE tenant_id = ? AND (project_id IS NULL OR project_id NOT IN (SELECT id FROM projects))
+			AND NOT EXISTS (SELECT 1 FROM glossary_terms g WHERE g.project_id = ? AND g.term = glossary_terms.term)`,
+			project.ID, tenantID, project.ID,
+		).Error
+	})
+}
+
+// migrateAuditAppendOnly makes the database refuse changes to recorded audit
+// events. Moving events of tenant 0 to the default tenant stays allowed.
+func migrateAuditAppendOnly(db *gorm.DB) error {
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			IF TG_OP = 'UPDATE' AND OLD.tenant_id = 0 AND NEW.tenant_id <> 0
+				AND (to_jsonb(NEW) - 'tenant_id') = (to_jsonb(OLD) - 'tenant_id') THEN
+				RETURN NEW;
+			END IF;
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql`,
+		`DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events`,
+		`CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+			FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+type tenantContextKey struct{}
+
+// WithTenant limits the database operations run with the returned context to one tenant
+func WithTenant(ctx context.Context, tenantID uint) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant set by WithTenant
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	tenantID, ok := ctx.Value(tenantContextKey{}).(uint)
+	return tenantID, ok
+}
+
+// TenantDB returns a session that only reads and writes the rows of one
+// tenant. Repositories built on it can't see other tenants' rows, even by ID.
+func TenantDB(db *gorm.DB, tenantID uint) *gorm.DB {
+	return db.WithContext(WithTenant(context.Background(), tenantID))
+}
+
+// RegisterTenantScope installs the callbacks that enforce TenantDB sessions:
+// every query, update and delete on a model with a TenantID field is filtered
+// by the session's tenant, and inserts are assigned to it. Sessions without a
+// tenant (migrations, background jobs, authentication) are not filtered.
+func RegisterTenantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tenant:assign", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	// Saving a struct writes every column; keep it in the session's tenant
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:assign", assignTenant); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenant:scope", scopeTenant); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("tenant:scope", scopeTenant)
+}
+
+// tenantField returns the session's tenant and the model's tenant column, if both exist
+func tenantField(db *gorm.DB) (uint, *schema.Field, bool) {
+	tenantID, ok := TenantFromContext(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return 0, nil, false
+	}
+	field := db.Statement.Schema.LookUpField("TenantID")
+	return tenantID, field, field != nil
+}
+
+func scopeTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantField(db)
+	if !ok {
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+func assignTenant(db *gorm.DB) {
+	tenantID, field, ok := tenantField(db)
+	if !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+	value := db.Statement.ReflectValue
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(value.Index(i)), tenantID); err != nil {
+				db.AddError(err)
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, value, tenantID); err != nil {
+			db.AddError(err)
+		}
+	}
+
+	// An upsert must not update a conflicting row of another tenant
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs, clause.Eq{
+				Column: clause.Column{Table: db.Statement.Table, Name: field.DBName},
+				Value:  tenantID,
+			})
+			db.Statement.AddClause(onConflict)
+		}
+	}
+}
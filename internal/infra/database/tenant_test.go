@@ -0,0 +1,185 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/infra/database"
+	"srs-automation/internal/infra/repository"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// newTenantTestDB opens an in-memory database with the tenant scope
+// installed and the document and project tables migrated
+func newTenantTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("database handle: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := database.RegisterTenantScope(db); err != nil {
+		t.Fatalf("register tenant scope: %v", err)
+	}
+	if err := db.AutoMigrate(&domain.Document{}, &domain.Project{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// createDocument stores a document of a tenant and returns it
+func createDocument(t *testing.T, db *gorm.DB, tenantID uint, filename string) *domain.Document {
+	t.Helper()
+
+	doc := &domain.Document{Filename: filename, Type: domain.DocumentTypeBRD, FilePath: "/tmp/" + filename}
+	if err := repository.NewDocumentRepository(database.TenantDB(db, tenantID)).Create(doc); err != nil {
+		t.Fatalf("create document: %v", err)
+	}
+	return doc
+}
+
+func TestTenantDBCreateStampsCallerTenant(t *testing.T) {
+	db := newTenantTestDB(t)
+
+	// A tenant ID set by the caller is overwritten with the session's tenant
+	doc := &domain.Document{TenantID: 2, Filename: "brd.pdf", Type: domain.DocumentTypeBRD, FilePath: "/tmp/brd.pdf"}
+	if err := repository.NewDocumentRepository(database.TenantDB(db, 1)).Create(doc); err != nil {
+		t.Fatalf("create document: %v", err)
+	}
+
+	var stored domain.Document
+	if err := db.First(&stored, doc.ID).Error; err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if stored.TenantID != 1 {
+		t.Errorf("tenant_id = %d, want 1", stored.TenantID)
+	}
+}
+
+func TestTenantDBFindByIDOfOtherTenant(t *testing.T) {
+	db := newTenantTestDB(t)
+	doc := createDocument(t, db, 1, "brd.pdf")
+
+	other := repository.NewDocumentRepository(database.TenantDB(db, 2))
+	if _, err := other.FindByID(doc.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("FindByID from another tenant: err = %v, want record not found", err)
+	}
+
+	own := repository.NewDocumentRepository(database.TenantDB(db, 1))
+	if _, err := own.FindByID(doc.ID); err != nil {
+		t.Errorf("FindByID from the owning tenant: %v", err)
+	}
+}
+
+func TestTenantDBFindAllOnlyOwnRows(t *testing.T) {
+	db := newTenantTestDB(t)
+	createDocument(t, db, 1, "a.pdf")
+	createDocument(t, db, 1, "b.pdf")
+	createDocument(t, db, 2, "c.pdf")
+
+	docs, err := repository.NewDocumentRepository(database.TenantDB(db, 2)).FindAll()
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if len(docs) != 1 || docs[0].Filename != "c.pdf" {
+		t.Errorf("FindAll = %v, want only c.pdf", docs)
+	}
+
+	// A session without a tenant, as used by migrations and background jobs, sees everything
+	var count int64
+	if err := db.Model(&domain.Document{}).Count(&count).Error; err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("unscoped count = %d, want 3", count)
+	}
+}
+
+func TestTenantDBUpdateOfOtherTenant(t *testing.T) {
+	db := newTenantTestDB(t)
+	doc := createDocument(t, db, 1, "brd.pdf")
+
+	// Save, as used by the repositories, with a row loaded by the owner
+	stolen := *doc
+	stolen.Filename = "renamed.pdf"
+	if err := repository.NewDocumentRepository(database.TenantDB(db, 2)).Update(&stolen); err != nil {
+		t.Fatalf("Update from another tenant: %v", err)
+	}
+
+	result := database.TenantDB(db, 2).Model(&domain.Document{}).Where("id = ?", doc.ID).Update("filename", "renamed.pdf")
+	if result.Error != nil {
+		t.Fatalf("column update from another tenant: %v", result.Error)
+	}
+	if result.RowsAffected != 0 {
+		t.Errorf("column update from another tenant affected %d rows, want 0", result.RowsAffected)
+	}
+
+	var stored domain.Document
+	if err := db.First(&stored, doc.ID).Error; err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if stored.Filename != "brd.pdf" || stored.TenantID != 1 {
+		t.Errorf("row after updates from another tenant = %q of tenant %d, want brd.pdf of tenant 1", stored.Filename, stored.TenantID)
+	}
+}
+
+func TestTenantDBDeleteOfOtherTenant(t *testing.T) {
+	db := newTenantTestDB(t)
+	doc := createDocument(t, db, 1, "brd.pdf")
+
+	result := database.TenantDB(db, 2).Delete(&domain.Document{}, doc.ID)
+	if result.Error != nil {
+		t.Fatalf("delete from another tenant: %v", result.Error)
+	}
+	if result.RowsAffected != 0 {
+		t.Errorf("delete from another tenant affected %d rows, want 0", result.RowsAffected)
+	}
+	if err := repository.NewDocumentRepository(database.TenantDB(db, 2)).Delete(doc.ID); err != nil {
+		t.Fatalf("repository delete from another tenant: %v", err)
+	}
+	if _, err := repository.NewDocumentRepository(database.TenantDB(db, 1)).FindByID(doc.ID); err != nil {
+		t.Errorf("document gone after deletes from another tenant: %v", err)
+	}
+
+	result = database.TenantDB(db, 1).Delete(&domain.Document{}, doc.ID)
+	if result.Error != nil || result.RowsAffected != 1 {
+		t.Errorf("delete by the owner: rows = %d, err = %v, want 1 row", result.RowsAffected, result.Error)
+	}
+}
+
+func TestTenantDBUpsertKeepsOtherTenantRow(t *testing.T) {
+	db := newTenantTestDB(t)
+
+	project := &domain.Project{Name: "Core Banking"}
+	if err := database.TenantDB(db, 1).Create(project).Error; err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	// An upsert from another tenant that conflicts on the primary key must not take the row over
+	takeover := &domain.Project{ID: project.ID, Name: "Taken"}
+	err := database.TenantDB(db, 2).Clauses(clause.OnConflict{UpdateAll: true}).Create(takeover).Error
+	if err != nil {
+		t.Fatalf("upsert from another tenant: %v", err)
+	}
+
+	var stored domain.Project
+	if err := db.First(&stored, project.ID).Error; err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if stored.Name != "Core Banking" || stored.TenantID != 1 {
+		t.Errorf("project after upsert from another tenant = %q of tenant %d, want Core Banking of tenant 1", stored.Name, stored.TenantID)
+	}
+}
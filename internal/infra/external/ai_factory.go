@@ -0,0 +1,42 @@
+package external
+
+import (
+	"fmt"
+	"srs-automation/internal/core/ports"
+)
+
+// AIFactory creates AI clients from tenant credentials
+type AIFactory struct {
+	googleCredsFile string
+}
+
+func NewAIFactory(googleCredsFile string) *AIFactory {
+	return &AIFactory{googleCredsFile: googleCredsFile}
+}
+
+func (f *AIFactory) Providers() []string {
+	return []string{"gemini", "groq"}
+}
+
+// NewClient creates a client for the provider; an empty model keeps the provider default
+func (f *AIFactory) NewClient(provider string, apiKey string, model string) (ports.AIService, error) {
+	switch provider {
+	case "groq":
+		client := NewGroqClient(apiKey)
+		if model != "" {
+			client.model = model
+		}
+		return client, nil
+	case "gemini":
+		client, err := NewGeminiClient(apiKey, f.googleCredsFile)
+		if err != nil {
+			return nil, err
+		}
+		if model != "" {
+			client.model = model
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q", provider)
+	}
+}
@@ -1,14 +1,23 @@
 package external
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"srs-automation/internal/core/domain"
+	"strings"
 	"time"
 )
 
+var ErrOutsideStorage = errors.New("file is outside this storage")
+
 type FileStorage struct {
 	uploadPath string
+	outputPath string
+	// Directories whose files, but not subdirectories, the storage may also
+	// read and delete: the default tenant's files from before tenants existed
+	legacyPaths []string
 }
 
 func NewFileStorage() *FileStorage {
@@ -17,16 +26,37 @@ func NewFileStorage() *FileStorage {
 		uploadPath = "./uploads"
 	}
 
-	// Create upload directory if not exists
+	outputPath := os.Getenv("OUTPUT_PATH")
+	if outputPath == "" {
+		outputPath = "./outputs"
+	}
+
+	// Create upload and output directories if not exists
 	os.MkdirAll(uploadPath, 0755)
+	os.MkdirAll(outputPath, 0755)
 
-	return &FileStorage{uploadPath: uploadPath}
+	return &FileStorage{uploadPath: uploadPath, outputPath: outputPath}
+}
+
+// ForTenant returns a storage that writes under a per-tenant subdirectory of
+// the upload and output directories
+func (fs *FileStorage) ForTenant(slug string) *FileStorage {
+	tenant := &FileStorage{
+		uploadPath: filepath.Join(fs.uploadPath, slug),
+		outputPath: filepath.Join(fs.outputPath, slug),
+	}
+	if slug == domain.DefaultTenantSlug {
+		tenant.legacyPaths = []string{fs.uploadPath, fs.outputPath}
+	}
+	os.MkdirAll(tenant.uploadPath, 0755)
+	os.MkdirAll(tenant.outputPath, 0755)
+	return tenant
 }
 
 func (fs *FileStorage) SaveFile(filename string, data []byte) (string, error) {
 	// Generate unique filename
 	timestamp := time.Now().Unix()
-	uniqueFilename := fmt.Sprintf("%d_%s", timestamp, filename)
+	uniqueFilename := fmt.Sprintf("%d_%s", timestamp, filepath.Base(filename))
 	filePath := filepath.Join(fs.uploadPath, uniqueFilename)
 
 	// Write file
@@ -37,10 +67,70 @@ func (fs *FileStorage) SaveFile(filename string, data []byte) (string, error) {
 	return filePath, nil
 }
 
-func (fs *FileStorage) GetFile(filepath string) ([]byte, error) {
-	return os.ReadFile(filepath)
+// SaveOutput writes a generated file to the output directory, replacing any previous version
+func (fs *FileStorage) SaveOutput(filename string, data []byte) (string, error) {
+	filePath := filepath.Join(fs.outputPath, filepath.Base(filename))
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+func (fs *FileStorage) GetFile(path string) ([]byte, error) {
+	if !fs.owns(path) {
+		return nil, ErrOutsideStorage
+	}
+	return os.ReadFile(path)
 }
 
-func (fs *FileStorage) DeleteFile(filepath string) error {
-	return os.Remove(filepath)
+func (fs *FileStorage) DeleteFile(path string) error {
+	if !fs.owns(path) {
+		return ErrOutsideStorage
+	}
+	return os.Remove(path)
+}
+
+// owns reports whether path is inside the upload or output directory, or
+// directly inside a legacy directory
+func (fs *FileStorage) owns(path string) bool {
+	for _, dir := range []string{fs.uploadPath, fs.outputPath} {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	for _, dir := range fs.legacyPaths {
+		if filepath.Clean(filepath.Dir(path)) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListFiles returns every file in the upload and output directories
+func (fs *FileStorage) ListFiles() ([]domain.StoredFile, error) {
+	var files []domain.StoredFile
+
+	for _, root := range []string{fs.uploadPath, fs.outputPath} {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			files = append(files, domain.StoredFile{
+				Path:    path,
+				Size:    info.Size(),
+				ModTime: info.ModTime(),
+			})
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	return files, nil
 }
@@ -0,0 +1,137 @@
+package external
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"srs-automation/internal/core/domain"
+)
+
+func newTestStorage(t *testing.T) *FileStorage {
+	t.Helper()
+
+	root := t.TempDir()
+	fs := &FileStorage{uploadPath: filepath.Join(root, "uploads"), outputPath: filepath.Join(root, "outputs")}
+	for _, dir := range []string{fs.uploadPath, fs.outputPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("create %s: %v", dir, err)
+		}
+	}
+	return fs
+}
+
+func TestForTenantWritesUnderTenantPrefix(t *testing.T) {
+	root := newTestStorage(t)
+	acme := root.ForTenant("acme")
+
+	upload, err := acme.SaveFile("brd.pdf", []byte("brd"))
+	if err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+	if filepath.Dir(upload) != filepath.Join(root.uploadPath, "acme") {
+		t.Errorf("upload saved at %s, want inside %s", upload, filepath.Join(root.uploadPath, "acme"))
+	}
+
+	output, err := acme.SaveOutput("SRS Draft.docx", []byte("srs"))
+	if err != nil {
+		t.Fatalf("SaveOutput: %v", err)
+	}
+	if filepath.Dir(output) != filepath.Join(root.outputPath, "acme") {
+		t.Errorf("output saved at %s, want inside %s", output, filepath.Join(root.outputPath, "acme"))
+	}
+}
+
+func TestForTenantFilenameCannotEscapePrefix(t *testing.T) {
+	root := newTestStorage(t)
+	acme := root.ForTenant("acme")
+
+	for _, name := range []string{"../globex/brd.pdf", "x/../../../globex/brd.pdf", "/etc/brd.pdf"} {
+		upload, err := acme.SaveFile(name, []byte("brd"))
+		if err != nil {
+			t.Fatalf("SaveFile(%q): %v", name, err)
+		}
+		if filepath.Dir(upload) != filepath.Join(root.uploadPath, "acme") {
+			t.Errorf("SaveFile(%q) wrote %s, outside the tenant directory", name, upload)
+		}
+
+		output, err := acme.SaveOutput(name, []byte("srs"))
+		if err != nil {
+			t.Fatalf("SaveOutput(%q): %v", name, err)
+		}
+		if filepath.Dir(output) != filepath.Join(root.outputPath, "acme") {
+			t.Errorf("SaveOutput(%q) wrote %s, outside the tenant directory", name, output)
+		}
+	}
+}
+
+func TestForTenantCannotReachOtherTenantFiles(t *testing.T) {
+	root := newTestStorage(t)
+	acme := root.ForTenant("acme")
+	globex := root.ForTenant("globex")
+
+	path, err := globex.SaveFile("brd.pdf", []byte("globex brd"))
+	if err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+
+	if _, err := acme.GetFile(path); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("GetFile of another tenant's file: err = %v, want ErrOutsideStorage", err)
+	}
+	traversal := filepath.Join(root.uploadPath, "acme", "..", "globex", filepath.Base(path))
+	if _, err := acme.GetFile(traversal); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("GetFile through ..: err = %v, want ErrOutsideStorage", err)
+	}
+	if err := acme.DeleteFile(path); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("DeleteFile of another tenant's file: err = %v, want ErrOutsideStorage", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("other tenant's file after DeleteFile: %v", err)
+	}
+
+	files, err := acme.ListFiles()
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	for _, f := range files {
+		if strings.Contains(f.Path, "globex") {
+			t.Errorf("ListFiles of acme includes %s", f.Path)
+		}
+	}
+
+	data, err := globex.GetFile(path)
+	if err != nil || string(data) != "globex brd" {
+		t.Errorf("GetFile by the owner = %q, %v", data, err)
+	}
+	if err := globex.DeleteFile(path); err != nil {
+		t.Errorf("DeleteFile by the owner: %v", err)
+	}
+}
+
+func TestDefaultTenantReachesLegacyFiles(t *testing.T) {
+	root := newTestStorage(t)
+
+	// Saved before tenants existed, directly in the upload directory
+	legacy, err := root.SaveFile("old.pdf", []byte("old brd"))
+	if err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+
+	if _, err := root.ForTenant(domain.DefaultTenantSlug).GetFile(legacy); err != nil {
+		t.Errorf("default tenant reading a legacy file: %v", err)
+	}
+	if _, err := root.ForTenant("acme").GetFile(legacy); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("other tenant reading a legacy file: err = %v, want ErrOutsideStorage", err)
+	}
+
+	// The legacy exception doesn't extend into the other tenants' directories
+	path, err := root.ForTenant("acme").SaveFile("brd.pdf", []byte("acme brd"))
+	if err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+	if _, err := root.ForTenant(domain.DefaultTenantSlug).GetFile(path); !errors.Is(err, ErrOutsideStorage) {
+		t.Errorf("default tenant reading acme's file: err = %v, want ErrOutsideStorage", err)
+	}
+}
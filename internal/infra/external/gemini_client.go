@@ -1,6 +1,7 @@
 package external
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,9 +10,10 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
 	"strings"
 
-	"github.com/gingfrederik/docx"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
@@ -22,6 +24,8 @@ type GeminiClient struct {
 	model        string
 	docsService  *docs.Service
 	driveService *drive.Service
+	// Receives the token usage of each response; may be nil
+	report func(usage domain.AITokenUsage)
 }
 
 func NewGeminiClient(apiKey string, googleCredsFile string) (*GeminiClient, error) {
@@ -58,13 +62,24 @@ func NewGeminiClient(apiKey string, googleCredsFile string) (*GeminiClient, erro
 }
 
 type geminiRequest struct {
-	Contents []geminiContent `json:"contents"`
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent `json:"contents"`
 }
 
 type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
 	Parts []geminiPart `json:"parts"`
 }
 
+// newGeminiRequest sends the instructions as the system instruction and the
+// document text as the user turn
+func newGeminiRequest(prompt chatPrompt) geminiRequest {
+	return geminiRequest{
+		SystemInstruction: &geminiContent{Parts: []geminiPart{{Text: prompt.system}}},
+		Contents:          []geminiContent{{Role: "user", Parts: []geminiPart{{Text: prompt.user}}}},
+	}
+}
+
 type geminiPart struct {
 	Text string `json:"text"`
 }
@@ -75,6 +90,10 @@ type geminiResponse struct {
 			Parts []geminiPart `json:"parts"`
 		} `json:"content"`
 	} `json:"candidates"`
+	UsageMetadata *struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
 }
 
 func (c *GeminiClient) ExtractContent(filePath string, fileType string) (string, error) {
@@ -83,10 +102,9 @@ func (c *GeminiClient) ExtractContent(filePath string, fileType string) (string,
 		return "", err
 	}
 
-	prompt := fmt.Sprintf(
-		"Extract and summarize the key information from this %s document:\n\n%s",
-		fileType,
-		string(content),
+	prompt := newPrompt(
+		fmt.Sprintf("Extract and summarize the key information from this %s document.", fileType),
+		promptData{label: "Document", content: string(content)},
 	)
 
 	return c.callGemini(prompt)
@@ -154,44 +172,6 @@ func (c *GeminiClient) ExtractContent(filePath string, fileType string) (string,
 // 	return fmt.Sprintf("https://docs.google.com/document/d/%s/edit", createdDoc.DocumentId), nil
 // }
 
-func (c *GeminiClient) GenerateDocxFile(content string, filename string) (string, error) {
-	// 1. Inisialisasi File Docx Baru
-	f := docx.NewFile()
-
-	// 2. Format Teks Gemini ke Paragraf Docx
-	// Karena Gemini outputnya Markdown, kita pecah per baris agar rapi
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		// Skip baris kosong berlebihan
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		// Tambahkan paragraf baru
-		para := f.AddParagraph()
-
-		// Sedikit trik: Membersihkan format Markdown sederhana (opsional)
-		// Misal menghapus tanda ** atau # agar lebih bersih di Word
-		cleanLine := strings.ReplaceAll(line, "**", "")
-		cleanLine = strings.ReplaceAll(cleanLine, "##", "")
-
-		para.AddText(cleanLine)
-	}
-
-	// 3. Tentukan Lokasi Simpan
-	// Pastikan folder "outputs" sudah dibuat manual di root project
-	outputPath := fmt.Sprintf("./outputs/%s.docx", filename)
-
-	// 4. Simpan File
-	err := f.Save(outputPath)
-	if err != nil {
-		return "", fmt.Errorf("gagal menyimpan file docx: %w", err)
-	}
-
-	return outputPath, nil
-}
-
 func (c *GeminiClient) CreateGoogleDoc(title string, content string, folderID string) (string, error) {
 	if c.docsService == nil || c.driveService == nil {
 		return "", errors.New("google services not initialized")
@@ -243,18 +223,75 @@ func (c *GeminiClient) CreateGoogleDoc(title string, content string, folderID st
 	return fmt.Sprintf("https://docs.google.com/document/d/%s/edit", createdFile.Id), nil
 }
 
-func (c *GeminiClient) GenerateSRS(brdContent string) (string, error) {
-	prompt := fmt.Sprintf(`Analisis file BRD ini dan buatkan Draft SRS yang sangat detail dalam format Markdown.`, brdContent)
+func (c *GeminiClient) Info() domain.AIClientInfo {
+	return domain.AIClientInfo{Provider: "gemini", Model: c.model, PromptVersion: promptVersion}
+}
 
-	return c.callGemini(prompt)
+func (c *GeminiClient) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	reporting := *c
+	reporting.report = report
+	return &reporting
 }
 
-func (c *GeminiClient) AnalyzeDocument(content string) (map[string]interface{}, error) {
-	prompt := fmt.Sprintf(`Analyze this document and extract key information in JSON format:
+// reportUsage passes on the token counts of a response; in a stream every
+// chunk repeats the running totals, so only the last one is reported. A
+// response without usageMetadata is reported as unknown usage.
+func (c *GeminiClient) reportUsage(resp geminiResponse) {
+	if c.report == nil {
+		return
+	}
+	if resp.UsageMetadata == nil {
+		c.report(domain.AITokenUsage{Unknown: true})
+		return
+	}
+	c.report(domain.AITokenUsage{
+		PromptTokens:     resp.UsageMetadata.PromptTokenCount,
+		CompletionTokens: resp.UsageMetadata.CandidatesTokenCount,
+	})
+}
 
-%s
+func (c *GeminiClient) GenerateSRS(brdContent string, ctx domain.PromptContext) (string, error) {
+	return c.callGemini(geminiSRSPrompt(brdContent, ctx))
+}
+
+func (c *GeminiClient) StreamSRS(brdContent string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return c.streamGemini(geminiSRSPrompt(brdContent, ctx), onToken)
+}
 
-Return a JSON object with: title, summary, key_points (array), requirements (array), stakeholders (array)`, content)
+func geminiSRSPrompt(brdContent string, ctx domain.PromptContext) chatPrompt {
+	return newPrompt(`Analisis file BRD yang diberikan pengguna dan buatkan Draft SRS yang sangat detail dalam format Markdown.
+`+promptInstructions(ctx), promptData{label: "BRD", content: brdContent})
+}
+
+func (c *GeminiClient) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	return c.callGemini(newPrompt(fmt.Sprintf(userStoryPrompt, promptInstructions(ctx)), promptData{label: "Requirements", content: requirements}))
+}
+
+func (c *GeminiClient) ExtractGlossary(brdContent string) (string, error) {
+	return c.callGemini(newPrompt(glossaryPrompt, promptData{label: "BRD", content: brdContent}))
+}
+
+func (c *GeminiClient) ExtractUseCaseModel(srsContent string) (string, error) {
+	return c.callGemini(newPrompt(useCaseModelPrompt, promptData{label: "SRS", content: srsContent}))
+}
+
+func (c *GeminiClient) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return c.callGemini(newPrompt(apiDesignPrompt,
+		promptData{label: "Requirement fungsional", content: requirements},
+		promptData{label: "Entitas data", content: entities},
+	))
+}
+
+func (c *GeminiClient) ExtractDataDictionary(srsContent string) (string, error) {
+	return c.callGemini(newPrompt(dataDictionaryPrompt, promptData{label: "SRS", content: srsContent}))
+}
+
+func (c *GeminiClient) AnalyzeDocument(content string) (map[string]interface{}, error) {
+	prompt := newPrompt(`Analyze this document and extract key information in JSON format.
+
+Return a JSON object with: title, summary, key_points (array), requirements (array), stakeholders (array)`,
+		promptData{label: "Document", content: content},
+	)
 
 	response, err := c.callGemini(prompt)
 	if err != nil {
@@ -269,22 +306,12 @@ Return a JSON object with: title, summary, key_points (array), requirements (arr
 	return result, nil
 }
 
-func (c *GeminiClient) callGemini(prompt string) (string, error) {
+func (c *GeminiClient) callGemini(prompt chatPrompt) (string, error) {
 	if c.apiKey == "" {
 		return "", errors.New("Gemini API key not configured")
 	}
 
-	reqBody := geminiRequest{
-		Contents: []geminiContent{
-			{
-				Parts: []geminiPart{
-					{Text: prompt},
-				},
-			},
-		},
-	}
-
-	jsonData, err := json.Marshal(reqBody)
+	jsonData, err := json.Marshal(newGeminiRequest(prompt))
 	if err != nil {
 		return "", err
 	}
@@ -317,6 +344,7 @@ func (c *GeminiClient) callGemini(prompt string) (string, error) {
 	if err := json.Unmarshal(body, &geminiResp); err != nil {
 		return "", err
 	}
+	c.reportUsage(geminiResp)
 
 	if len(geminiResp.Candidates) == 0 || len(geminiResp.Candidates[0].Content.Parts) == 0 {
 		return "", errors.New("no response from Gemini")
@@ -324,3 +352,69 @@ func (c *GeminiClient) callGemini(prompt string) (string, error) {
 
 	return geminiResp.Candidates[0].Content.Parts[0].Text, nil
 }
+
+// streamGemini is callGemini with the response delivered piece by piece to
+// onToken, read from the server-sent events of streamGenerateContent
+func (c *GeminiClient) streamGemini(prompt chatPrompt, onToken func(token string)) (string, error) {
+	if c.apiKey == "" {
+		return "", errors.New("Gemini API key not configured")
+	}
+
+	jsonData, err := json.Marshal(newGeminiRequest(prompt))
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("https://generativelanguage.googleapis.com/v1beta/models/%s:streamGenerateContent?alt=sse&key=%s", c.model, c.apiKey)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("Gemini API error: %s", string(body))
+	}
+
+	var content strings.Builder
+	var last geminiResponse
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+
+		var chunk geminiResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", err
+		}
+		last = chunk
+		for _, candidate := range chunk.Candidates {
+			for _, part := range candidate.Content.Parts {
+				if part.Text == "" {
+					continue
+				}
+				content.WriteString(part.Text)
+				onToken(part.Text)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	c.reportUsage(last)
+
+	if content.Len() == 0 {
+		return "", errors.New("no response from Gemini")
+	}
+	return content.String(), nil
+}
@@ -2,16 +2,21 @@ package external
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
 	"strings"
 
-	"github.com/gingfrederik/docx"
 	openai "github.com/sashabaranov/go-openai"
 )
 
 type GroqClient struct {
 	client *openai.Client
 	model  string
+	// Receives the token usage of each response; may be nil
+	report func(usage domain.AITokenUsage)
 }
 
 func NewGroqClient(apiKey string) *GroqClient {
@@ -28,10 +33,37 @@ func NewGroqClient(apiKey string) *GroqClient {
 	}
 }
 
+func (c *GroqClient) Info() domain.AIClientInfo {
+	return domain.AIClientInfo{Provider: "groq", Model: c.model, PromptVersion: promptVersion}
+}
+
+func (c *GroqClient) WithUsage(report func(usage domain.AITokenUsage)) ports.AIService {
+	reporting := *c
+	reporting.report = report
+	return &reporting
+}
+
+// reportUsage passes on the token counts of a response, which are all 0 when
+// Groq left them out
+func (c *GroqClient) reportUsage(usage openai.Usage) {
+	if c.report != nil {
+		c.report(domain.AITokenUsage{PromptTokens: usage.PromptTokens, CompletionTokens: usage.CompletionTokens, Unknown: usage.TotalTokens == 0})
+	}
+}
+
 // Implementasi Interface: GenerateSRS
-func (c *GroqClient) GenerateSRS(content string) (string, error) {
-	prompt := fmt.Sprintf(`You are a Senior System Analyst. 
-Buatlah Software Requirements Specification (SRS) yang komprehensif berdasarkan input teks di bawah ini.
+func (c *GroqClient) GenerateSRS(content string, ctx domain.PromptContext) (string, error) {
+	return c.complete(srsPrompt(content, ctx))
+}
+
+// Implementasi Interface: StreamSRS
+func (c *GroqClient) StreamSRS(content string, ctx domain.PromptContext, onToken func(token string)) (string, error) {
+	return c.stream(srsPrompt(content, ctx), onToken)
+}
+
+func srsPrompt(content string, ctx domain.PromptContext) chatPrompt {
+	return newPrompt(`You are a Senior System Analyst. 
+Buatlah Software Requirements Specification (SRS) yang komprehensif berdasarkan teks BRD yang diberikan pengguna.
 
 CATATAN PENTING:
 1. Input berupa teks yang diekstrak dari dokumen. Gambar atau diagram TIDAK disertakan.
@@ -39,26 +71,48 @@ CATATAN PENTING:
 2. Jika teks merujuk pada diagram yang hilang (misalnya, "lihat Gambar 1"), simpulkan logikanya dari konteks sekitarnya jika memungkinkan.
 
 3. Keluarkan HANYA isi SRS.
-
-Data Input:
-%s
-
+`+promptInstructions(ctx)+`
 Struktur:
 1. Pendahuluan
 2. Persyaratan Fungsional
 3. Persyaratan Non-Fungsional
-4. Fitur Sistem`, content)
+4. Fitur Sistem`, promptData{label: "BRD", content: content})
+}
+
+// Implementasi Interface: GenerateUserStories
+func (c *GroqClient) GenerateUserStories(requirements string, ctx domain.PromptContext) (string, error) {
+	return c.complete(newPrompt(fmt.Sprintf(userStoryPrompt, promptInstructions(ctx)), promptData{label: "Requirements", content: requirements}))
+}
 
+// Implementasi Interface: ExtractGlossary
+func (c *GroqClient) ExtractGlossary(brdContent string) (string, error) {
+	return c.complete(newPrompt(glossaryPrompt, promptData{label: "BRD", content: brdContent}))
+}
+
+// Implementasi Interface: ExtractUseCaseModel
+func (c *GroqClient) ExtractUseCaseModel(srsContent string) (string, error) {
+	return c.complete(newPrompt(useCaseModelPrompt, promptData{label: "SRS", content: srsContent}))
+}
+
+// Implementasi Interface: DraftAPIDesign
+func (c *GroqClient) DraftAPIDesign(requirements string, entities string) (string, error) {
+	return c.complete(newPrompt(apiDesignPrompt,
+		promptData{label: "Requirement fungsional", content: requirements},
+		promptData{label: "Entitas data", content: entities},
+	))
+}
+
+// Implementasi Interface: ExtractDataDictionary
+func (c *GroqClient) ExtractDataDictionary(srsContent string) (string, error) {
+	return c.complete(newPrompt(dataDictionaryPrompt, promptData{label: "SRS", content: srsContent}))
+}
+
+func (c *GroqClient) complete(prompt chatPrompt) (string, error) {
 	resp, err := c.client.CreateChatCompletion(
 		context.Background(),
 		openai.ChatCompletionRequest{
-			Model: c.model,
-			Messages: []openai.ChatCompletionMessage{
-				{
-					Role:    openai.ChatMessageRoleUser,
-					Content: prompt,
-				},
-			},
+			Model:    c.model,
+			Messages: prompt.messages(),
 			// Groq support max tokens, sesuaikan kebutuhan
 			MaxTokens: 4096,
 		},
@@ -67,34 +121,64 @@ Struktur:
 	if err != nil {
 		return "", fmt.Errorf("groq api error: %w", err)
 	}
+	c.reportUsage(resp.Usage)
+	if len(resp.Choices) == 0 {
+		return "", errors.New("no response from Groq")
+	}
 
 	return resp.Choices[0].Message.Content, nil
 }
 
-// Implementasi Interface: GenerateDocxFile (Sama persis seperti sebelumnya)
-func (c *GroqClient) GenerateDocxFile(content string, filename string) (string, error) {
-	f := docx.NewFile()
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
+// stream is complete with the response delivered piece by piece to onToken
+func (c *GroqClient) stream(prompt chatPrompt, onToken func(token string)) (string, error) {
+	stream, err := c.client.CreateChatCompletionStream(
+		context.Background(),
+		openai.ChatCompletionRequest{
+			Model:     c.model,
+			Messages:  prompt.messages(),
+			MaxTokens: 4096,
+			Stream:    true,
+			// The last chunk then carries the token usage
+			StreamOptions: &openai.StreamOptions{IncludeUsage: true},
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("groq api error: %w", err)
+	}
+	defer stream.Close()
+
+	var content strings.Builder
+	var usage openai.Usage
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("groq api error: %w", err)
+		}
+		if resp.Usage != nil {
+			usage = *resp.Usage
+		}
+		if len(resp.Choices) == 0 || resp.Choices[0].Delta.Content == "" {
 			continue
 		}
-		// Bersihkan markdown simpel
-		cleanLine := strings.ReplaceAll(line, "**", "")
-		cleanLine = strings.ReplaceAll(cleanLine, "##", "")
-		cleanLine = strings.ReplaceAll(cleanLine, "#", "")
-
-		para := f.AddParagraph()
-		para.AddText(cleanLine)
+		token := resp.Choices[0].Delta.Content
+		content.WriteString(token)
+		onToken(token)
 	}
+	c.reportUsage(usage)
 
-	// Simpan ke folder outputs
-	outputPath := fmt.Sprintf("./outputs/%s.docx", filename)
-	err := f.Save(outputPath)
-	if err != nil {
-		return "", fmt.Errorf("gagal save docx: %w", err)
+	if content.Len() == 0 {
+		return "", errors.New("no response from Groq")
 	}
+	return content.String(), nil
+}
 
-	return outputPath, nil
+// messages sends the instructions and the document text as separate roles
+func (p chatPrompt) messages() []openai.ChatCompletionMessage {
+	return []openai.ChatCompletionMessage{
+		{Role: openai.ChatMessageRoleSystem, Content: p.system},
+		{Role: openai.ChatMessageRoleUser, Content: p.user},
+	}
 }
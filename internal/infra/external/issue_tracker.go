@@ -0,0 +1,160 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"srs-automation/internal/core/domain"
+	"srs-automation/internal/core/ports"
+	"strings"
+	"time"
+)
+
+// NewIssueTrackerClient builds the tracker client selected by ISSUE_TRACKER
+// ("jira" or "github"). It returns nil when no tracker is configured. The base
+// URL is configurable so a local mock server can stand in for the real API.
+func NewIssueTrackerClient() ports.IssueTrackerClient {
+	baseURL := strings.TrimRight(os.Getenv("ISSUE_TRACKER_URL"), "/")
+	project := os.Getenv("ISSUE_TRACKER_PROJECT")
+	user := os.Getenv("ISSUE_TRACKER_USER")
+	token := os.Getenv("ISSUE_TRACKER_TOKEN")
+
+	switch strings.ToLower(os.Getenv("ISSUE_TRACKER")) {
+	case "jira":
+		issueType := os.Getenv("ISSUE_TRACKER_ISSUE_TYPE")
+		if issueType == "" {
+			issueType = "Story"
+		}
+		return &JiraClient{
+			baseURL:   baseURL,
+			project:   project,
+			user:      user,
+			token:     token,
+			issueType: issueType,
+			http:      &http.Client{Timeout: 30 * time.Second},
+		}
+	case "github":
+		if baseURL == "" {
+			baseURL = "https://api.github.com"
+		}
+		return &GitHubIssuesClient{
+			baseURL: baseURL,
+			repo:    project,
+			token:   token,
+			http:    &http.Client{Timeout: 30 * time.Second},
+		}
+	case "":
+		return nil
+	default:
+		log.Printf("Unknown ISSUE_TRACKER %q, issue push disabled", os.Getenv("ISSUE_TRACKER"))
+		return nil
+	}
+}
+
+// JiraClient creates issues through the Jira REST API v2
+type JiraClient struct {
+	baseURL   string
+	project   string
+	user      string
+	token     string
+	issueType string
+	http      *http.Client
+}
+
+func (c *JiraClient) CreateIssue(issue domain.TrackerIssue) (*domain.TrackerIssueRef, error) {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		// Jira labels cannot contain spaces
+		labels = append(labels, strings.ReplaceAll(label, " ", "-"))
+	}
+
+	payload := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": c.project},
+			"issuetype":   map[string]string{"name": c.issueType},
+			"summary":     issue.Summary,
+			"description": issue.JiraDescription(),
+			"priority":    map[string]string{"name": issue.Priority},
+			"labels":      labels,
+		},
+	}
+
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := postJSON(c.http, c.baseURL+"/rest/api/2/issue", payload, &resp, func(req *http.Request) {
+		req.SetBasicAuth(c.user, c.token)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domain.TrackerIssueRef{Key: resp.Key, URL: c.baseURL + "/browse/" + resp.Key}, nil
+}
+
+// GitHubIssuesClient creates issues through the GitHub REST API
+type GitHubIssuesClient struct {
+	baseURL string
+	// "owner/repo"
+	repo  string
+	token string
+	http  *http.Client
+}
+
+func (c *GitHubIssuesClient) CreateIssue(issue domain.TrackerIssue) (*domain.TrackerIssueRef, error) {
+	payload := map[string]interface{}{
+		"title":  issue.Summary,
+		"body":   issue.MarkdownBody(),
+		"labels": issue.GitHubLabels(),
+	}
+
+	var resp struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := postJSON(c.http, fmt.Sprintf("%s/repos/%s/issues", c.baseURL, c.repo), payload, &resp, func(req *http.Request) {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domain.TrackerIssueRef{Key: fmt.Sprintf("%s#%d", c.repo, resp.Number), URL: resp.HTMLURL}, nil
+}
+
+// postJSON sends payload as JSON and decodes a 2xx response into out
+func postJSON(client *http.Client, url string, payload interface{}, out interface{}, authorize func(*http.Request)) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("issue tracker error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,122 @@
+package external
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"srs-automation/internal/core/domain"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// JWTIssuer issues and verifies locally signed user tokens with HS256 or RS256
+type JWTIssuer struct {
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	issuer    string
+}
+
+type userClaims struct {
+	Email string      `json:"email"`
+	Role  domain.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTIssuer reads JWT_ALGORITHM (HS256 or RS256), JWT_SECRET for HS256 or
+// JWT_PRIVATE_KEY_FILE / JWT_PUBLIC_KEY_FILE (PEM) for RS256, and JWT_ISSUER
+func NewJWTIssuer() (*JWTIssuer, error) {
+	issuer := os.Getenv("JWT_ISSUER")
+	if issuer == "" {
+		issuer = "srs-automation"
+	}
+
+	switch alg := os.Getenv("JWT_ALGORITHM"); alg {
+	case "", "HS256":
+		secret := []byte(os.Getenv("JWT_SECRET"))
+		if len(secret) == 0 {
+			// Without a configured secret, tokens are only valid until the server restarts
+			log.Println("JWT_SECRET is not set, using a random signing key")
+			secret = make([]byte, 32)
+			rand.Read(secret)
+		}
+		return &JWTIssuer{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret, issuer: issuer}, nil
+
+	case "RS256":
+		privatePEM, err := os.ReadFile(os.Getenv("JWT_PRIVATE_KEY_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca JWT_PRIVATE_KEY_FILE: %w", err)
+		}
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE tidak valid: %w", err)
+		}
+
+		signer := &JWTIssuer{method: jwt.SigningMethodRS256, signKey: privateKey, verifyKey: &privateKey.PublicKey, issuer: issuer}
+		// A separate public key is optional; it has to match the private key
+		if publicFile := os.Getenv("JWT_PUBLIC_KEY_FILE"); publicFile != "" {
+			publicPEM, err := os.ReadFile(publicFile)
+			if err != nil {
+				return nil, fmt.Errorf("gagal membaca JWT_PUBLIC_KEY_FILE: %w", err)
+			}
+			publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+			if err != nil {
+				return nil, fmt.Errorf("JWT_PUBLIC_KEY_FILE tidak valid: %w", err)
+			}
+			if !publicKey.Equal(&privateKey.PublicKey) {
+				return nil, errors.New("JWT_PUBLIC_KEY_FILE tidak cocok dengan JWT_PRIVATE_KEY_FILE")
+			}
+			signer.verifyKey = publicKey
+		}
+		return signer, nil
+
+	default:
+		return nil, fmt.Errorf("JWT_ALGORITHM %q tidak didukung (HS256 atau RS256)", alg)
+	}
+}
+
+func (j *JWTIssuer) Issue(claims domain.TokenClaims) (string, error) {
+	token := jwt.NewWithClaims(j.method, userClaims{
+		Email: claims.Email,
+		Role:  claims.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    j.issuer,
+			Subject:   strconv.FormatUint(uint64(claims.UserID), 10),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+	})
+	return token.SignedString(j.signKey)
+}
+
+func (j *JWTIssuer) Parse(raw string) (*domain.TokenClaims, error) {
+	var claims userClaims
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) {
+		return j.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{j.method.Alg()}),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &domain.TokenClaims{
+		UserID:    uint(userID),
+		Email:     claims.Email,
+		Role:      claims.Role,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
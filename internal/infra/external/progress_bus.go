@@ -0,0 +1,107 @@
+package external
+
+import (
+	"srs-automation/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// progressRetention is how long the final event of a run stays available to
+// clients that connect after processing ended
+const progressRetention = time.Minute
+
+// progressBuffer is the number of events a subscriber may fall behind before
+// further events are dropped for it; the final event is always delivered
+const progressBuffer = 256
+
+// MemoryProgressBus broadcasts progress events inside the process. Document
+// IDs are unique across tenants, so one bus serves the whole server.
+type MemoryProgressBus struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan domain.ProgressEvent]struct{}
+	last        map[uint]domain.ProgressEvent
+}
+
+func NewProgressBus() *MemoryProgressBus {
+	return &MemoryProgressBus{
+		subscribers: make(map[uint]map[chan domain.ProgressEvent]struct{}),
+		last:        make(map[uint]domain.ProgressEvent),
+	}
+}
+
+func (b *MemoryProgressBus) Publish(event domain.ProgressEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.Stage != domain.ProgressToken {
+		b.last[event.DocumentID] = event
+	}
+	if event.Final() {
+		time.AfterFunc(progressRetention, func() { b.forget(event) })
+	}
+
+	for ch := range b.subscribers[event.DocumentID] {
+		// A slow client loses events instead of holding up processing
+		select {
+		case ch <- event:
+		default:
+			if event.Final() {
+				b.replaceOldest(ch, event)
+			}
+		}
+	}
+}
+
+// replaceOldest makes room for the final event in a full buffer by dropping
+// the oldest event, so the client still learns that the run ended. Publish
+// is the only sender and holds the lock, so the send can't block.
+func (b *MemoryProgressBus) replaceOldest(ch chan domain.ProgressEvent, event domain.ProgressEvent) {
+	select {
+	case <-ch:
+	default:
+	}
+	ch <- event
+}
+
+func (b *MemoryProgressBus) Subscribe(documentID uint) (<-chan domain.ProgressEvent, func()) {
+	ch := make(chan domain.ProgressEvent, progressBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[documentID] == nil {
+		b.subscribers[documentID] = make(map[chan domain.ProgressEvent]struct{})
+	}
+	b.subscribers[documentID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[documentID], ch)
+			if len(b.subscribers[documentID]) == 0 {
+				delete(b.subscribers, documentID)
+			}
+		})
+	}
+}
+
+func (b *MemoryProgressBus) Last(documentID uint) (domain.ProgressEvent, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	event, ok := b.last[documentID]
+	return event, ok
+}
+
+// forget drops the final event of a run unless a newer run has started since
+func (b *MemoryProgressBus) forget(event domain.ProgressEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if last, ok := b.last[event.DocumentID]; ok && last.Time.Equal(event.Time) {
+		delete(b.last, event.DocumentID)
+	}
+}
@@ -0,0 +1,38 @@
+package external
+
+import (
+	"testing"
+
+	"srs-automation/internal/core/domain"
+)
+
+func TestProgressBusDeliversFinalEventToFullSubscriber(t *testing.T) {
+	bus := NewProgressBus()
+	events, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	// A client that reads nothing while the whole SRS streams in
+	for i := 0; i < progressBuffer*2; i++ {
+		bus.Publish(domain.ProgressEvent{DocumentID: 1, Stage: domain.ProgressToken, Token: "x"})
+	}
+	bus.Publish(domain.ProgressEvent{DocumentID: 1, Stage: domain.ProgressCompleted})
+
+	var last domain.ProgressEvent
+	for len(events) > 0 {
+		last = <-events
+	}
+	if !last.Final() {
+		t.Errorf("last buffered event = %q, want the final completed event", last.Stage)
+	}
+}
+
+func TestProgressBusOnlyPublishesToDocumentSubscribers(t *testing.T) {
+	bus := NewProgressBus()
+	events, cancel := bus.Subscribe(1)
+	defer cancel()
+
+	bus.Publish(domain.ProgressEvent{DocumentID: 2, Stage: domain.ProgressExtracting})
+	if len(events) != 0 {
+		t.Errorf("subscriber of document 1 received %d events of document 2", len(events))
+	}
+}
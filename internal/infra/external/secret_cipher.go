@@ -0,0 +1,65 @@
+package external
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+)
+
+var (
+	ErrSecretsKeyMissing = errors.New("SECRETS_ENCRYPTION_KEY is not set")
+	ErrInvalidSecret     = errors.New("encrypted secret is corrupt or was encrypted with another key")
+)
+
+// AESCipher encrypts secrets with AES-256-GCM under a key derived from
+// SECRETS_ENCRYPTION_KEY
+type AESCipher struct {
+	aead cipher.AEAD
+}
+
+func NewSecretCipher() *AESCipher {
+	passphrase := os.Getenv("SECRETS_ENCRYPTION_KEY")
+	if passphrase == "" {
+		// Unlike signing keys, a random key would make stored secrets unreadable after a restart
+		return &AESCipher{}
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, _ := aes.NewCipher(key[:])
+	aead, _ := cipher.NewGCM(block)
+	return &AESCipher{aead: aead}
+}
+
+func (c *AESCipher) Encrypt(plain string) (string, error) {
+	if c.aead == nil {
+		return "", ErrSecretsKeyMissing
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *AESCipher) Decrypt(encrypted string) (string, error) {
+	if c.aead == nil {
+		return "", ErrSecretsKeyMissing
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrInvalidSecret
+	}
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrInvalidSecret
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,60 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"srs-automation/internal/core/ports"
+	"time"
+)
+
+// SMTPMailer sends email through an SMTP server. Any local SMTP stand-in
+// (MailHog, smtp4dev, ...) works; authentication is only used when a
+// username is configured.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewMailer builds a mailer from SMTP_HOST, SMTP_PORT (default 587),
+// SMTP_USERNAME, SMTP_PASSWORD and SMTP_FROM. It returns nil when SMTP_HOST
+// is not set, which disables email notifications.
+func NewMailer() ports.Mailer {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil
+	}
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "srs-automation@localhost"
+	}
+
+	mailer := &SMTPMailer{addr: net.JoinHostPort(host, port), from: from}
+	if user := os.Getenv("SMTP_USERNAME"); user != "" {
+		mailer.auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return mailer
+}
+
+func (m *SMTPMailer) Send(to string, subject string, body string) error {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", m.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.Write(bytes.ReplaceAll([]byte(body), []byte("\n"), []byte("\r\n")))
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{to}, msg.Bytes())
+}
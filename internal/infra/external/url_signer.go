@@ -0,0 +1,50 @@
+package external
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+type HMACSigner struct {
+	key []byte
+}
+
+func NewURLSigner() *HMACSigner {
+	key := []byte(os.Getenv("DOWNLOAD_SIGNING_KEY"))
+	if len(key) == 0 {
+		// Without a configured key, links are only valid until the server restarts
+		log.Println("DOWNLOAD_SIGNING_KEY is not set, using a random signing key")
+		key = make([]byte, 32)
+		rand.Read(key)
+	}
+
+	return &HMACSigner{key: key}
+}
+
+func (s *HMACSigner) Sign(payload string, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	mac.Write([]byte("|"))
+	mac.Write([]byte(strconv.FormatInt(expiresAt.Unix(), 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HMACSigner) Verify(payload string, expiresAt time.Time, signature string) bool {
+	expected, err := hex.DecodeString(s.Sign(payload, expiresAt))
+	if err != nil {
+		return false
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(expected, given)
+}
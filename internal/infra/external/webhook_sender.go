@@ -0,0 +1,45 @@
+package external
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPWebhookSender POSTs webhook payloads. Redirects aren't followed, so a
+// receiver can't bounce the signed payload to another host.
+type HTTPWebhookSender struct {
+	http *http.Client
+}
+
+func NewWebhookSender() *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPWebhookSender) Send(url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return resp.StatusCode, nil
+}
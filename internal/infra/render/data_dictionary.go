@@ -0,0 +1,54 @@
+package render
+
+import (
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"strings"
+)
+
+// documentContent is the SRS content followed by the data dictionary section
+func documentContent(doc *domain.ExportDocument) string {
+	return appendDataDictionary(doc.Content, doc.DataDictionary)
+}
+
+// appendDataDictionary adds a "Kamus Data" section with one attribute table per entity
+func appendDataDictionary(content string, entities []domain.DataEntity) string {
+	if len(entities) == 0 {
+		return content
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(content, "\n"))
+	b.WriteString("\n\n## Kamus Data\n")
+
+	for _, entity := range entities {
+		fmt.Fprintf(&b, "\n### %s\n\n", entity.Name)
+		if entity.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", entity.Description)
+		}
+		if len(entity.Sources) > 0 {
+			fmt.Fprintf(&b, "Sumber: %s\n\n", strings.Join(entity.Sources, ", "))
+		}
+		if len(entity.Attributes) == 0 {
+			continue
+		}
+
+		b.WriteString("| Atribut | Tipe | Wajib | Batasan | Deskripsi | Sumber |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+		for _, attr := range entity.Attributes {
+			required := "Tidak"
+			if attr.Required {
+				required = "Ya"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				tableCell(attr.Name), tableCell(attr.Type), required,
+				tableCell(strings.Join(attr.Constraints, "; ")), tableCell(attr.Description), tableCell(attr.Source))
+		}
+	}
+	return b.String()
+}
+
+// tableCell keeps a value inside a single Markdown table cell
+func tableCell(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "|", "/")
+}
@@ -0,0 +1,372 @@
+package render
+
+import (
+	"fmt"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"strings"
+)
+
+var diagramIdentRe = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// erCardinality maps relation cardinalities to crow's foot notation, which
+// Mermaid erDiagram and PlantUML IE diagrams share
+var erCardinality = map[string]string{
+	domain.CardinalityOneToOne:   "||--||",
+	domain.CardinalityOneToMany:  "||--o{",
+	domain.CardinalityManyToOne:  "}o--||",
+	domain.CardinalityManyToMany: "}o--o{",
+}
+
+// MermaidRenderer implements ports.DiagramRenderer
+type MermaidRenderer struct{}
+
+func NewMermaidRenderer() *MermaidRenderer {
+	return &MermaidRenderer{}
+}
+
+func (r *MermaidRenderer) Format() string {
+	return "mermaid"
+}
+
+// Render produces a use case diagram (as a flowchart, Mermaid has no use case
+// diagram type), one sequence diagram per use case flow and an ER diagram
+func (r *MermaidRenderer) Render(model *domain.UseCaseModel) []domain.Diagram {
+	var diagrams []domain.Diagram
+
+	if len(model.UseCases) > 0 {
+		var b strings.Builder
+		actors := diagramActors(model)
+		b.WriteString("flowchart LR\n")
+		for i, actor := range actors {
+			fmt.Fprintf(&b, "  a%d((\"%s\"))\n", i+1, mermaidText(actor.Name))
+		}
+		b.WriteString("  subgraph system[\"Sistem\"]\n")
+		for i, uc := range model.UseCases {
+			fmt.Fprintf(&b, "    uc%d([\"%s\"])\n", i+1, mermaidText(useCaseLabel(uc)))
+		}
+		b.WriteString("  end\n")
+		writeUseCaseEdges(&b, model, actors, func(from, to, label string) {
+			if label == "" {
+				fmt.Fprintf(&b, "  %s --> %s\n", from, to)
+			} else {
+				fmt.Fprintf(&b, "  %s -.->|%s| %s\n", from, label, to)
+			}
+		})
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramUseCase, Format: r.Format(), Title: "Use Case Diagram", Source: b.String(),
+		})
+	}
+
+	for _, uc := range model.UseCases {
+		if len(uc.Flow) == 0 {
+			continue
+		}
+		var b strings.Builder
+		b.WriteString("sequenceDiagram\n")
+		participants := flowParticipants(uc)
+		for i, name := range participants {
+			keyword := "participant"
+			if isHumanActor(model, name) {
+				keyword = "actor"
+			}
+			fmt.Fprintf(&b, "  %s p%d as %s\n", keyword, i+1, mermaidText(name))
+		}
+		for _, step := range uc.Flow {
+			fmt.Fprintf(&b, "  p%d->>p%d: %s\n", indexOf(participants, step.From)+1, indexOf(participants, step.To)+1, mermaidText(step.Message))
+		}
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramSequence, Format: r.Format(), Title: useCaseLabel(uc), Source: b.String(),
+		})
+	}
+
+	if len(model.Entities) > 0 {
+		var b strings.Builder
+		b.WriteString("erDiagram\n")
+		for _, entity := range model.Entities {
+			fmt.Fprintf(&b, "  %s {\n", diagramIdent(entity.Name))
+			for _, attr := range entity.Attributes {
+				fmt.Fprintf(&b, "    %s %s", diagramIdent(attributeType(attr)), diagramIdent(attr.Name))
+				if attr.Key == "PK" || attr.Key == "FK" {
+					fmt.Fprintf(&b, " %s", attr.Key)
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("  }\n")
+		}
+		for _, entity := range model.Entities {
+			for _, rel := range entity.Relations {
+				fmt.Fprintf(&b, "  %s %s %s : \"%s\"\n", diagramIdent(entity.Name), relationNotation(rel),
+					diagramIdent(rel.Target), mermaidText(rel.Label))
+			}
+		}
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramER, Format: r.Format(), Title: "Entity Relationship Diagram", Source: b.String(),
+		})
+	}
+
+	return diagrams
+}
+
+// PlantUMLRenderer implements ports.DiagramRenderer
+type PlantUMLRenderer struct{}
+
+func NewPlantUMLRenderer() *PlantUMLRenderer {
+	return &PlantUMLRenderer{}
+}
+
+func (r *PlantUMLRenderer) Format() string {
+	return "plantuml"
+}
+
+func (r *PlantUMLRenderer) Render(model *domain.UseCaseModel) []domain.Diagram {
+	var diagrams []domain.Diagram
+
+	if len(model.UseCases) > 0 {
+		var b strings.Builder
+		actors := diagramActors(model)
+		b.WriteString("@startuml\nleft to right direction\n")
+		for i, actor := range actors {
+			fmt.Fprintf(&b, "actor \"%s\" as a%d\n", plantUMLText(actor.Name), i+1)
+		}
+		b.WriteString("rectangle \"Sistem\" {\n")
+		for i, uc := range model.UseCases {
+			fmt.Fprintf(&b, "  usecase \"%s\" as uc%d\n", plantUMLText(useCaseLabel(uc)), i+1)
+		}
+		b.WriteString("}\n")
+		writeUseCaseEdges(&b, model, actors, func(from, to, label string) {
+			if label == "" {
+				fmt.Fprintf(&b, "%s --> %s\n", from, to)
+			} else {
+				fmt.Fprintf(&b, "%s ..> %s : <<%s>>\n", from, to, label)
+			}
+		})
+		b.WriteString("@enduml\n")
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramUseCase, Format: r.Format(), Title: "Use Case Diagram", Source: b.String(),
+		})
+	}
+
+	for _, uc := range model.UseCases {
+		if len(uc.Flow) == 0 {
+			continue
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "@startuml\ntitle %s\n", plantUMLText(useCaseLabel(uc)))
+		participants := flowParticipants(uc)
+		for i, name := range participants {
+			keyword := "participant"
+			if isHumanActor(model, name) {
+				keyword = "actor"
+			}
+			fmt.Fprintf(&b, "%s \"%s\" as p%d\n", keyword, plantUMLText(name), i+1)
+		}
+		for _, step := range uc.Flow {
+			fmt.Fprintf(&b, "p%d -> p%d : %s\n", indexOf(participants, step.From)+1, indexOf(participants, step.To)+1, plantUMLText(step.Message))
+		}
+		b.WriteString("@enduml\n")
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramSequence, Format: r.Format(), Title: useCaseLabel(uc), Source: b.String(),
+		})
+	}
+
+	if len(model.Entities) > 0 {
+		var b strings.Builder
+		b.WriteString("@startuml\nhide circle\nskinparam linetype ortho\n")
+		for _, entity := range model.Entities {
+			fmt.Fprintf(&b, "entity \"%s\" as %s {\n", plantUMLText(entity.Name), diagramIdent(entity.Name))
+			// Primary keys go above the separator line, as in IE notation
+			for _, attr := range entity.Attributes {
+				if attr.Key == "PK" {
+					fmt.Fprintf(&b, "  * %s : %s <<PK>>\n", plantUMLText(attr.Name), plantUMLText(attributeType(attr)))
+				}
+			}
+			b.WriteString("  --\n")
+			for _, attr := range entity.Attributes {
+				switch attr.Key {
+				case "PK":
+				case "FK":
+					fmt.Fprintf(&b, "  %s : %s <<FK>>\n", plantUMLText(attr.Name), plantUMLText(attributeType(attr)))
+				default:
+					fmt.Fprintf(&b, "  %s : %s\n", plantUMLText(attr.Name), plantUMLText(attributeType(attr)))
+				}
+			}
+			b.WriteString("}\n")
+		}
+		for _, entity := range model.Entities {
+			for _, rel := range entity.Relations {
+				fmt.Fprintf(&b, "%s %s %s", diagramIdent(entity.Name), relationNotation(rel), diagramIdent(rel.Target))
+				if rel.Label != "" {
+					fmt.Fprintf(&b, " : %s", plantUMLText(rel.Label))
+				}
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("@enduml\n")
+		diagrams = append(diagrams, domain.Diagram{
+			Kind: domain.DiagramER, Format: r.Format(), Title: "Entity Relationship Diagram", Source: b.String(),
+		})
+	}
+
+	return diagrams
+}
+
+// diagramActors returns the model's actors followed by any actor that is only
+// named by a use case
+func diagramActors(model *domain.UseCaseModel) []domain.Actor {
+	actors := append([]domain.Actor(nil), model.Actors...)
+	for _, uc := range model.UseCases {
+		for _, name := range uc.Actors {
+			if model.FindActor(name) == nil && actorIndex(actors, name) < 0 {
+				actors = append(actors, domain.Actor{Name: name, Kind: domain.ActorPrimary})
+			}
+		}
+	}
+	return actors
+}
+
+// writeUseCaseEdges emits actor associations (primary actors point at the use
+// case, secondary and system actors are pointed at) and include/extend edges
+func writeUseCaseEdges(b *strings.Builder, model *domain.UseCaseModel, actors []domain.Actor, edge func(from, to, label string)) {
+	for i, uc := range model.UseCases {
+		ucID := fmt.Sprintf("uc%d", i+1)
+		for _, name := range uc.Actors {
+			idx := actorIndex(actors, name)
+			if idx < 0 {
+				continue
+			}
+			actorID := fmt.Sprintf("a%d", idx+1)
+			if actors[idx].Kind == domain.ActorPrimary || actors[idx].Kind == "" {
+				edge(actorID, ucID, "")
+			} else {
+				edge(ucID, actorID, "")
+			}
+		}
+		for _, target := range uc.Includes {
+			if j := useCaseIndex(model.UseCases, target); j >= 0 {
+				edge(ucID, fmt.Sprintf("uc%d", j+1), "include")
+			}
+		}
+		for _, target := range uc.Extends {
+			if j := useCaseIndex(model.UseCases, target); j >= 0 {
+				edge(ucID, fmt.Sprintf("uc%d", j+1), "extend")
+			}
+		}
+	}
+}
+
+// flowParticipants lists the participants of a use case flow in order of appearance
+func flowParticipants(uc domain.UseCase) []string {
+	var participants []string
+	for _, step := range uc.Flow {
+		for _, name := range []string{step.From, step.To} {
+			if indexOf(participants, name) < 0 {
+				participants = append(participants, name)
+			}
+		}
+	}
+	return participants
+}
+
+func isHumanActor(model *domain.UseCaseModel, name string) bool {
+	actor := model.FindActor(name)
+	return actor != nil && actor.Kind != domain.ActorSystem
+}
+
+func useCaseLabel(uc domain.UseCase) string {
+	if uc.ID == "" {
+		return uc.Name
+	}
+	return uc.ID + " " + uc.Name
+}
+
+func attributeType(attr domain.EntityAttribute) string {
+	if attr.Type == "" {
+		return "string"
+	}
+	return attr.Type
+}
+
+// relationNotation defaults to one-to-many for unknown cardinalities
+func relationNotation(rel domain.EntityRelation) string {
+	if notation, ok := erCardinality[strings.ToLower(rel.Cardinality)]; ok {
+		return notation
+	}
+	return erCardinality[domain.CardinalityOneToMany]
+}
+
+func actorIndex(actors []domain.Actor, name string) int {
+	for i, actor := range actors {
+		if actor.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func useCaseIndex(useCases []domain.UseCase, id string) int {
+	for i, uc := range useCases {
+		if uc.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexOf(items []string, item string) int {
+	for i, v := range items {
+		if v == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// diagramIdent turns a name into an identifier both notations accept
+func diagramIdent(name string) string {
+	ident := strings.Trim(diagramIdentRe.ReplaceAllString(name, "_"), "_")
+	if ident == "" {
+		return "_"
+	}
+	return ident
+}
+
+// mermaidText keeps a label on one line and drops characters that end a
+// Mermaid statement or label
+func mermaidText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.NewReplacer(`"`, "'", ";", ",", "#", "").Replace(text)
+}
+
+func plantUMLText(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), `"`, "'")
+}
+
+// appendDiagrams adds an appendix with the diagram sources as fenced code
+// blocks, grouping the notations of each diagram under one heading
+func appendDiagrams(content string, diagrams []domain.Diagram) string {
+	if len(diagrams) == 0 {
+		return content
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.TrimRight(content, "\n"))
+	b.WriteString("\n\n## Lampiran: Diagram\n")
+
+	written := map[string]bool{}
+	for _, d := range diagrams {
+		key := string(d.Kind) + "\x00" + d.Title
+		if written[key] {
+			continue
+		}
+		written[key] = true
+
+		fmt.Fprintf(&b, "\n### %s\n", d.Title)
+		for _, same := range diagrams {
+			if same.Kind == d.Kind && same.Title == d.Title {
+				fmt.Fprintf(&b, "\n```%s\n%s\n```\n", same.Format, strings.TrimRight(same.Source, "\n"))
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,353 @@
+// Package render turns SRS Markdown into downloadable document formats.
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"srs-automation/internal/core/domain"
+	"srs-automation/pkg/markdown"
+	"strings"
+	"time"
+)
+
+const (
+	bulletNumID   = 1
+	bulletAbsID   = 1
+	orderedAbsID  = 2
+	firstOrderNum = 2
+)
+
+// docxBody accumulates the WordprocessingML body of a document
+type docxBody struct {
+	buf bytes.Buffer
+	// numIDs of ordered lists, each restarting at 1
+	orderedNums []int
+	// offset added to numbering IDs so they don't clash with a template's own
+	numOffset int
+}
+
+// WriteDocx writes a standalone .docx with cover page, table of contents and
+// the Markdown content mapped to Word styles.
+func WriteDocx(w io.Writer, meta domain.ExportMeta, content string) error {
+	body := &docxBody{}
+	body.writeCover(meta)
+	body.writeTOC()
+	body.writeBlocks(markdown.Parse(content))
+	body.buf.WriteString(sectionProperties)
+
+	parts := map[string]string{
+		"[Content_Types].xml":          contentTypesXML,
+		"_rels/.rels":                  rootRelsXML,
+		"docProps/core.xml":            coreProps(meta),
+		"word/_rels/document.xml.rels": documentRelsXML,
+		"word/document.xml":            documentXML(body.buf.String()),
+		"word/styles.xml":              stylesXML(),
+		"word/numbering.xml":           numberingXML(body.numberingDefinitions()),
+		"word/settings.xml":            settingsXML,
+		"word/footer1.xml":             footerXML,
+	}
+
+	return writeZip(w, parts, []string{
+		"[Content_Types].xml", "_rels/.rels", "docProps/core.xml",
+		"word/_rels/document.xml.rels", "word/document.xml", "word/styles.xml",
+		"word/numbering.xml", "word/settings.xml", "word/footer1.xml",
+	})
+}
+
+func writeZip(w io.Writer, parts map[string]string, order []string) error {
+	zw := zip.NewWriter(w)
+	for _, name := range order {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, parts[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (b *docxBody) writeCover(meta domain.ExportMeta) {
+	b.writeStyledText("Title", meta.Title)
+	if meta.Subtitle != "" {
+		b.writeStyledText("Subtitle", meta.Subtitle)
+	}
+
+	if meta.Version != "" {
+		b.writeStyledText("CoverInfo", "Versi: "+meta.Version)
+	}
+	if meta.Status != "" {
+		b.writeStyledText("CoverInfo", "Status: "+meta.Status)
+	}
+	if meta.Author != "" {
+		b.writeStyledText("CoverInfo", "Penyusun: "+meta.Author)
+	}
+	if !meta.Date.IsZero() {
+		b.writeStyledText("CoverInfo", "Tanggal: "+meta.Date.Format("02 January 2006"))
+	}
+
+	if len(meta.Approvals) > 0 {
+		b.buf.WriteString(`<w:p/>`)
+		b.writeApprovalTable(meta.Approvals)
+	}
+
+	b.writePageBreak()
+}
+
+// writeApprovalTable writes the document control table
+func (b *docxBody) writeApprovalTable(approvals []domain.ApprovalEntry) {
+	rows := make([][]string, 0, len(approvals))
+	for _, a := range approvals {
+		date := "-"
+		if a.Date != nil {
+			date = a.Date.Format("02 January 2006")
+		}
+		name := a.Name
+		if name == "" {
+			name = "-"
+		}
+		rows = append(rows, []string{escapeMarkdown(a.Role), escapeMarkdown(name), escapeMarkdown(a.Status), date})
+	}
+	b.writeTable([]string{"Peran", "Nama", "Status", "Tanggal"}, rows)
+}
+
+// escapeMarkdown keeps literal values from being parsed as inline Markdown
+func escapeMarkdown(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune("\\`*_[]()|", r) {
+			out.WriteRune('\\')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func (b *docxBody) writeTOC() {
+	b.writeStyledText("TOCHeading", "Daftar Isi")
+	b.buf.WriteString(`<w:p><w:r><w:fldChar w:fldCharType="begin" w:dirty="true"/></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> TOC \o "1-3" \h \z \u </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"/></w:r>` +
+		`<w:r><w:t>Klik kanan lalu pilih Update Field untuk memperbarui daftar isi.</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end"/></w:r></w:p>`)
+	b.writePageBreak()
+}
+
+func (b *docxBody) writeBlocks(blocks []markdown.Block) {
+	for _, block := range blocks {
+		switch block.Kind {
+		case markdown.BlockHeading:
+			level := block.Level
+			if level > 4 {
+				level = 4
+			}
+			b.writeParagraph(fmt.Sprintf("Heading%d", level), "", block.Text)
+
+		case markdown.BlockParagraph:
+			b.writeParagraph("", "", block.Text)
+
+		case markdown.BlockList:
+			b.writeList(block.Items)
+
+		case markdown.BlockTable:
+			b.writeTable(block.Header, block.Rows)
+
+		case markdown.BlockCode:
+			for _, line := range strings.Split(block.Text, "\n") {
+				b.buf.WriteString(`<w:p><w:pPr><w:pStyle w:val="Code"/></w:pPr>`)
+				writeRun(&b.buf, markdown.Span{Text: line})
+				b.buf.WriteString(`</w:p>`)
+			}
+
+		case markdown.BlockRule:
+			b.buf.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="A6A6A6"/></w:pBdr></w:pPr></w:p>`)
+		}
+	}
+}
+
+func (b *docxBody) writeList(items []markdown.ListItem) {
+	orderedNum := 0
+	for _, item := range items {
+		numID := bulletNumID
+		if item.Ordered {
+			// All ordered items of one list share a numbering instance
+			if orderedNum == 0 {
+				orderedNum = firstOrderNum + len(b.orderedNums)
+				b.orderedNums = append(b.orderedNums, orderedNum)
+			}
+			numID = orderedNum
+		}
+
+		depth := item.Depth
+		if depth > 8 {
+			depth = 8
+		}
+		numPr := fmt.Sprintf(`<w:numPr><w:ilvl w:val="%d"/><w:numId w:val="%d"/></w:numPr>`, depth, numID+b.numOffset)
+		b.writeParagraph("ListParagraph", numPr, item.Text)
+	}
+}
+
+func (b *docxBody) writeTable(header []string, rows [][]string) {
+	cols := len(header)
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	b.buf.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="5000" w:type="pct"/></w:tblPr><w:tblGrid>`)
+	for i := 0; i < cols; i++ {
+		b.buf.WriteString(`<w:gridCol/>`)
+	}
+	b.buf.WriteString(`</w:tblGrid>`)
+
+	b.writeTableRow(header, cols, true)
+	for _, row := range rows {
+		b.writeTableRow(row, cols, false)
+	}
+	b.buf.WriteString(`</w:tbl><w:p/>`)
+}
+
+func (b *docxBody) writeTableRow(cells []string, cols int, header bool) {
+	b.buf.WriteString(`<w:tr>`)
+	if header {
+		b.buf.WriteString(`<w:trPr><w:tblHeader/></w:trPr>`)
+	}
+	for i := 0; i < cols; i++ {
+		text := ""
+		if i < len(cells) {
+			text = cells[i]
+		}
+
+		b.buf.WriteString(`<w:tc><w:tcPr>`)
+		if header {
+			b.buf.WriteString(`<w:shd w:val="clear" w:color="auto" w:fill="D9E2F3"/>`)
+		}
+		b.buf.WriteString(`</w:tcPr><w:p><w:pPr><w:pStyle w:val="TableText"/></w:pPr>`)
+		for _, span := range markdown.ParseInline(text) {
+			span.Bold = span.Bold || header
+			writeRun(&b.buf, span)
+		}
+		b.buf.WriteString(`</w:p></w:tc>`)
+	}
+	b.buf.WriteString(`</w:tr>`)
+}
+
+func (b *docxBody) writeParagraph(style string, extraPPr string, text string) {
+	b.buf.WriteString(`<w:p>`)
+	if style != "" || extraPPr != "" {
+		b.buf.WriteString(`<w:pPr>`)
+		if style != "" {
+			fmt.Fprintf(&b.buf, `<w:pStyle w:val="%s"/>`, style)
+		}
+		b.buf.WriteString(extraPPr)
+		b.buf.WriteString(`</w:pPr>`)
+	}
+	for _, span := range markdown.ParseInline(text) {
+		writeRun(&b.buf, span)
+	}
+	b.buf.WriteString(`</w:p>`)
+}
+
+// writeStyledText writes a paragraph of literal (non-Markdown) text
+func (b *docxBody) writeStyledText(style string, text string) {
+	fmt.Fprintf(&b.buf, `<w:p><w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	writeRun(&b.buf, markdown.Span{Text: text})
+	b.buf.WriteString(`</w:p>`)
+}
+
+func (b *docxBody) writePageBreak() {
+	b.buf.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+}
+
+func writeRun(buf *bytes.Buffer, span markdown.Span) {
+	buf.WriteString(`<w:r>`)
+
+	var rPr strings.Builder
+	switch {
+	case span.RequirementID:
+		rPr.WriteString(`<w:rStyle w:val="RequirementID"/>`)
+	case span.Code:
+		rPr.WriteString(`<w:rStyle w:val="InlineCode"/>`)
+	}
+	if span.Bold {
+		rPr.WriteString(`<w:b/>`)
+	}
+	if span.Italic {
+		rPr.WriteString(`<w:i/>`)
+	}
+	if rPr.Len() > 0 {
+		buf.WriteString(`<w:rPr>` + rPr.String() + `</w:rPr>`)
+	}
+
+	buf.WriteString(`<w:t xml:space="preserve">`)
+	writeEscaped(buf, span.Text)
+	buf.WriteString(`</w:t></w:r>`)
+}
+
+func writeEscaped(buf *bytes.Buffer, text string) {
+	xml.EscapeText(buf, []byte(text))
+}
+
+// numberingDefinitions returns the abstractNum and num elements for the body
+func (b *docxBody) numberingDefinitions() (string, string) {
+	var abstract, nums strings.Builder
+
+	fmt.Fprintf(&abstract, `<w:abstractNum w:abstractNumId="%d"><w:multiLevelType w:val="hybridMultilevel"/>`, bulletAbsID+b.numOffset)
+	bullets := []string{"•", "◦", "▪"}
+	for lvl := 0; lvl < 9; lvl++ {
+		fmt.Fprintf(&abstract, `<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="%s"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="360"/></w:pPr></w:lvl>`,
+			lvl, bullets[lvl%len(bullets)], 720+lvl*360)
+	}
+	abstract.WriteString(`</w:abstractNum>`)
+
+	fmt.Fprintf(&abstract, `<w:abstractNum w:abstractNumId="%d"><w:multiLevelType w:val="multilevel"/>`, orderedAbsID+b.numOffset)
+	for lvl := 0; lvl < 9; lvl++ {
+		var text strings.Builder
+		for k := 1; k <= lvl+1; k++ {
+			fmt.Fprintf(&text, "%%%d.", k)
+		}
+		fmt.Fprintf(&abstract, `<w:lvl w:ilvl="%d"><w:start w:val="1"/><w:numFmt w:val="decimal"/><w:lvlText w:val="%s"/><w:lvlJc w:val="left"/><w:pPr><w:ind w:left="%d" w:hanging="%d"/></w:pPr></w:lvl>`,
+			lvl, text.String(), 720+lvl*480, 360+lvl*120)
+	}
+	abstract.WriteString(`</w:abstractNum>`)
+
+	fmt.Fprintf(&nums, `<w:num w:numId="%d"><w:abstractNumId w:val="%d"/></w:num>`, bulletNumID+b.numOffset, bulletAbsID+b.numOffset)
+	for _, numID := range b.orderedNums {
+		fmt.Fprintf(&nums, `<w:num w:numId="%d"><w:abstractNumId w:val="%d"/><w:lvlOverride w:ilvl="0"><w:startOverride w:val="1"/></w:lvlOverride></w:num>`,
+			numID+b.numOffset, orderedAbsID+b.numOffset)
+	}
+
+	return abstract.String(), nums.String()
+}
+
+func documentXML(body string) string {
+	return xml.Header + `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>` +
+		body + `</w:body></w:document>`
+}
+
+func numberingXML(abstract, nums string) string {
+	return xml.Header + `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		abstract + nums + `</w:numbering>`
+}
+
+func coreProps(meta domain.ExportMeta) string {
+	var title, author bytes.Buffer
+	xml.EscapeText(&title, []byte(meta.Title))
+	xml.EscapeText(&author, []byte(meta.Author))
+
+	date := meta.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	return xml.Header + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<dc:title>` + title.String() + `</dc:title>` +
+		`<dc:creator>` + author.String() + `</dc:creator>` +
+		`<dcterms:created xsi:type="dcterms:W3CDTF">` + date.UTC().Format(time.RFC3339) + `</dcterms:created>` +
+		`</cp:coreProperties>`
+}
@@ -0,0 +1,289 @@
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"srs-automation/pkg/markdown"
+	"strings"
+)
+
+// templateNumOffset keeps generated list numbering clear of the template's own
+const templateNumOffset = 1000
+
+// Placeholders split by Word into several runs, e.g. "{{ti" + "tle}}"
+var splitPlaceholderRe = regexp.MustCompile(`\{(?:<[^>]*>)*\{(?:<[^>]*>|[A-Za-z_ ])*?\}(?:<[^>]*>)*\}`)
+
+var tagRe = regexp.MustCompile(`<[^>]*>`)
+
+var ErrInvalidTemplate = errors.New("template must be a valid .docx or .dotx file")
+
+type zipPart struct {
+	name string
+	data []byte
+}
+
+// ValidateDocxTemplate checks that data is a Word document or template package
+func ValidateDocxTemplate(data []byte) error {
+	parts, err := readZipParts(data)
+	if err != nil {
+		return ErrInvalidTemplate
+	}
+
+	doc := findPart(parts, "word/document.xml")
+	if doc == nil || !bytes.Contains(doc.data, []byte("</w:body>")) {
+		return ErrInvalidTemplate
+	}
+
+	return nil
+}
+
+// WriteDocxFromTemplate renders content into a corporate reference template.
+// The template keeps its own styles, headers, footers and cover page; the
+// placeholders below are filled from the SRS:
+//
+//	{{title}} {{subtitle}} {{version}} {{author}} {{status}} {{date}}
+//	{{approved_by}} {{approved_at}}
+//	{{toc}} {{approval_table}} {{content}} (each on its own paragraph)
+//
+// Without a {{content}} placeholder the SRS is appended to the end of the body.
+func WriteDocxFromTemplate(w io.Writer, template []byte, meta domain.ExportMeta, content string) error {
+	parts, err := readZipParts(template)
+	if err != nil {
+		return ErrInvalidTemplate
+	}
+
+	doc := findPart(parts, "word/document.xml")
+	if doc == nil {
+		return ErrInvalidTemplate
+	}
+
+	body := &docxBody{numOffset: templateNumOffset}
+	body.writeBlocks(markdown.Parse(content))
+
+	toc := &docxBody{}
+	toc.writeTOC()
+
+	approvals := &docxBody{}
+	approvals.writeApprovalTable(meta.Approvals)
+
+	fields := templateFields(meta)
+
+	xmlDoc := normalizePlaceholders(string(doc.data))
+	xmlDoc = replaceBlockPlaceholder(xmlDoc, "{{toc}}", toc.buf.String())
+	xmlDoc = replaceBlockPlaceholder(xmlDoc, "{{approval_table}}", approvals.buf.String())
+	if strings.Contains(xmlDoc, "{{content}}") {
+		xmlDoc = replaceBlockPlaceholder(xmlDoc, "{{content}}", body.buf.String())
+	} else {
+		xmlDoc = appendToBody(xmlDoc, body.buf.String())
+	}
+	doc.data = []byte(fields.Replace(xmlDoc))
+
+	for _, part := range parts {
+		if isHeaderOrFooter(part.name) || part.name == "docProps/core.xml" {
+			part.data = []byte(fields.Replace(normalizePlaceholders(string(part.data))))
+		}
+	}
+
+	if styles := findPart(parts, "word/styles.xml"); styles != nil {
+		styles.data = []byte(injectMissingStyles(string(styles.data)))
+	} else {
+		parts = append(parts, &zipPart{name: "word/styles.xml", data: []byte(stylesXML())})
+		parts = addPartReference(parts, "styles.xml", "styles",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml")
+	}
+
+	abstract, nums := body.numberingDefinitions()
+	if numbering := findPart(parts, "word/numbering.xml"); numbering != nil {
+		numbering.data = []byte(injectNumbering(string(numbering.data), abstract, nums))
+	} else {
+		parts = append(parts, &zipPart{name: "word/numbering.xml", data: []byte(numberingXML(abstract, nums))})
+		parts = addPartReference(parts, "numbering.xml", "numbering",
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml")
+	}
+
+	// A .dotx must be saved as a regular document
+	if ct := findPart(parts, "[Content_Types].xml"); ct != nil {
+		ct.data = bytes.ReplaceAll(ct.data,
+			[]byte("wordprocessingml.template.main+xml"),
+			[]byte("wordprocessingml.document.main+xml"))
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(part.data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func readZipParts(data []byte) ([]*zipPart, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*zipPart, 0, len(zr.File))
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, &zipPart{name: f.Name, data: content})
+	}
+	return parts, nil
+}
+
+func findPart(parts []*zipPart, name string) *zipPart {
+	for _, part := range parts {
+		if part.name == name {
+			return part
+		}
+	}
+	return nil
+}
+
+func isHeaderOrFooter(name string) bool {
+	return strings.HasPrefix(name, "word/header") || strings.HasPrefix(name, "word/footer")
+}
+
+func templateFields(meta domain.ExportMeta) *strings.Replacer {
+	date := ""
+	if !meta.Date.IsZero() {
+		date = meta.Date.Format("02 January 2006")
+	}
+
+	approvedBy, approvedAt := "", ""
+	for _, a := range meta.Approvals {
+		if a.Date != nil && a.Status == "Disetujui" {
+			approvedBy = a.Name
+			approvedAt = a.Date.Format("02 January 2006")
+		}
+	}
+
+	values := map[string]string{
+		"title":       meta.Title,
+		"subtitle":    meta.Subtitle,
+		"version":     meta.Version,
+		"author":      meta.Author,
+		"status":      meta.Status,
+		"date":        date,
+		"approved_by": approvedBy,
+		"approved_at": approvedAt,
+	}
+
+	var pairs []string
+	for key, value := range values {
+		var escaped bytes.Buffer
+		writeEscaped(&escaped, value)
+		pairs = append(pairs, "{{"+key+"}}", escaped.String(), "{{ "+key+" }}", escaped.String())
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// normalizePlaceholders joins placeholders that Word split across runs
+func normalizePlaceholders(xmlText string) string {
+	return splitPlaceholderRe.ReplaceAllStringFunc(xmlText, func(match string) string {
+		if strings.Contains(match, "</w:p>") {
+			return match
+		}
+		return strings.ReplaceAll(tagRe.ReplaceAllString(match, ""), " ", "")
+	})
+}
+
+// replaceBlockPlaceholder replaces the whole paragraph holding placeholder with blockXML
+func replaceBlockPlaceholder(xmlText string, placeholder string, blockXML string) string {
+	idx := strings.Index(xmlText, placeholder)
+	if idx < 0 {
+		return xmlText
+	}
+
+	start := strings.LastIndex(xmlText[:idx], "<w:p>")
+	if alt := strings.LastIndex(xmlText[:idx], "<w:p "); alt > start {
+		start = alt
+	}
+	end := strings.Index(xmlText[idx:], "</w:p>")
+	if start < 0 || end < 0 {
+		return xmlText
+	}
+	end += idx + len("</w:p>")
+
+	return xmlText[:start] + blockXML + xmlText[end:]
+}
+
+// appendToBody inserts blockXML before the body-level section properties
+func appendToBody(xmlText string, blockXML string) string {
+	sect := strings.LastIndex(xmlText, "<w:sectPr")
+	if sect < 0 || sect < strings.LastIndex(xmlText, "</w:p>") || sect < strings.LastIndex(xmlText, "</w:tbl>") {
+		sect = strings.LastIndex(xmlText, "</w:body>")
+	}
+	if sect < 0 {
+		return xmlText
+	}
+	return xmlText[:sect] + blockXML + xmlText[sect:]
+}
+
+func injectMissingStyles(stylesXML string) string {
+	var missing strings.Builder
+	for _, style := range docxStyles {
+		if style.ID == "Normal" || strings.Contains(stylesXML, `w:styleId="`+style.ID+`"`) {
+			continue
+		}
+		missing.WriteString(style.XML)
+	}
+	return strings.Replace(stylesXML, "</w:styles>", missing.String()+"</w:styles>", 1)
+}
+
+func injectNumbering(numberingXML string, abstract string, nums string) string {
+	// abstractNum elements must precede num elements
+	insertAt := strings.Index(numberingXML, "<w:num ")
+	if insertAt < 0 {
+		insertAt = strings.Index(numberingXML, "<w:numIdMacAtCleanup")
+	}
+	if insertAt < 0 {
+		insertAt = strings.Index(numberingXML, "</w:numbering>")
+	}
+	if insertAt < 0 {
+		return numberingXML
+	}
+	numberingXML = numberingXML[:insertAt] + abstract + numberingXML[insertAt:]
+
+	insertAt = strings.Index(numberingXML, "<w:numIdMacAtCleanup")
+	if insertAt < 0 {
+		insertAt = strings.Index(numberingXML, "</w:numbering>")
+	}
+	return numberingXML[:insertAt] + nums + numberingXML[insertAt:]
+}
+
+// addPartReference registers a new word/ part in the document relationships and content types
+func addPartReference(parts []*zipPart, target string, relType string, contentType string) []*zipPart {
+	if rels := findPart(parts, "word/_rels/document.xml.rels"); rels != nil {
+		rel := fmt.Sprintf(`<Relationship Id="rIdSrs%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/%s" Target="%s"/>`,
+			relType, relType, target)
+		rels.data = bytes.Replace(rels.data, []byte("</Relationships>"), []byte(rel+"</Relationships>"), 1)
+	}
+
+	if ct := findPart(parts, "[Content_Types].xml"); ct != nil {
+		override := fmt.Sprintf(`<Override PartName="/word/%s" ContentType="%s"/>`, target, contentType)
+		ct.data = bytes.Replace(ct.data, []byte("</Types>"), []byte(override+"</Types>"), 1)
+	}
+
+	return parts
+}
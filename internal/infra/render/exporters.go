@@ -0,0 +1,128 @@
+package render
+
+import (
+	"io"
+	"srs-automation/internal/core/domain"
+)
+
+// DocxExporter implements ports.Exporter and ports.TemplateValidator
+type DocxExporter struct{}
+
+func NewDocxExporter() *DocxExporter {
+	return &DocxExporter{}
+}
+
+func (e *DocxExporter) Format() string {
+	return "docx"
+}
+
+func (e *DocxExporter) MediaType() string {
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
+
+// Export writes a standalone .docx, or fills the document's corporate template when one is set
+func (e *DocxExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	if len(doc.Template) > 0 {
+		return WriteDocxFromTemplate(w, doc.Template, doc.Meta, documentContent(doc))
+	}
+	return WriteDocx(w, doc.Meta, documentContent(doc))
+}
+
+func (e *DocxExporter) ValidateTemplate(data []byte) error {
+	return ValidateDocxTemplate(data)
+}
+
+// PDFExporter implements ports.Exporter
+type PDFExporter struct{}
+
+func NewPDFExporter() *PDFExporter {
+	return &PDFExporter{}
+}
+
+func (e *PDFExporter) Format() string {
+	return "pdf"
+}
+
+func (e *PDFExporter) MediaType() string {
+	return "application/pdf"
+}
+
+func (e *PDFExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	return WritePDF(w, doc.Meta, documentContent(doc))
+}
+
+// MarkdownExporter implements ports.Exporter
+type MarkdownExporter struct{}
+
+func NewMarkdownExporter() *MarkdownExporter {
+	return &MarkdownExporter{}
+}
+
+func (e *MarkdownExporter) Format() string {
+	return "md"
+}
+
+func (e *MarkdownExporter) MediaType() string {
+	return "text/markdown"
+}
+
+func (e *MarkdownExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	return WriteMarkdown(w, doc.Meta, appendDiagrams(documentContent(doc), doc.Diagrams))
+}
+
+// HTMLExporter implements ports.Exporter
+type HTMLExporter struct{}
+
+func NewHTMLExporter() *HTMLExporter {
+	return &HTMLExporter{}
+}
+
+func (e *HTMLExporter) Format() string {
+	return "html"
+}
+
+func (e *HTMLExporter) MediaType() string {
+	return "text/html"
+}
+
+func (e *HTMLExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	return WriteHTML(w, doc.Meta, appendDiagrams(documentContent(doc), doc.Diagrams))
+}
+
+// JSONExporter implements ports.Exporter
+type JSONExporter struct{}
+
+func NewJSONExporter() *JSONExporter {
+	return &JSONExporter{}
+}
+
+func (e *JSONExporter) Format() string {
+	return "json"
+}
+
+func (e *JSONExporter) MediaType() string {
+	return "application/json"
+}
+
+func (e *JSONExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	return WriteJSON(w, doc)
+}
+
+// ReqIFExporter implements ports.Exporter
+type ReqIFExporter struct{}
+
+func NewReqIFExporter() *ReqIFExporter {
+	return &ReqIFExporter{}
+}
+
+func (e *ReqIFExporter) Format() string {
+	return "reqif"
+}
+
+func (e *ReqIFExporter) MediaType() string {
+	return "application/xml"
+}
+
+func (e *ReqIFExporter) Export(w io.Writer, doc *domain.ExportDocument) error {
+	return WriteReqIF(w, doc)
+}
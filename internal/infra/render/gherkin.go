@@ -0,0 +1,88 @@
+package render
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"srs-automation/internal/core/domain"
+	"strings"
+)
+
+// GherkinWriter implements ports.FeatureWriter
+type GherkinWriter struct{}
+
+func NewGherkinWriter() *GherkinWriter {
+	return &GherkinWriter{}
+}
+
+// WriteFeature writes a user story as a Gherkin feature: the story narrative
+// becomes the feature description and each scenario a Scenario block. The
+// source requirement codes are added as tags for traceability.
+func (g *GherkinWriter) WriteFeature(w io.Writer, story domain.UserStory) error {
+	bw := bufio.NewWriter(w)
+
+	tags := []string{fmt.Sprintf("@SRS-%d", story.SRSID)}
+	for _, code := range story.RequirementCodes {
+		tags = append(tags, "@"+code)
+	}
+	fmt.Fprintln(bw, strings.Join(tags, " "))
+	fmt.Fprintf(bw, "Feature: %s\n", gherkinLine(story.Title))
+	if story.Role != "" {
+		fmt.Fprintf(bw, "  As a %s\n", gherkinLine(story.Role))
+	}
+	if story.Goal != "" {
+		fmt.Fprintf(bw, "  I want %s\n", gherkinLine(story.Goal))
+	}
+	if story.Benefit != "" {
+		fmt.Fprintf(bw, "  So that %s\n", gherkinLine(story.Benefit))
+	}
+
+	for _, scenario := range story.Scenarios {
+		fmt.Fprintf(bw, "\n  Scenario: %s\n", gherkinLine(scenario.Name))
+		writeGherkinSteps(bw, "Given", scenario.Given)
+		writeGherkinSteps(bw, "When", scenario.When)
+		writeGherkinSteps(bw, "Then", scenario.Then)
+	}
+
+	return bw.Flush()
+}
+
+// WriteArchive writes one .feature file per story into a zip archive
+func (g *GherkinWriter) WriteArchive(w io.Writer, stories []domain.UserStory) error {
+	zw := zip.NewWriter(w)
+	used := map[string]int{}
+
+	for _, story := range stories {
+		name := story.FeatureFilename()
+		if n := used[name]; n > 0 {
+			name = fmt.Sprintf("%s-%d.feature", strings.TrimSuffix(name, ".feature"), n+1)
+		}
+		used[story.FeatureFilename()]++
+
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if err := g.WriteFeature(fw, story); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeGherkinSteps writes the first step with its keyword and the rest with "And"
+func writeGherkinSteps(w *bufio.Writer, keyword string, steps []string) {
+	for i, step := range steps {
+		if i > 0 {
+			keyword = "And"
+		}
+		fmt.Fprintf(w, "    %s %s\n", keyword, gherkinLine(step))
+	}
+}
+
+// gherkinLine keeps a value on a single line, as every Gherkin step must be
+func gherkinLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
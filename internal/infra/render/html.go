@@ -0,0 +1,246 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"srs-automation/internal/core/domain"
+	"srs-automation/pkg/markdown"
+	"strings"
+)
+
+const htmlTOCDepth = 3
+
+const htmlStyles = `body{font-family:Calibri,Arial,sans-serif;font-size:11pt;line-height:1.5;color:#222;max-width:860px;margin:0 auto;padding:32px}
+.cover{border-bottom:2px solid #1F3864;margin-bottom:32px;padding-bottom:24px}
+.cover h1{color:#1F3864;font-size:28pt;margin:0}
+.cover .subtitle{color:#2F5496;font-size:14pt;margin:4px 0 16px}
+.cover p{margin:2px 0}
+h1,h2,h3,h4,h5,h6{color:#1F3864}
+table{border-collapse:collapse;width:100%;margin:12px 0}
+th,td{border:1px solid #A6A6A6;padding:4px 8px;text-align:left;vertical-align:top;font-size:10pt}
+th{background:#D9E2F3}
+pre{background:#F2F2F2;padding:8px;overflow-x:auto}
+code{font-family:Consolas,monospace;background:#F2F2F2;padding:0 2px}
+.req-id{font-weight:bold;color:#C55A11}
+nav.toc ol{list-style:none;padding-left:0}
+.watermark{position:fixed;top:40%;left:0;right:0;text-align:center;font-size:120pt;font-weight:bold;color:rgba(192,0,0,0.08);transform:rotate(-35deg);pointer-events:none;z-index:-1}`
+
+type htmlHeading struct {
+	level int
+	id    string
+	text  string
+}
+
+// WriteHTML writes a standalone HTML page (inline styles, no external assets)
+// with cover, approval table, table of contents and the rendered content
+func WriteHTML(w io.Writer, meta domain.ExportMeta, content string) error {
+	blocks := markdown.Parse(content)
+
+	var headings []htmlHeading
+	for i, block := range blocks {
+		if block.Kind == markdown.BlockHeading {
+			headings = append(headings, htmlHeading{
+				level: block.Level,
+				id:    fmt.Sprintf("sec-%d", i+1),
+				text:  markdown.PlainText(block.Text),
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("<!DOCTYPE html>\n<html lang=\"id\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&buf, "<title>%s</title>\n", html.EscapeString(meta.Title))
+	fmt.Fprintf(&buf, "<style>\n%s\n</style>\n</head>\n<body>\n", htmlStyles)
+
+	if strings.EqualFold(meta.Status, "DRAFT") {
+		buf.WriteString("<div class=\"watermark\">DRAFT</div>\n")
+	}
+
+	writeHTMLCover(&buf, meta)
+	writeHTMLTOC(&buf, headings)
+
+	buf.WriteString("<main>\n")
+	headingIndex := 0
+	for _, block := range blocks {
+		switch block.Kind {
+		case markdown.BlockHeading:
+			h := headings[headingIndex]
+			headingIndex++
+			level := block.Level
+			if level > 6 {
+				level = 6
+			}
+			fmt.Fprintf(&buf, "<h%d id=\"%s\">", level, h.id)
+			writeHTMLInline(&buf, block.Text)
+			fmt.Fprintf(&buf, "</h%d>\n", level)
+
+		case markdown.BlockParagraph:
+			buf.WriteString("<p>")
+			writeHTMLInline(&buf, block.Text)
+			buf.WriteString("</p>\n")
+
+		case markdown.BlockList:
+			writeHTMLList(&buf, block.Items)
+
+		case markdown.BlockTable:
+			writeHTMLTable(&buf, block.Header, block.Rows)
+
+		case markdown.BlockCode:
+			if block.Lang != "" {
+				fmt.Fprintf(&buf, "<pre><code class=\"language-%s\">", html.EscapeString(block.Lang))
+			} else {
+				buf.WriteString("<pre><code>")
+			}
+			buf.WriteString(html.EscapeString(block.Text))
+			buf.WriteString("</code></pre>\n")
+
+		case markdown.BlockRule:
+			buf.WriteString("<hr>\n")
+		}
+	}
+	buf.WriteString("</main>\n</body>\n</html>\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeHTMLCover(buf *bytes.Buffer, meta domain.ExportMeta) {
+	buf.WriteString("<header class=\"cover\">\n")
+	fmt.Fprintf(buf, "<h1>%s</h1>\n", html.EscapeString(meta.Title))
+	if meta.Subtitle != "" {
+		fmt.Fprintf(buf, "<p class=\"subtitle\">%s</p>\n", html.EscapeString(meta.Subtitle))
+	}
+
+	if meta.Version != "" {
+		fmt.Fprintf(buf, "<p>Versi: %s</p>\n", html.EscapeString(meta.Version))
+	}
+	if meta.Status != "" {
+		fmt.Fprintf(buf, "<p>Status: %s</p>\n", html.EscapeString(meta.Status))
+	}
+	if meta.Author != "" {
+		fmt.Fprintf(buf, "<p>Penyusun: %s</p>\n", html.EscapeString(meta.Author))
+	}
+	if !meta.Date.IsZero() {
+		fmt.Fprintf(buf, "<p>Tanggal: %s</p>\n", meta.Date.Format("02 January 2006"))
+	}
+
+	if len(meta.Approvals) > 0 {
+		rows := make([][]string, 0, len(meta.Approvals))
+		for _, a := range meta.Approvals {
+			date := "-"
+			if a.Date != nil {
+				date = a.Date.Format("02 January 2006")
+			}
+			name := a.Name
+			if name == "" {
+				name = "-"
+			}
+			rows = append(rows, []string{escapeMarkdown(a.Role), escapeMarkdown(name), escapeMarkdown(a.Status), date})
+		}
+		writeHTMLTable(buf, []string{"Peran", "Nama", "Status", "Tanggal"}, rows)
+	}
+	buf.WriteString("</header>\n")
+}
+
+func writeHTMLTOC(buf *bytes.Buffer, headings []htmlHeading) {
+	if len(headings) == 0 {
+		return
+	}
+
+	buf.WriteString("<nav class=\"toc\">\n<h2>Daftar Isi</h2>\n<ol>\n")
+	for _, h := range headings {
+		if h.level > htmlTOCDepth {
+			continue
+		}
+		fmt.Fprintf(buf, "<li style=\"margin-left:%dem\"><a href=\"#%s\">%s</a></li>\n",
+			h.level-1, h.id, html.EscapeString(h.text))
+	}
+	buf.WriteString("</ol>\n</nav>\n")
+}
+
+// writeHTMLList writes list items as nested <ul>/<ol> elements following their depth
+func writeHTMLList(buf *bytes.Buffer, items []markdown.ListItem) {
+	var open []string
+
+	for i, item := range items {
+		tag := "ul"
+		if item.Ordered {
+			tag = "ol"
+		}
+
+		for len(open) > item.Depth+1 {
+			fmt.Fprintf(buf, "</li></%s>\n", open[len(open)-1])
+			open = open[:len(open)-1]
+		}
+		switch {
+		case len(open) < item.Depth+1:
+			for len(open) < item.Depth+1 {
+				fmt.Fprintf(buf, "<%s>", tag)
+				open = append(open, tag)
+			}
+		case i > 0:
+			buf.WriteString("</li>")
+		}
+
+		buf.WriteString("<li>")
+		writeHTMLInline(buf, item.Text)
+	}
+
+	for len(open) > 0 {
+		fmt.Fprintf(buf, "</li></%s>\n", open[len(open)-1])
+		open = open[:len(open)-1]
+	}
+}
+
+func writeHTMLTable(buf *bytes.Buffer, header []string, rows [][]string) {
+	cols := len(header)
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+
+	buf.WriteString("<table>\n<thead><tr>")
+	for i := 0; i < cols; i++ {
+		buf.WriteString("<th>")
+		if i < len(header) {
+			writeHTMLInline(buf, header[i])
+		}
+		buf.WriteString("</th>")
+	}
+	buf.WriteString("</tr></thead>\n<tbody>\n")
+
+	for _, row := range rows {
+		buf.WriteString("<tr>")
+		for i := 0; i < cols; i++ {
+			buf.WriteString("<td>")
+			if i < len(row) {
+				writeHTMLInline(buf, row[i])
+			}
+			buf.WriteString("</td>")
+		}
+		buf.WriteString("</tr>\n")
+	}
+	buf.WriteString("</tbody>\n</table>\n")
+}
+
+func writeHTMLInline(buf *bytes.Buffer, text string) {
+	for _, span := range markdown.ParseInline(text) {
+		escaped := html.EscapeString(span.Text)
+		switch {
+		case span.RequirementID:
+			escaped = `<span class="req-id">` + escaped + `</span>`
+		case span.Code:
+			escaped = "<code>" + escaped + "</code>"
+		}
+		if span.Italic {
+			escaped = "<em>" + escaped + "</em>"
+		}
+		if span.Bold {
+			escaped = "<strong>" + escaped + "</strong>"
+		}
+		buf.WriteString(escaped)
+	}
+}
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"srs-automation/internal/core/domain"
+	"strconv"
+	"strings"
+)
+
+// JiraCSVExporter implements ports.IssueExporter for the Jira CSV importer
+type JiraCSVExporter struct{}
+
+func NewJiraCSVExporter() *JiraCSVExporter {
+	return &JiraCSVExporter{}
+}
+
+func (e *JiraCSVExporter) Format() string {
+	return "jira"
+}
+
+func (e *JiraCSVExporter) Extension() string {
+	return "csv"
+}
+
+func (e *JiraCSVExporter) MediaType() string {
+	return "text/csv"
+}
+
+// Export writes one Epic row per SRS section followed by the stories, linked
+// through the importer's "Issue Id"/"Parent Id" columns. Labels get one
+// column each, as the importer expects for multi-value fields.
+func (e *JiraCSVExporter) Export(w io.Writer, issues []domain.TrackerIssue) error {
+	labelColumns := 1
+	for _, issue := range issues {
+		if len(issue.Labels) > labelColumns {
+			labelColumns = len(issue.Labels)
+		}
+	}
+
+	header := []string{"Issue Id", "Parent Id", "Issue Type", "Summary", "Description", "Acceptance Criteria", "Priority"}
+	for i := 0; i < labelColumns; i++ {
+		header = append(header, "Labels")
+	}
+	header = append(header, "Epic Name", "Requirement ID")
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	row := func(id int, parent int, issueType, summary, description, criteria, priority string, labels []string, epicName, code string) error {
+		parentID := ""
+		if parent > 0 {
+			parentID = strconv.Itoa(parent)
+		}
+		record := []string{strconv.Itoa(id), parentID, issueType, summary, description, criteria, priority}
+		for i := 0; i < labelColumns; i++ {
+			label := ""
+			if i < len(labels) {
+				label = labels[i]
+			}
+			record = append(record, label)
+		}
+		record = append(record, epicName, code)
+		return cw.Write(record)
+	}
+
+	epicIDs := map[string]int{}
+	nextID := 1
+	for _, issue := range issues {
+		if issue.Epic == "" || epicIDs[issue.Epic] > 0 {
+			continue
+		}
+		epicIDs[issue.Epic] = nextID
+		if err := row(nextID, 0, "Epic", issue.Epic, "", "", "", nil, issue.Epic, ""); err != nil {
+			return err
+		}
+		nextID++
+	}
+
+	for _, issue := range issues {
+		criteria := ""
+		if len(issue.AcceptanceCriteria) > 0 {
+			criteria = "* " + strings.Join(issue.AcceptanceCriteria, "\n* ")
+		}
+		if err := row(nextID, epicIDs[issue.Epic], "Story", issue.Summary, issue.JiraDescription(),
+			criteria, issue.Priority, issue.Labels, "", issue.RequirementCode); err != nil {
+			return err
+		}
+		nextID++
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// GitHubIssuesExporter implements ports.IssueExporter as a JSON array of
+// request bodies for the GitHub "create an issue" API
+type GitHubIssuesExporter struct{}
+
+func NewGitHubIssuesExporter() *GitHubIssuesExporter {
+	return &GitHubIssuesExporter{}
+}
+
+func (e *GitHubIssuesExporter) Format() string {
+	return "github"
+}
+
+func (e *GitHubIssuesExporter) Extension() string {
+	return "json"
+}
+
+func (e *GitHubIssuesExporter) MediaType() string {
+	return "application/json"
+}
+
+type githubIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+}
+
+func (e *GitHubIssuesExporter) Export(w io.Writer, issues []domain.TrackerIssue) error {
+	out := make([]githubIssue, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, githubIssue{
+			Title:  issue.Summary,
+			Body:   issue.MarkdownBody(),
+			Labels: issue.GitHubLabels(),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
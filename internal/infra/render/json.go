@@ -0,0 +1,114 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+	"srs-automation/internal/core/domain"
+	"time"
+)
+
+// jsonSchemaVersion is bumped whenever the JSON export layout changes
+const jsonSchemaVersion = "1.1"
+
+type jsonExport struct {
+	SchemaVersion string              `json:"schema_version"`
+	SRSID         uint                `json:"srs_id"`
+	Revision      int                 `json:"revision,omitempty"`
+	Title         string              `json:"title"`
+	Subtitle      string              `json:"subtitle,omitempty"`
+	Version       string              `json:"version"`
+	Author        string              `json:"author,omitempty"`
+	Status        string              `json:"status"`
+	Date          *time.Time          `json:"date,omitempty"`
+	Approvals     []jsonApproval      `json:"approvals"`
+	Sections      []domain.SRSSection `json:"sections"`
+	Requirements  []jsonRequirement   `json:"requirements"`
+	// Added in 1.1
+	DataDictionary []jsonDataEntity `json:"data_dictionary"`
+}
+
+type jsonRequirement struct {
+	Code               string   `json:"code"`
+	Type               string   `json:"type"`
+	Statement          string   `json:"statement"`
+	AcceptanceCriteria []string `json:"acceptance_criteria,omitempty"`
+	Priority           string   `json:"priority,omitempty"`
+	Section            string   `json:"section"`
+}
+
+type jsonDataEntity struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Sources     []string               `json:"sources,omitempty"`
+	Attributes  []domain.DataAttribute `json:"attributes"`
+}
+
+type jsonApproval struct {
+	Role   string     `json:"role"`
+	Name   string     `json:"name"`
+	Status string     `json:"status"`
+	Date   *time.Time `json:"date,omitempty"`
+}
+
+// WriteJSON writes the canonical JSON form of an SRS: document control fields,
+// the section tree, the identified requirements and the data dictionary. Field
+// order is fixed and empty collections are written as [] so exports diff cleanly.
+func WriteJSON(w io.Writer, doc *domain.ExportDocument) error {
+	out := jsonExport{
+		SchemaVersion:  jsonSchemaVersion,
+		SRSID:          doc.SRSID,
+		Revision:       doc.Revision,
+		Title:          doc.Meta.Title,
+		Subtitle:       doc.Meta.Subtitle,
+		Version:        doc.Meta.Version,
+		Author:         doc.Meta.Author,
+		Status:         doc.Meta.Status,
+		Approvals:      []jsonApproval{},
+		Sections:       doc.Sections,
+		Requirements:   []jsonRequirement{},
+		DataDictionary: []jsonDataEntity{},
+	}
+	if !doc.Meta.Date.IsZero() {
+		date := doc.Meta.Date.UTC()
+		out.Date = &date
+	}
+	for _, a := range doc.Meta.Approvals {
+		approval := jsonApproval{Role: a.Role, Name: a.Name, Status: a.Status}
+		if a.Date != nil {
+			date := a.Date.UTC()
+			approval.Date = &date
+		}
+		out.Approvals = append(out.Approvals, approval)
+	}
+	if out.Sections == nil {
+		out.Sections = []domain.SRSSection{}
+	}
+	for _, req := range doc.Requirements {
+		out.Requirements = append(out.Requirements, jsonRequirement{
+			Code:               req.Code,
+			Type:               string(req.Type),
+			Statement:          req.Statement,
+			AcceptanceCriteria: req.AcceptanceCriteria,
+			Priority:           req.Priority,
+			Section:            req.Section,
+		})
+	}
+
+	for _, entity := range doc.DataDictionary {
+		attributes := entity.Attributes
+		if attributes == nil {
+			attributes = []domain.DataAttribute{}
+		}
+		out.DataDictionary = append(out.DataDictionary, jsonDataEntity{
+			Name:        entity.Name,
+			Description: entity.Description,
+			Sources:     entity.Sources,
+			Attributes:  attributes,
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"srs-automation/internal/core/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteMarkdown writes the SRS content as Markdown with a YAML front matter
+// block carrying the document control fields
+func WriteMarkdown(w io.Writer, meta domain.ExportMeta, content string) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("---\n")
+	writeFrontMatter(bw, "title", meta.Title)
+	writeFrontMatter(bw, "subtitle", meta.Subtitle)
+	writeFrontMatter(bw, "version", meta.Version)
+	writeFrontMatter(bw, "author", meta.Author)
+	writeFrontMatter(bw, "status", meta.Status)
+	if !meta.Date.IsZero() {
+		writeFrontMatter(bw, "date", meta.Date.Format(time.RFC3339))
+	}
+	if len(meta.Approvals) > 0 {
+		bw.WriteString("approvals:\n")
+		for _, a := range meta.Approvals {
+			fmt.Fprintf(bw, "  - role: %s\n", strconv.Quote(a.Role))
+			fmt.Fprintf(bw, "    name: %s\n", strconv.Quote(a.Name))
+			fmt.Fprintf(bw, "    status: %s\n", strconv.Quote(a.Status))
+			if a.Date != nil {
+				fmt.Fprintf(bw, "    date: %s\n", strconv.Quote(a.Date.Format(time.RFC3339)))
+			}
+		}
+	}
+	bw.WriteString("---\n\n")
+
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if !strings.HasPrefix(content, "# ") && meta.Title != "" {
+		fmt.Fprintf(bw, "# %s\n\n", meta.Title)
+	}
+	bw.WriteString(content)
+	bw.WriteString("\n")
+
+	return bw.Flush()
+}
+
+// writeFrontMatter writes a double-quoted YAML scalar, skipping empty values
+func writeFrontMatter(w *bufio.Writer, key string, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(w, "%s: %s\n", key, strconv.Quote(value))
+}
@@ -0,0 +1,346 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"gopkg.in/yaml.v3"
+)
+
+const openAPIVersion = "3.1.0"
+
+// errorSchemaName is the error body every endpoint of this kind of API returns
+const errorSchemaName = "Error"
+
+var pathParamRe = regexp.MustCompile(`\{([^}]+)\}`)
+
+// openAPIPrimitives maps the property types of the API design to JSON Schema type and format
+var openAPIPrimitives = map[string][2]string{
+	"string":    {"string", ""},
+	"text":      {"string", ""},
+	"uuid":      {"string", "uuid"},
+	"email":     {"string", "email"},
+	"date":      {"string", "date"},
+	"date-time": {"string", "date-time"},
+	"datetime":  {"string", "date-time"},
+	"timestamp": {"string", "date-time"},
+	"integer":   {"integer", ""},
+	"int":       {"integer", ""},
+	"bigint":    {"integer", "int64"},
+	"number":    {"number", ""},
+	"float":     {"number", "float"},
+	"decimal":   {"number", "double"},
+	"double":    {"number", "double"},
+	"boolean":   {"boolean", ""},
+	"bool":      {"boolean", ""},
+	"object":    {"object", ""},
+}
+
+// OpenAPIRenderer implements ports.APISpecRenderer
+type OpenAPIRenderer struct{}
+
+func NewOpenAPIRenderer() *OpenAPIRenderer {
+	return &OpenAPIRenderer{}
+}
+
+func (r *OpenAPIRenderer) MediaType() string {
+	return "application/yaml"
+}
+
+// Render builds an OpenAPI 3.1 YAML document and validates it by parsing it
+// back with libopenapi, which also resolves every $ref. Operations carry their
+// requirement codes in the x-requirements extension.
+func (r *OpenAPIRenderer) Render(spec *domain.APISpec) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(buildOpenAPI(spec)); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := validateOpenAPI(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func validateOpenAPI(data []byte) error {
+	doc, err := libopenapi.NewDocument(data)
+	if err != nil {
+		return err
+	}
+	if doc.GetVersion() != openAPIVersion {
+		return fmt.Errorf("unexpected OpenAPI version %q", doc.GetVersion())
+	}
+	if _, errs := doc.BuildV3Model(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+// orderedMap is a YAML mapping that keeps insertion order, so paths and
+// properties appear in the order of the API design
+type orderedMap []mapEntry
+
+type mapEntry struct {
+	key   string
+	value interface{}
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	for i := range *m {
+		if (*m)[i].key == key {
+			(*m)[i].value = value
+			return
+		}
+	}
+	*m = append(*m, mapEntry{key: key, value: value})
+}
+
+func (m *orderedMap) get(key string) interface{} {
+	for _, e := range *m {
+		if e.key == key {
+			return e.value
+		}
+	}
+	return nil
+}
+
+func (m orderedMap) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, e := range m {
+		var value yaml.Node
+		if err := value.Encode(e.value); err != nil {
+			return nil, err
+		}
+		// Keys are always strings; status codes must not become YAML integers
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.key}, &value)
+	}
+	return node, nil
+}
+
+func buildOpenAPI(spec *domain.APISpec) orderedMap {
+	var doc orderedMap
+	doc.set("openapi", openAPIVersion)
+	doc.set("info", orderedMap{
+		{"title", spec.Title},
+		{"version", spec.Version},
+		{"description", fmt.Sprintf("Draft dihasilkan otomatis dari SRS #%d. Setiap operasi mencantumkan requirement sumbernya di `x-requirements`.", spec.SRSID)},
+	})
+
+	var tags []orderedMap
+	seenTags := map[string]bool{}
+	paths := orderedMap{}
+	for _, op := range spec.Operations {
+		if op.Resource != "" && !seenTags[op.Resource] {
+			seenTags[op.Resource] = true
+			tags = append(tags, orderedMap{{"name", op.Resource}})
+		}
+
+		item, _ := paths.get(op.Path).(orderedMap)
+		item.set(strings.ToLower(op.Method), buildOperation(op))
+		paths.set(op.Path, item)
+	}
+	if len(tags) > 0 {
+		doc.set("tags", tags)
+	}
+	doc.set("paths", paths)
+
+	schemas := orderedMap{}
+	for _, s := range spec.Schemas {
+		schemas.set(s.Name, buildSchema(s))
+	}
+	// Schemas the design refers to without defining them become open objects,
+	// so every $ref resolves
+	for _, name := range referencedSchemas(spec) {
+		if schemas.get(name) == nil {
+			schemas.set(name, orderedMap{
+				{"type", "object"},
+				{"description", "Belum didefinisikan di desain API"},
+			})
+		}
+	}
+	schemas.set(errorSchemaName, orderedMap{
+		{"type", "object"},
+		{"required", []string{"error"}},
+		{"properties", orderedMap{{"error", orderedMap{{"type", "string"}}}}},
+	})
+	doc.set("components", orderedMap{{"schemas", schemas}})
+
+	return doc
+}
+
+func buildOperation(op domain.APIOperation) orderedMap {
+	var o orderedMap
+	if op.Resource != "" {
+		o.set("tags", []string{op.Resource})
+	}
+	o.set("summary", op.Summary)
+
+	description := op.Description
+	if len(op.RequirementCodes) > 0 {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Requirement: " + strings.Join(op.RequirementCodes, ", ")
+	}
+	if description != "" {
+		o.set("description", description)
+	}
+	o.set("operationId", op.OperationID)
+	o.set("x-requirements", op.RequirementCodes)
+
+	params := pathParamRe.FindAllStringSubmatch(op.Path, -1)
+	if len(params) > 0 {
+		var parameters []orderedMap
+		for _, p := range params {
+			parameters = append(parameters, orderedMap{
+				{"name", p[1]},
+				{"in", "path"},
+				{"required", true},
+				{"schema", orderedMap{{"type", "string"}}},
+			})
+		}
+		o.set("parameters", parameters)
+	}
+
+	if op.RequestSchema != "" {
+		o.set("requestBody", orderedMap{
+			{"required", true},
+			{"content", jsonContent(schemaRef(op.RequestSchema))},
+		})
+	}
+
+	responses := orderedMap{}
+	status, description := successStatus(op.Method)
+	success := orderedMap{{"description", description}}
+	if op.ResponseSchema != "" {
+		schema := schemaRef(op.ResponseSchema)
+		if op.ResponseIsList {
+			schema = orderedMap{{"type", "array"}, {"items", schema}}
+		}
+		if status == "204" {
+			status, success = "200", orderedMap{{"description", "OK"}}
+		}
+		success.set("content", jsonContent(orderedMap{
+			{"type", "object"},
+			{"properties", orderedMap{{"data", schema}}},
+		}))
+	}
+	responses.set(status, success)
+	if op.RequestSchema != "" {
+		responses.set("400", errorResponse("Request tidak valid"))
+	}
+	if len(params) > 0 {
+		responses.set("404", errorResponse("Data tidak ditemukan"))
+	}
+	responses.set("500", errorResponse("Kesalahan server"))
+	o.set("responses", responses)
+
+	return o
+}
+
+// successStatus follows the status codes the SRS API itself uses
+func successStatus(method string) (string, string) {
+	switch strings.ToUpper(method) {
+	case http.MethodPost:
+		return "201", "Created"
+	case http.MethodDelete:
+		return "204", "No Content"
+	default:
+		return "200", "OK"
+	}
+}
+
+func buildSchema(s domain.APISchema) orderedMap {
+	o := orderedMap{{"type", "object"}}
+	if s.Description != "" {
+		o.set("description", s.Description)
+	}
+
+	var required []string
+	properties := orderedMap{}
+	for _, p := range s.Properties {
+		prop := propertySchema(p.Type)
+		if p.Description != "" {
+			prop.set("description", p.Description)
+		}
+		properties.set(p.Name, prop)
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	if len(required) > 0 {
+		o.set("required", required)
+	}
+	o.set("properties", properties)
+	return o
+}
+
+// propertySchema maps a design type to JSON Schema; unknown types are schema names
+func propertySchema(t string) orderedMap {
+	t = strings.TrimSpace(t)
+	if strings.HasSuffix(t, "[]") {
+		return orderedMap{{"type", "array"}, {"items", propertySchema(strings.TrimSuffix(t, "[]"))}}
+	}
+	if t == "" {
+		t = "string"
+	}
+	if primitive, ok := openAPIPrimitives[strings.ToLower(t)]; ok {
+		o := orderedMap{{"type", primitive[0]}}
+		if primitive[1] != "" {
+			o.set("format", primitive[1])
+		}
+		return o
+	}
+	return schemaRef(t)
+}
+
+// referencedSchemas lists the schema names used by operations and properties
+func referencedSchemas(spec *domain.APISpec) []string {
+	var names []string
+	add := func(t string) {
+		t = strings.TrimSuffix(strings.TrimSpace(t), "[]")
+		if t == "" {
+			return
+		}
+		if _, ok := openAPIPrimitives[strings.ToLower(t)]; ok {
+			return
+		}
+		names = append(names, t)
+	}
+	for _, op := range spec.Operations {
+		add(op.RequestSchema)
+		add(op.ResponseSchema)
+	}
+	for _, s := range spec.Schemas {
+		for _, p := range s.Properties {
+			add(p.Type)
+		}
+	}
+	return names
+}
+
+func schemaRef(name string) orderedMap {
+	return orderedMap{{"$ref", "#/components/schemas/" + name}}
+}
+
+func jsonContent(schema orderedMap) orderedMap {
+	return orderedMap{{"application/json", orderedMap{{"schema", schema}}}}
+}
+
+func errorResponse(description string) orderedMap {
+	return orderedMap{
+		{"description", description},
+		{"content", jsonContent(schemaRef(errorSchemaName))},
+	}
+}
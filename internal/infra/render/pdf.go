@@ -0,0 +1,481 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"srs-automation/internal/core/domain"
+	"srs-automation/pkg/markdown"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin     = 20.0
+	pdfLineHeight = 5.5
+	pdfFontSize   = 10.5
+	pdfFont       = "Helvetica"
+	pdfMonoFont   = "Courier"
+	// Headings up to this level are listed in the table of contents
+	pdfTOCDepth = 3
+)
+
+type pdfHeading struct {
+	level int
+	text  string
+	link  int
+	page  int
+}
+
+// pdfWriter lays out one rendering pass of an SRS
+type pdfWriter struct {
+	pdf      *fpdf.Fpdf
+	tr       func(string) string
+	meta     domain.ExportMeta
+	headings []*pdfHeading
+	next     int
+}
+
+// WritePDF renders Markdown content to a PDF with cover page, table of
+// contents, page numbers and a DRAFT watermark when the status is DRAFT.
+//
+// The document is laid out twice: the first pass finds the page of every
+// heading so the second pass can print them in the table of contents.
+func WritePDF(w io.Writer, meta domain.ExportMeta, content string) error {
+	blocks := markdown.Parse(content)
+
+	var headings []*pdfHeading
+	for _, block := range blocks {
+		if block.Kind == markdown.BlockHeading && block.Level <= pdfTOCDepth {
+			headings = append(headings, &pdfHeading{level: block.Level, text: markdown.PlainText(block.Text)})
+		}
+	}
+
+	first := newPDFWriter(meta, headings)
+	first.render(blocks)
+	if err := first.pdf.Error(); err != nil {
+		return fmt.Errorf("failed to render pdf: %w", err)
+	}
+
+	second := newPDFWriter(meta, headings)
+	second.render(blocks)
+	return second.pdf.Output(w)
+}
+
+func newPDFWriter(meta domain.ExportMeta, headings []*pdfHeading) *pdfWriter {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin+5, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AliasNbPages("{nb}")
+	pdf.SetTitle(meta.Title, true)
+	pdf.SetAuthor(meta.Author, true)
+	pdf.SetCreator("SRS Automation", true)
+
+	p := &pdfWriter{
+		pdf:      pdf,
+		tr:       pdf.UnicodeTranslatorFromDescriptor(""),
+		meta:     meta,
+		headings: headings,
+	}
+	pdf.SetHeaderFunc(p.header)
+	pdf.SetFooterFunc(p.footer)
+	return p
+}
+
+func (p *pdfWriter) render(blocks []markdown.Block) {
+	p.writeCover()
+	p.writeTOC()
+	p.pdf.AddPage()
+	p.writeBlocks(blocks)
+}
+
+func (p *pdfWriter) header() {
+	pageW, pageH := p.pdf.GetPageSize()
+
+	if strings.EqualFold(p.meta.Status, "DRAFT") {
+		p.pdf.SetAlpha(0.12, "Normal")
+		p.pdf.SetFont(pdfFont, "B", 110)
+		p.pdf.SetTextColor(128, 128, 128)
+		p.pdf.TransformBegin()
+		p.pdf.TransformRotate(45, pageW/2, pageH/2)
+		text := "DRAFT"
+		p.pdf.Text(pageW/2-p.pdf.GetStringWidth(text)/2, pageH/2+15, text)
+		p.pdf.TransformEnd()
+		p.pdf.SetAlpha(1, "Normal")
+	}
+
+	if p.pdf.PageNo() > 1 {
+		p.pdf.SetFont(pdfFont, "", 8)
+		p.pdf.SetTextColor(110, 110, 110)
+		p.pdf.SetXY(pdfMargin, 10)
+		p.pdf.CellFormat(0, 5, p.tr(p.meta.Title), "B", 0, "R", false, 0, "")
+	}
+
+	p.pdf.SetTextColor(0, 0, 0)
+	p.pdf.SetY(pdfMargin + 5)
+}
+
+func (p *pdfWriter) footer() {
+	if p.pdf.PageNo() == 1 {
+		return
+	}
+	p.pdf.SetY(-15)
+	p.pdf.SetFont(pdfFont, "", 8)
+	p.pdf.SetTextColor(110, 110, 110)
+	p.pdf.CellFormat(0, 5, fmt.Sprintf("Halaman %d dari {nb}", p.pdf.PageNo()), "", 0, "C", false, 0, "")
+	p.pdf.SetTextColor(0, 0, 0)
+}
+
+func (p *pdfWriter) writeCover() {
+	p.pdf.AddPage()
+	p.pdf.SetY(80)
+
+	p.pdf.SetFont(pdfFont, "B", 24)
+	p.pdf.SetTextColor(31, 56, 100)
+	p.pdf.MultiCell(0, 11, p.tr(p.meta.Title), "", "C", false)
+	p.pdf.Ln(4)
+
+	if p.meta.Subtitle != "" {
+		p.pdf.SetFont(pdfFont, "", 15)
+		p.pdf.SetTextColor(89, 89, 89)
+		p.pdf.MultiCell(0, 8, p.tr(p.meta.Subtitle), "", "C", false)
+		p.pdf.Ln(10)
+	}
+
+	p.pdf.SetFont(pdfFont, "", 11)
+	p.pdf.SetTextColor(0, 0, 0)
+	info := []struct{ label, value string }{
+		{"Versi", p.meta.Version},
+		{"Status", p.meta.Status},
+		{"Penyusun", p.meta.Author},
+	}
+	if !p.meta.Date.IsZero() {
+		info = append(info, struct{ label, value string }{"Tanggal", p.meta.Date.Format("02 January 2006")})
+	}
+	for _, line := range info {
+		if line.value != "" {
+			p.pdf.CellFormat(0, 6, p.tr(line.label+": "+line.value), "", 1, "C", false, 0, "")
+		}
+	}
+
+	if len(p.meta.Approvals) > 0 {
+		p.pdf.Ln(12)
+		rows := make([][]string, 0, len(p.meta.Approvals))
+		for _, a := range p.meta.Approvals {
+			date, name := "-", a.Name
+			if a.Date != nil {
+				date = a.Date.Format("02 January 2006")
+			}
+			if name == "" {
+				name = "-"
+			}
+			rows = append(rows, []string{a.Role, name, a.Status, date})
+		}
+		p.writeTable([]string{"Peran", "Nama", "Status", "Tanggal"}, rows, false)
+	}
+}
+
+func (p *pdfWriter) writeTOC() {
+	if len(p.headings) == 0 {
+		return
+	}
+
+	p.pdf.AddPage()
+	p.pdf.SetFont(pdfFont, "B", 16)
+	p.pdf.SetTextColor(47, 84, 150)
+	p.pdf.CellFormat(0, 10, p.tr("Daftar Isi"), "", 1, "L", false, 0, "")
+	p.pdf.Ln(2)
+	p.pdf.SetTextColor(0, 0, 0)
+
+	pageW, _ := p.pdf.GetPageSize()
+	numberW := 12.0
+	for _, h := range p.headings {
+		h.link = p.pdf.AddLink()
+
+		indent := float64(h.level-1) * 6
+		style := ""
+		if h.level == 1 {
+			style = "B"
+		}
+		p.pdf.SetFont(pdfFont, style, pdfFontSize)
+
+		text := p.tr(h.text)
+		textW := pageW - 2*pdfMargin - indent - numberW
+		for p.pdf.GetStringWidth(text) > textW-4 && len(text) > 4 {
+			text = text[:len(text)-4] + "..."
+		}
+
+		page := "..."
+		if h.page > 0 {
+			page = fmt.Sprintf("%d", h.page)
+		}
+
+		// Dotted leader between title and page number
+		dots := ""
+		dotW := p.pdf.GetStringWidth(".")
+		if gap := textW - p.pdf.GetStringWidth(text) - 2; gap > 0 {
+			dots = strings.Repeat(".", int(gap/dotW))
+		}
+
+		p.pdf.SetX(pdfMargin + indent)
+		p.pdf.CellFormat(textW, 6.5, text+" "+dots, "", 0, "L", false, h.link, "")
+		p.pdf.CellFormat(numberW, 6.5, page, "", 1, "R", false, h.link, "")
+	}
+}
+
+func (p *pdfWriter) writeBlocks(blocks []markdown.Block) {
+	for _, block := range blocks {
+		switch block.Kind {
+		case markdown.BlockHeading:
+			p.writeHeading(block.Level, block.Text)
+
+		case markdown.BlockParagraph:
+			p.writeSpans(block.Text, pdfFontSize)
+			p.pdf.Ln(pdfLineHeight + 2)
+
+		case markdown.BlockList:
+			p.writeList(block.Items)
+
+		case markdown.BlockTable:
+			rows := make([][]string, len(block.Rows))
+			for i, row := range block.Rows {
+				rows[i] = make([]string, len(row))
+				for j, cell := range row {
+					rows[i][j] = markdown.PlainText(cell)
+				}
+			}
+			header := make([]string, len(block.Header))
+			for i, cell := range block.Header {
+				header[i] = markdown.PlainText(cell)
+			}
+			p.writeTable(header, rows, true)
+
+		case markdown.BlockCode:
+			p.pdf.SetFont(pdfMonoFont, "", 9)
+			p.pdf.SetFillColor(242, 242, 242)
+			p.pdf.MultiCell(0, 4.5, p.tr(block.Text), "", "L", true)
+			p.pdf.Ln(3)
+
+		case markdown.BlockRule:
+			_, y := p.pdf.GetXY()
+			pageW, _ := p.pdf.GetPageSize()
+			p.pdf.SetDrawColor(166, 166, 166)
+			p.pdf.Line(pdfMargin, y+2, pageW-pdfMargin, y+2)
+			p.pdf.Ln(5)
+		}
+	}
+}
+
+func (p *pdfWriter) writeHeading(level int, text string) {
+	sizes := map[int]float64{1: 16, 2: 14, 3: 12}
+	size, ok := sizes[level]
+	if !ok {
+		size = 11
+	}
+
+	// Keep a heading together with the first lines that follow it
+	_, pageH := p.pdf.GetPageSize()
+	if p.pdf.GetY() > pageH-pdfMargin-25 {
+		p.pdf.AddPage()
+	}
+
+	p.pdf.Ln(3)
+	if level <= pdfTOCDepth && p.next < len(p.headings) {
+		h := p.headings[p.next]
+		p.next++
+		h.page = p.pdf.PageNo()
+		if h.link > 0 {
+			p.pdf.SetLink(h.link, -1, -1)
+		}
+		p.pdf.Bookmark(p.tr(h.text), level-1, -1)
+	}
+
+	p.pdf.SetFont(pdfFont, "B", size)
+	p.pdf.SetTextColor(47, 84, 150)
+	p.pdf.MultiCell(0, size*0.5, p.tr(markdown.PlainText(text)), "", "L", false)
+	p.pdf.SetTextColor(0, 0, 0)
+	p.pdf.Ln(2)
+}
+
+// writeSpans writes inline Markdown at the current position, wrapping at the margins
+func (p *pdfWriter) writeSpans(text string, size float64) {
+	for _, span := range markdown.ParseInline(text) {
+		style := ""
+		if span.Bold || span.RequirementID {
+			style += "B"
+		}
+		if span.Italic {
+			style += "I"
+		}
+
+		family := pdfFont
+		if span.Code {
+			family = pdfMonoFont
+		}
+		p.pdf.SetFont(family, style, size)
+
+		if span.RequirementID {
+			p.pdf.SetTextColor(197, 90, 17)
+		}
+		p.pdf.Write(pdfLineHeight, p.tr(span.Text))
+		p.pdf.SetTextColor(0, 0, 0)
+	}
+}
+
+func (p *pdfWriter) writeList(items []markdown.ListItem) {
+	counters := make([]int, 10)
+	for _, item := range items {
+		depth := item.Depth
+		if depth > 8 {
+			depth = 8
+		}
+		for k := depth + 1; k < len(counters); k++ {
+			counters[k] = 0
+		}
+
+		label := "•"
+		if item.Ordered {
+			counters[depth]++
+			label = fmt.Sprintf("%d.", counters[depth])
+		}
+
+		x := pdfMargin + 4 + float64(depth)*6
+		p.pdf.SetFont(pdfFont, "", pdfFontSize)
+		p.pdf.SetX(x)
+		p.pdf.CellFormat(6, pdfLineHeight, p.tr(label), "", 0, "L", false, 0, "")
+
+		p.pdf.SetLeftMargin(x + 6)
+		p.writeSpans(item.Text, pdfFontSize)
+		p.pdf.SetLeftMargin(pdfMargin)
+		p.pdf.Ln(pdfLineHeight + 0.5)
+	}
+	p.pdf.Ln(2)
+}
+
+const (
+	pdfCellFont = 9.0
+	pdfCellLine = 4.5
+)
+
+// writeTable draws a table with wrapped cells, repeating the header row on new pages
+func (p *pdfWriter) writeTable(header []string, rows [][]string, repeatHeader bool) {
+	cols := len(header)
+	for _, row := range rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return
+	}
+
+	pageW, pageH := p.pdf.GetPageSize()
+	widths := p.columnWidths(header, rows, cols, pageW-2*pdfMargin)
+
+	p.writeTableRow(header, widths, true)
+	for _, row := range rows {
+		lines, height := p.layoutTableRow(row, widths, false)
+		if p.pdf.GetY()+height > pageH-pdfMargin {
+			p.pdf.AddPage()
+			if repeatHeader {
+				p.writeTableRow(header, widths, true)
+			}
+		}
+		p.drawTableRow(lines, widths, height, false)
+	}
+	p.pdf.Ln(4)
+}
+
+func (p *pdfWriter) writeTableRow(cells []string, widths []float64, header bool) {
+	lines, height := p.layoutTableRow(cells, widths, header)
+	_, pageH := p.pdf.GetPageSize()
+	if p.pdf.GetY()+height > pageH-pdfMargin {
+		p.pdf.AddPage()
+	}
+	p.drawTableRow(lines, widths, height, header)
+}
+
+// layoutTableRow wraps every cell and returns the lines and the row height
+func (p *pdfWriter) layoutTableRow(cells []string, widths []float64, header bool) ([][][]byte, float64) {
+	p.setCellFont(header)
+
+	lines := make([][][]byte, len(widths))
+	height := pdfCellLine
+	for i := range widths {
+		text := ""
+		if i < len(cells) {
+			text = cells[i]
+		}
+		lines[i] = p.pdf.SplitLines([]byte(p.tr(text)), widths[i]-2)
+		if h := float64(len(lines[i])) * pdfCellLine; h > height {
+			height = h
+		}
+	}
+	return lines, height + 2
+}
+
+func (p *pdfWriter) drawTableRow(lines [][][]byte, widths []float64, height float64, header bool) {
+	p.setCellFont(header)
+	p.pdf.SetDrawColor(166, 166, 166)
+	p.pdf.SetFillColor(217, 226, 243)
+
+	x, y := pdfMargin, p.pdf.GetY()
+	for i, cellLines := range lines {
+		style := "D"
+		if header {
+			style = "FD"
+		}
+		p.pdf.Rect(x, y, widths[i], height, style)
+
+		for j, line := range cellLines {
+			p.pdf.SetXY(x+1, y+1+float64(j)*pdfCellLine)
+			p.pdf.CellFormat(widths[i]-2, pdfCellLine, string(line), "", 0, "L", false, 0, "")
+		}
+		x += widths[i]
+	}
+	p.pdf.SetXY(pdfMargin, y+height)
+}
+
+func (p *pdfWriter) setCellFont(header bool) {
+	style := ""
+	if header {
+		style = "B"
+	}
+	p.pdf.SetFont(pdfFont, style, pdfCellFont)
+}
+
+// columnWidths sizes columns by their longest content, with a minimum width each
+func (p *pdfWriter) columnWidths(header []string, rows [][]string, cols int, available float64) []float64 {
+	p.setCellFont(false)
+	natural := make([]float64, cols)
+	measure := func(cells []string) {
+		for i, cell := range cells {
+			if w := p.pdf.GetStringWidth(p.tr(cell)) + 4; i < cols && w > natural[i] {
+				natural[i] = w
+			}
+		}
+	}
+	measure(header)
+	for _, row := range rows {
+		measure(row)
+	}
+
+	total := 0.0
+	for i := range natural {
+		if natural[i] < 15 {
+			natural[i] = 15
+		}
+		total += natural[i]
+	}
+
+	widths := make([]float64, cols)
+	for i := range natural {
+		if total <= available {
+			widths[i] = natural[i] * available / total
+		} else {
+			// Shrink proportionally but give narrow columns their minimum first
+			widths[i] = 15 + (natural[i]-15)*(available-15*float64(cols))/(total-15*float64(cols))
+		}
+	}
+	return widths
+}
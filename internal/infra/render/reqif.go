@@ -0,0 +1,317 @@
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"srs-automation/internal/core/domain"
+	"strings"
+	"time"
+)
+
+// ReqIF 1.2 (OMG formal/16-07-01) namespace
+const reqifNamespace = "http://www.omg.org/spec/ReqIF/20110401/reqif.xsd"
+
+// Identifiers of the fixed datatype, spec type and attribute definitions
+const (
+	reqifStringType      = "DT-String"
+	reqifSectionType     = "SOT-Section"
+	reqifRequirementType = "SOT-Requirement"
+	reqifSpecType        = "ST-SRS"
+	reqifMaxStringLength = 32000
+	reqifToolID          = "srs-automation"
+
+	// Attribute definitions of sections
+	reqifAttrSectionTitle = "AD-Section-ChapterName"
+	reqifAttrSectionText  = "AD-Section-Text"
+
+	// Attribute definitions of requirements
+	reqifAttrForeignID = "AD-Req-ForeignID"
+	reqifAttrReqText   = "AD-Req-Text"
+	reqifAttrReqTitle  = "AD-Req-ChapterName"
+	reqifAttrReqType   = "AD-Req-Type"
+	reqifAttrPriority  = "AD-Req-Priority"
+	reqifAttrCriteria  = "AD-Req-AcceptanceCriteria"
+)
+
+// reqifAttributeOrder is the order attribute values are written in
+var reqifAttributeOrder = []string{
+	reqifAttrSectionTitle, reqifAttrSectionText,
+	reqifAttrForeignID, reqifAttrReqText, reqifAttrReqTitle, reqifAttrReqType, reqifAttrPriority, reqifAttrCriteria,
+}
+
+var reqifIdentifierRe = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+type reqifDoc struct {
+	XMLName xml.Name `xml:"REQ-IF"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	Header  struct {
+		Header reqifHeader `xml:"REQ-IF-HEADER"`
+	} `xml:"THE-HEADER"`
+	Content struct {
+		Content reqifContent `xml:"REQ-IF-CONTENT"`
+	} `xml:"CORE-CONTENT"`
+}
+
+type reqifHeader struct {
+	Identifier   string `xml:"IDENTIFIER,attr"`
+	Comment      string `xml:"COMMENT,omitempty"`
+	CreationTime string `xml:"CREATION-TIME"`
+	ToolID       string `xml:"REQ-IF-TOOL-ID"`
+	Version      string `xml:"REQ-IF-VERSION"`
+	SourceToolID string `xml:"SOURCE-TOOL-ID"`
+	Title        string `xml:"TITLE"`
+}
+
+type reqifContent struct {
+	Datatypes struct {
+		Strings []reqifDatatype `xml:"DATATYPE-DEFINITION-STRING"`
+	} `xml:"DATATYPES"`
+	SpecTypes struct {
+		ObjectTypes        []reqifSpecObjectType `xml:"SPEC-OBJECT-TYPE"`
+		SpecificationTypes []reqifIdentifiable   `xml:"SPECIFICATION-TYPE"`
+	} `xml:"SPEC-TYPES"`
+	SpecObjects struct {
+		Objects []reqifSpecObject `xml:"SPEC-OBJECT"`
+	} `xml:"SPEC-OBJECTS"`
+	SpecRelations  struct{} `xml:"SPEC-RELATIONS"`
+	Specifications struct {
+		Specifications []reqifSpecification `xml:"SPECIFICATION"`
+	} `xml:"SPECIFICATIONS"`
+}
+
+type reqifIdentifiable struct {
+	Identifier string `xml:"IDENTIFIER,attr"`
+	LastChange string `xml:"LAST-CHANGE,attr"`
+	LongName   string `xml:"LONG-NAME,attr,omitempty"`
+}
+
+type reqifDatatype struct {
+	reqifIdentifiable
+	MaxLength int `xml:"MAX-LENGTH,attr"`
+}
+
+type reqifSpecObjectType struct {
+	reqifIdentifiable
+	Attributes struct {
+		Strings []reqifAttributeDefinition `xml:"ATTRIBUTE-DEFINITION-STRING"`
+	} `xml:"SPEC-ATTRIBUTES"`
+}
+
+type reqifAttributeDefinition struct {
+	reqifIdentifiable
+	Type struct {
+		Ref string `xml:"DATATYPE-DEFINITION-STRING-REF"`
+	} `xml:"TYPE"`
+}
+
+type reqifSpecObject struct {
+	reqifIdentifiable
+	Values struct {
+		Strings []reqifStringValue `xml:"ATTRIBUTE-VALUE-STRING"`
+	} `xml:"VALUES"`
+	Type struct {
+		Ref string `xml:"SPEC-OBJECT-TYPE-REF"`
+	} `xml:"TYPE"`
+}
+
+type reqifStringValue struct {
+	Value      string `xml:"THE-VALUE,attr"`
+	Definition struct {
+		Ref string `xml:"ATTRIBUTE-DEFINITION-STRING-REF"`
+	} `xml:"DEFINITION"`
+}
+
+type reqifSpecification struct {
+	reqifIdentifiable
+	Type struct {
+		Ref string `xml:"SPECIFICATION-TYPE-REF"`
+	} `xml:"TYPE"`
+	Children *reqifChildren `xml:"CHILDREN,omitempty"`
+}
+
+type reqifChildren struct {
+	Hierarchies []reqifHierarchy `xml:"SPEC-HIERARCHY"`
+}
+
+type reqifHierarchy struct {
+	reqifIdentifiable
+	Object struct {
+		Ref string `xml:"SPEC-OBJECT-REF"`
+	} `xml:"OBJECT"`
+	Children *reqifChildren `xml:"CHILDREN,omitempty"`
+}
+
+// reqifBuilder collects spec objects while walking the section tree
+type reqifBuilder struct {
+	prefix     string
+	lastChange string
+	objects    []reqifSpecObject
+	// requirements not yet placed under a section, keyed by section title
+	pending map[string][]domain.Requirement
+	seq     int
+}
+
+// WriteReqIF writes the SRS as an OMG ReqIF 1.2 exchange document. Sections
+// become chapter objects and each identified requirement a requirement object
+// nested under its section in the specification hierarchy.
+func WriteReqIF(w io.Writer, doc *domain.ExportDocument) error {
+	lastChange := doc.Meta.Date
+	if lastChange.IsZero() {
+		lastChange = time.Now()
+	}
+
+	b := &reqifBuilder{
+		prefix:     fmt.Sprintf("SRS-%d", doc.SRSID),
+		lastChange: lastChange.UTC().Format(time.RFC3339),
+		pending:    map[string][]domain.Requirement{},
+	}
+	if doc.Revision > 0 {
+		b.prefix += fmt.Sprintf("-R%d", doc.Revision)
+	}
+	for _, req := range doc.Requirements {
+		b.pending[req.Section] = append(b.pending[req.Section], req)
+	}
+
+	out := reqifDoc{XMLNS: reqifNamespace}
+	out.Header.Header = reqifHeader{
+		Identifier:   b.prefix + "-HEADER",
+		Comment:      doc.Meta.Subtitle,
+		CreationTime: b.lastChange,
+		ToolID:       reqifToolID,
+		Version:      "1.0",
+		SourceToolID: reqifToolID,
+		Title:        doc.Meta.Title,
+	}
+
+	content := &out.Content.Content
+	content.Datatypes.Strings = []reqifDatatype{{
+		reqifIdentifiable: b.identifiable(reqifStringType, "String"),
+		MaxLength:         reqifMaxStringLength,
+	}}
+	content.SpecTypes.ObjectTypes = []reqifSpecObjectType{
+		b.objectType(reqifSectionType, "Section",
+			[2]string{reqifAttrSectionTitle, "ReqIF.ChapterName"},
+			[2]string{reqifAttrSectionText, "ReqIF.Text"}),
+		b.objectType(reqifRequirementType, "Requirement",
+			[2]string{reqifAttrForeignID, "ReqIF.ForeignID"},
+			[2]string{reqifAttrReqText, "ReqIF.Text"},
+			[2]string{reqifAttrReqTitle, "ReqIF.ChapterName"},
+			[2]string{reqifAttrReqType, "Type"},
+			[2]string{reqifAttrPriority, "Priority"},
+			[2]string{reqifAttrCriteria, "AcceptanceCriteria"}),
+	}
+	content.SpecTypes.SpecificationTypes = []reqifIdentifiable{b.identifiable(reqifSpecType, "Software Requirements Specification")}
+
+	children := b.sections(doc.Sections)
+	// Requirements whose section could not be matched are listed at the end
+	for _, req := range doc.Requirements {
+		if _, ok := b.pending[req.Section]; ok {
+			children = append(children, b.requirement(req))
+		}
+	}
+
+	spec := reqifSpecification{reqifIdentifiable: reqifIdentifiable{
+		Identifier: b.prefix,
+		LastChange: b.lastChange,
+		LongName:   doc.Meta.Title,
+	}}
+	spec.Type.Ref = reqifSpecType
+	if len(children) > 0 {
+		spec.Children = &reqifChildren{Hierarchies: children}
+	}
+	content.SpecObjects.Objects = b.objects
+	content.Specifications.Specifications = []reqifSpecification{spec}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (b *reqifBuilder) identifiable(id string, name string) reqifIdentifiable {
+	return reqifIdentifiable{Identifier: id, LastChange: b.lastChange, LongName: name}
+}
+
+// objectType builds a spec object type from {identifier, long name} attribute pairs
+func (b *reqifBuilder) objectType(id string, name string, attrs ...[2]string) reqifSpecObjectType {
+	t := reqifSpecObjectType{reqifIdentifiable: b.identifiable(id, name)}
+	for _, attr := range attrs {
+		def := reqifAttributeDefinition{reqifIdentifiable: b.identifiable(attr[0], attr[1])}
+		def.Type.Ref = reqifStringType
+		t.Attributes.Strings = append(t.Attributes.Strings, def)
+	}
+	return t
+}
+
+func (b *reqifBuilder) sections(sections []domain.SRSSection) []reqifHierarchy {
+	var hierarchies []reqifHierarchy
+	for _, sec := range sections {
+		b.seq++
+		objectID := fmt.Sprintf("%s-SEC-%d", b.prefix, b.seq)
+		b.addObject(objectID, reqifSectionType, map[string]string{
+			reqifAttrSectionTitle: sec.Title,
+			reqifAttrSectionText:  sec.Content,
+		})
+
+		children := b.sections(sec.Subsections)
+		for _, req := range b.pending[sec.Title] {
+			children = append(children, b.requirement(req))
+		}
+		delete(b.pending, sec.Title)
+
+		hierarchies = append(hierarchies, b.hierarchy(objectID, children))
+	}
+	return hierarchies
+}
+
+func (b *reqifBuilder) requirement(req domain.Requirement) reqifHierarchy {
+	objectID := fmt.Sprintf("%s-REQ-%s", b.prefix, reqifIdentifierRe.ReplaceAllString(req.Code, "_"))
+	b.addObject(objectID, reqifRequirementType, map[string]string{
+		reqifAttrForeignID: req.Code,
+		reqifAttrReqText:   req.Statement,
+		reqifAttrReqTitle:  req.Section,
+		reqifAttrReqType:   string(req.Type),
+		reqifAttrPriority:  req.Priority,
+		reqifAttrCriteria:  strings.Join(req.AcceptanceCriteria, "\n"),
+	})
+	return b.hierarchy(objectID, nil)
+}
+
+func (b *reqifBuilder) hierarchy(objectID string, children []reqifHierarchy) reqifHierarchy {
+	h := reqifHierarchy{reqifIdentifiable: reqifIdentifiable{
+		Identifier: objectID + "-H",
+		LastChange: b.lastChange,
+	}}
+	h.Object.Ref = objectID
+	if len(children) > 0 {
+		h.Children = &reqifChildren{Hierarchies: children}
+	}
+	return h
+}
+
+// addObject adds a spec object with the given non-empty attribute values,
+// written in attribute-definition order
+func (b *reqifBuilder) addObject(id string, objectType string, values map[string]string) {
+	obj := reqifSpecObject{reqifIdentifiable: reqifIdentifiable{Identifier: id, LastChange: b.lastChange}}
+	obj.Type.Ref = objectType
+
+	for _, def := range reqifAttributeOrder {
+		value, ok := values[def]
+		if !ok || value == "" {
+			continue
+		}
+		v := reqifStringValue{Value: value}
+		v.Definition.Ref = def
+		obj.Values.Strings = append(obj.Values.Strings, v)
+	}
+
+	b.objects = append(b.objects, obj)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AICacheRepository struct {
+	db *gorm.DB
+}
+
+func NewAICacheRepository(db *gorm.DB) *AICacheRepository {
+	return &AICacheRepository{db: db}
+}
+
+func (r *AICacheRepository) Find(key string, now time.Time) (*domain.AICacheEntry, error) {
+	var entry domain.AICacheEntry
+	err := r.db.Where("key = ? AND expires_at > ?", key, now).First(&entry).Error
+	return &entry, err
+}
+
+// Save upserts on (tenant_id, key); a refreshed response starts counting hits anew
+func (r *AICacheRepository) Save(entry *domain.AICacheEntry) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"response", "hits", "expires_at", "updated_at"}),
+	}).Create(entry).Error
+}
+
+func (r *AICacheRepository) AddHit(id uint) error {
+	return r.db.Model(&domain.AICacheEntry{}).
+		Where("id = ?", id).
+		UpdateColumn("hits", gorm.Expr("hits + 1")).Error
+}
+
+func (r *AICacheRepository) Stats() ([]domain.AICacheMethodStats, error) {
+	var stats []domain.AICacheMethodStats
+	err := r.db.Model(&domain.AICacheEntry{}).
+		Select("method, COUNT(*) AS entries, COALESCE(SUM(hits), 0) AS hits").
+		Group("method").
+		Order("method ASC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func (r *AICacheRepository) DeleteAll() error {
+	return r.db.Where("1 = 1").Delete(&domain.AICacheEntry{}).Error
+}
+
+func (r *AICacheRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.AICacheEntry{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AIUsageRepository struct {
+	db *gorm.DB
+}
+
+func NewAIUsageRepository(db *gorm.DB) *AIUsageRepository {
+	return &AIUsageRepository{db: db}
+}
+
+func (r *AIUsageRepository) Create(record *domain.AIUsageRecord) error {
+	return r.db.Create(record).Error
+}
+
+// aiUsageGroupColumns are the selected columns that name each group
+var aiUsageGroupColumns = map[domain.AIUsageGroup][]string{
+	domain.AIUsageByDay:      {"TO_CHAR(created_at, 'YYYY-MM-DD') AS day"},
+	domain.AIUsageByProject:  {"project_id"},
+	domain.AIUsageByDocument: {"document_id"},
+	domain.AIUsageByModel:    {"provider", "model"},
+}
+
+var aiUsageGroupKeys = map[domain.AIUsageGroup]string{
+	domain.AIUsageByDay:      "day",
+	domain.AIUsageByProject:  "project_id",
+	domain.AIUsageByDocument: "document_id",
+	domain.AIUsageByModel:    "provider, model",
+}
+
+func (r *AIUsageRepository) Summarize(filter domain.AIUsageFilter, group domain.AIUsageGroup) ([]domain.AIUsageSummary, error) {
+	columns := append(append([]string{}, aiUsageGroupColumns[group]...),
+		"COUNT(*) AS calls",
+		"COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens",
+		"COALESCE(SUM(completion_tokens), 0) AS completion_tokens",
+		"COALESCE(SUM(cost), 0) AS cost",
+		"COALESCE(AVG(latency_ms), 0) AS avg_latency_ms",
+	)
+
+	query := r.db.Model(&domain.AIUsageRecord{}).Select(columns)
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.DocumentID != 0 {
+		query = query.Where("document_id = ?", filter.DocumentID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if key, ok := aiUsageGroupKeys[group]; ok {
+		query = query.Group(key).Order(key)
+	}
+
+	var summaries []domain.AIUsageSummary
+	err := query.Scan(&summaries).Error
+	return summaries, err
+}
+
+func (r *AIUsageRepository) TotalCost(projectID uint, since time.Time) (float64, error) {
+	var total float64
+	err := r.db.Model(&domain.AIUsageRecord{}).
+		Select("COALESCE(SUM(cost), 0)").
+		Where("project_id = ? AND created_at >= ?", projectID, since).
+		Scan(&total).Error
+	return total, err
+}
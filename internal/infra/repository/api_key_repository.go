@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(key *domain.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *APIKeyRepository) FindByID(id uint) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.First(&key, id).Error
+	return &key, err
+}
+
+func (r *APIKeyRepository) FindByHash(hash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	err := r.db.Where("key_hash = ?", hash).First(&key).Error
+	return &key, err
+}
+
+func (r *APIKeyRepository) FindAll() ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *APIKeyRepository) Update(key *domain.APIKey) error {
+	return r.db.Save(key).Error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APISpecRepository struct {
+	db *gorm.DB
+}
+
+func NewAPISpecRepository(db *gorm.DB) *APISpecRepository {
+	return &APISpecRepository{db: db}
+}
+
+// Save upserts on srs_id, so each SRS keeps a single spec
+func (r *APISpecRepository) Save(spec *domain.APISpec) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "srs_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "version", "operations", "schemas", "document", "updated_at"}),
+	}).Create(spec).Error
+}
+
+func (r *APISpecRepository) FindBySRSID(srsID uint) (*domain.APISpec, error) {
+	var spec domain.APISpec
+	err := r.db.Where("srs_id = ?", srsID).First(&spec).Error
+	return &spec, err
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type AuditEventRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) *AuditEventRepository {
+	return &AuditEventRepository{db: db}
+}
+
+// Append locks the table while reading the last hash, so concurrent appends
+// can't fork the chain
+func (r *AuditEventRepository) Append(event *domain.AuditEvent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE audit_events IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		var last domain.AuditEvent
+		if err := tx.Order("id DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+
+		event.Seal(last.Hash)
+		return tx.Create(event).Error
+	})
+}
+
+func (r *AuditEventRepository) Find(filter domain.AuditFilter) ([]domain.AuditEvent, error) {
+	query := r.db.Model(&domain.AuditEvent{})
+	if filter.ActorID > 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.ActorName != "" {
+		query = query.Where("LOWER(actor_name) = LOWER(?)", filter.ActorName)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID > 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []domain.AuditEvent
+	err := query.Order("id DESC").Find(&events).Error
+	return events, err
+}
+
+func (r *AuditEventRepository) FindChain() ([]domain.AuditEvent, error) {
+	var events []domain.AuditEvent
+	err := r.db.Order("id ASC").Find(&events).Error
+	return events, err
+}
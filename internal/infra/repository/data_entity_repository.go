@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type DataEntityRepository struct {
+	db *gorm.DB
+}
+
+func NewDataEntityRepository(db *gorm.DB) *DataEntityRepository {
+	return &DataEntityRepository{db: db}
+}
+
+func (r *DataEntityRepository) Create(entity *domain.DataEntity) error {
+	return r.db.Create(entity).Error
+}
+
+func (r *DataEntityRepository) FindByID(id uint) (*domain.DataEntity, error) {
+	var entity domain.DataEntity
+	err := r.db.First(&entity, id).Error
+	return &entity, err
+}
+
+func (r *DataEntityRepository) FindBySRSID(srsID uint) ([]domain.DataEntity, error) {
+	var entities []domain.DataEntity
+	err := r.db.Where("srs_id = ?", srsID).Order("id ASC").Find(&entities).Error
+	return entities, err
+}
+
+func (r *DataEntityRepository) Update(entity *domain.DataEntity) error {
+	return r.db.Save(entity).Error
+}
+
+func (r *DataEntityRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.DataEntity{}, id).Error
+}
@@ -30,6 +30,53 @@ func (r *DocumentRepository) FindAll() ([]domain.Document, error) {
 	return docs, err
 }
 
+func (r *DocumentRepository) FindByProject(projectID uint) ([]domain.Document, error) {
+	var docs []domain.Document
+	err := r.db.Where("project_id = ?", projectID).Order("created_at DESC").Find(&docs).Error
+	return docs, err
+}
+
+// documentSortColumns maps domain.DocumentSortFields to columns
+var documentSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"filename":   "filename",
+	"status":     "status",
+	"type":       "type",
+}
+
+func (r *DocumentRepository) FindPage(filter domain.DocumentFilter) ([]domain.Document, int64, error) {
+	query := r.db.Model(&domain.Document{})
+	if filter.ProjectID > 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Author != "" {
+		query = query.Where("author = ?", filter.Author)
+	}
+	if filter.Search != "" {
+		query = query.Where("filename ILIKE ?", "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	// The generated SRS text is only loaded with a single document
+	var docs []domain.Document
+	total, err := findPage(query, filter.Page, documentSortColumns, &docs, func(db *gorm.DB) *gorm.DB {
+		return db.Omit("ExtractedData")
+	})
+	return docs, total, err
+}
+
 func (r *DocumentRepository) Update(doc *domain.Document) error {
 	return r.db.Save(doc).Error
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type FileAccessLogRepository struct {
+	db *gorm.DB
+}
+
+func NewFileAccessLogRepository(db *gorm.DB) *FileAccessLogRepository {
+	return &FileAccessLogRepository{db: db}
+}
+
+func (r *FileAccessLogRepository) Create(log *domain.FileAccessLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *FileAccessLogRepository) FindByDocumentID(docID uint) ([]domain.FileAccessLog, error) {
+	var logs []domain.FileAccessLog
+	err := r.db.Where("document_id = ?", docID).Order("created_at DESC").Find(&logs).Error
+	return logs, err
+}
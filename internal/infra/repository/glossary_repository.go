@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type GlossaryRepository struct {
+	db *gorm.DB
+}
+
+func NewGlossaryRepository(db *gorm.DB) *GlossaryRepository {
+	return &GlossaryRepository{db: db}
+}
+
+func (r *GlossaryRepository) Create(term *domain.GlossaryTerm) error {
+	return r.db.Create(term).Error
+}
+
+func (r *GlossaryRepository) FindByID(id uint) (*domain.GlossaryTerm, error) {
+	var term domain.GlossaryTerm
+	err := r.db.First(&term, id).Error
+	return &term, err
+}
+
+func (r *GlossaryRepository) FindByProject(projectID uint) ([]domain.GlossaryTerm, error) {
+	var terms []domain.GlossaryTerm
+	err := r.db.Where("project_id = ?", projectID).Order("term ASC").Find(&terms).Error
+	return terms, err
+}
+
+func (r *GlossaryRepository) FindByStatus(projectID uint, status domain.GlossaryTermStatus) ([]domain.GlossaryTerm, error) {
+	var terms []domain.GlossaryTerm
+	err := r.db.Where("project_id = ? AND status = ?", projectID, status).Order("term ASC").Find(&terms).Error
+	return terms, err
+}
+
+func (r *GlossaryRepository) Update(term *domain.GlossaryTerm) error {
+	return r.db.Save(term).Error
+}
+
+func (r *GlossaryRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.GlossaryTerm{}, id).Error
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+func (r *NotificationRepository) Create(notification *domain.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *NotificationRepository) Update(notification *domain.Notification) error {
+	return r.db.Save(notification).Error
+}
+
+func (r *NotificationRepository) FindRecent(limit int) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	err := r.db.Order("id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+// ClaimDue locks the due rows with SKIP LOCKED, so several server instances
+// can share the queue without sending an email twice
+func (r *NotificationRepository) ClaimDue(digest bool, now time.Time, lease time.Duration, limit int) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND digest = ? AND next_attempt_at <= ?", domain.NotificationPending, digest, now).
+			Order("id ASC").
+			Limit(limit).
+			Find(&notifications).Error; err != nil {
+			return err
+		}
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(notifications))
+		for i := range notifications {
+			ids[i] = notifications[i].ID
+		}
+		return tx.Model(&domain.Notification{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	return notifications, err
+}
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+func (r *NotificationPreferenceRepository) Save(pref *domain.NotificationPreference) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"mode", "types", "updated_at"}),
+	}).Create(pref).Error
+}
+
+func (r *NotificationPreferenceRepository) FindByUser(userID uint) (*domain.NotificationPreference, error) {
+	var pref domain.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).First(&pref).Error
+	return &pref, err
+}
+
+func (r *NotificationPreferenceRepository) FindAll() ([]domain.NotificationPreference, error) {
+	var prefs []domain.NotificationPreference
+	err := r.db.Find(&prefs).Error
+	return prefs, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"srs-automation/internal/core/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// findPage counts the rows of query and loads one page of them, ordered by
+// the sort column with the ID as tie-breaker. columns maps the sort fields
+// of the list to their SQL columns, so only known columns reach the query.
+// scopes, such as preloads, apply to loading the page but not to the count.
+func findPage[T any](query *gorm.DB, page domain.PageRequest, columns map[string]string, rows *[]T, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	column, ok := columns[page.Sort]
+	if !ok {
+		return 0, fmt.Errorf("unknown sort field %q", page.Sort)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	direction, after := "ASC", ">"
+	if page.Desc {
+		direction, after = "DESC", "<"
+	}
+	if page.After != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, after), page.After.Value, page.After.ID)
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+
+	err := query.Scopes(scopes...).Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).Limit(page.Limit + 1).Find(rows).Error
+	return total, err
+}
+
+// escapeLike makes a search term match literally inside a LIKE pattern
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
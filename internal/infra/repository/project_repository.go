@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) *ProjectRepository {
+	return &ProjectRepository{db: db}
+}
+
+func (r *ProjectRepository) Create(project *domain.Project) error {
+	return r.db.Create(project).Error
+}
+
+func (r *ProjectRepository) FindByID(id uint) (*domain.Project, error) {
+	var project domain.Project
+	err := r.db.First(&project, id).Error
+	return &project, err
+}
+
+func (r *ProjectRepository) FindByName(name string) (*domain.Project, error) {
+	var project domain.Project
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&project).Error
+	return &project, err
+}
+
+func (r *ProjectRepository) FindAll() ([]domain.Project, error) {
+	var projects []domain.Project
+	err := r.db.Order("name ASC").Find(&projects).Error
+	return projects, err
+}
+
+func (r *ProjectRepository) Update(project *domain.Project) error {
+	return r.db.Save(project).Error
+}
+
+func (r *ProjectRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Project{}, id).Error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RedactionReportRepository struct {
+	db *gorm.DB
+}
+
+func NewRedactionReportRepository(db *gorm.DB) *RedactionReportRepository {
+	return &RedactionReportRepository{db: db}
+}
+
+func (r *RedactionReportRepository) Save(report *domain.RedactionReport) error {
+	return r.db.Omit("Document").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "document_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"total", "counts", "findings", "updated_at"}),
+	}).Create(report).Error
+}
+
+func (r *RedactionReportRepository) FindByDocument(documentID uint) (*domain.RedactionReport, error) {
+	var report domain.RedactionReport
+	err := r.db.Where("document_id = ?", documentID).First(&report).Error
+	return &report, err
+}
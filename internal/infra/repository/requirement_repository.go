@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type RequirementRepository struct {
+	db *gorm.DB
+}
+
+func NewRequirementRepository(db *gorm.DB) *RequirementRepository {
+	return &RequirementRepository{db: db}
+}
+
+func (r *RequirementRepository) Create(req *domain.Requirement) error {
+	return r.db.Create(req).Error
+}
+
+func (r *RequirementRepository) FindBySRSID(srsID uint) ([]domain.Requirement, error) {
+	var requirements []domain.Requirement
+	err := r.db.Where("srs_id = ?", srsID).Order("id ASC").Find(&requirements).Error
+	return requirements, err
+}
+
+func (r *RequirementRepository) Update(req *domain.Requirement) error {
+	return r.db.Save(req).Error
+}
+
+func (r *RequirementRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Requirement{}, id).Error
+}
@@ -1,11 +1,24 @@
 package repository
 
 import (
+	"fmt"
 	"srs-automation/internal/core/domain"
 
 	"gorm.io/gorm"
 )
 
+// srsDependents are the models whose rows belong to an SRS by srs_id. They
+// are deleted together with their SRS; rows whose SRS was deleted without
+// them are removed by the retention sweeper.
+var srsDependents = []interface{}{
+	&domain.SRSRevision{},
+	&domain.Requirement{},
+	&domain.UserStory{},
+	&domain.UseCaseModel{},
+	&domain.APISpec{},
+	&domain.DataEntity{},
+}
+
 type SRSRepository struct {
 	db *gorm.DB
 }
@@ -36,10 +49,121 @@ func (r *SRSRepository) FindAll() ([]domain.SRS, error) {
 	return srsList, err
 }
 
+func (r *SRSRepository) FindByProject(projectID uint) ([]domain.SRS, error) {
+	var srsList []domain.SRS
+	err := r.db.Preload("SourceDocument").Where("project_id = ?", projectID).Order("created_at DESC").Find(&srsList).Error
+	return srsList, err
+}
+
+// srsSortColumns maps domain.SRSSortFields to columns
+var srsSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"title":      "title",
+	"status":     "status",
+	"author":     "author",
+}
+
+// FindPage preloads the source documents without their generated content,
+// which the SRS rows already carry
+func (r *SRSRepository) FindPage(filter domain.SRSFilter) ([]domain.SRS, int64, error) {
+	query := r.db.Model(&domain.SRS{})
+	if filter.ProjectID > 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Author != "" {
+		query = query.Where("author = ?", filter.Author)
+	}
+	if filter.Search != "" {
+		query = query.Where("title ILIKE ?", "%"+escapeLike(filter.Search)+"%")
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var srsList []domain.SRS
+	total, err := findPage(query, filter.Page, srsSortColumns, &srsList, func(db *gorm.DB) *gorm.DB {
+		return db.Preload("SourceDocument", func(db *gorm.DB) *gorm.DB {
+			return db.Omit("extracted_data")
+		})
+	})
+	return srsList, total, err
+}
+
+// FindOrphaned returns SRS rows whose source document no longer exists
+func (r *SRSRepository) FindOrphaned() ([]domain.SRS, error) {
+	var srsList []domain.SRS
+	err := r.db.Where("source_document_id NOT IN (?)", r.db.Model(&domain.Document{}).Select("id")).Find(&srsList).Error
+	return srsList, err
+}
+
 func (r *SRSRepository) Update(srs *domain.SRS) error {
 	return r.db.Save(srs).Error
 }
 
+// Delete removes an SRS and the rows that belong to it
 func (r *SRSRepository) Delete(id uint) error {
-	return r.db.Delete(&domain.SRS{}, id).Error
+	return r.deleteWhere("id = ?", id)
+}
+
+// DeleteByDocumentID removes the SRS of a document and the rows that belong to them
+func (r *SRSRepository) DeleteByDocumentID(docID uint) error {
+	return r.deleteWhere("source_document_id = ?", docID)
+}
+
+func (r *SRSRepository) deleteWhere(query string, args ...interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		ids := tx.Model(&domain.SRS{}).Select("id").Where(query, args...)
+		for _, model := range srsDependents {
+			if err := tx.Where("srs_id IN (?)", ids).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where(query, args...).Delete(&domain.SRS{}).Error
+	})
+}
+
+// CountOrphanedDependents counts, per table, the rows whose SRS no longer exists
+func (r *SRSRepository) CountOrphanedDependents() (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, model := range srsDependents {
+		var count int64
+		if err := r.db.Model(model).Where("srs_id NOT IN (?)", r.db.Model(&domain.SRS{}).Select("id")).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			counts[tableName(r.db, model)] = count
+		}
+	}
+	return counts, nil
+}
+
+// DeleteOrphanedDependents deletes the rows whose SRS no longer exists and
+// returns how many were deleted per table
+func (r *SRSRepository) DeleteOrphanedDependents() (map[string]int64, error) {
+	counts := map[string]int64{}
+	for _, model := range srsDependents {
+		result := r.db.Where("srs_id NOT IN (?)", r.db.Model(&domain.SRS{}).Select("id")).Delete(model)
+		if result.Error != nil {
+			return counts, result.Error
+		}
+		if result.RowsAffected > 0 {
+			counts[tableName(r.db, model)] = result.RowsAffected
+		}
+	}
+	return counts, nil
+}
+
+func tableName(db *gorm.DB, model interface{}) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return fmt.Sprintf("%T", model)
+	}
+	return stmt.Schema.Table
 }
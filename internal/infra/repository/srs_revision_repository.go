@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type SRSRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewSRSRevisionRepository(db *gorm.DB) *SRSRevisionRepository {
+	return &SRSRevisionRepository{db: db}
+}
+
+func (r *SRSRevisionRepository) Create(rev *domain.SRSRevision) error {
+	return r.db.Create(rev).Error
+}
+
+func (r *SRSRevisionRepository) FindBySRSID(srsID uint) ([]domain.SRSRevision, error) {
+	var revisions []domain.SRSRevision
+	err := r.db.Where("srs_id = ?", srsID).Order("revision DESC").Find(&revisions).Error
+	return revisions, err
+}
+
+func (r *SRSRevisionRepository) FindByRevision(srsID uint, revision int) (*domain.SRSRevision, error) {
+	var rev domain.SRSRevision
+	err := r.db.Where("srs_id = ? AND revision = ?", srsID, revision).First(&rev).Error
+	return &rev, err
+}
+
+// LatestRevision returns the highest revision number of an SRS, or 0 if it has none
+func (r *SRSRevisionRepository) LatestRevision(srsID uint) (int, error) {
+	var latest int
+	err := r.db.Model(&domain.SRSRevision{}).
+		Where("srs_id = ?", srsID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error
+	return latest, err
+}
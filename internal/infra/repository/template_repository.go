@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type DocxTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewDocxTemplateRepository(db *gorm.DB) *DocxTemplateRepository {
+	return &DocxTemplateRepository{db: db}
+}
+
+func (r *DocxTemplateRepository) Create(tmpl *domain.DocxTemplate) error {
+	return r.db.Create(tmpl).Error
+}
+
+func (r *DocxTemplateRepository) FindByID(id uint) (*domain.DocxTemplate, error) {
+	var tmpl domain.DocxTemplate
+	err := r.db.First(&tmpl, id).Error
+	return &tmpl, err
+}
+
+func (r *DocxTemplateRepository) FindByProject(projectID uint) ([]domain.DocxTemplate, error) {
+	var templates []domain.DocxTemplate
+	err := r.db.Where("project_id = ?", projectID).Order("created_at DESC").Find(&templates).Error
+	return templates, err
+}
+
+// FindDefault returns the project's default template, or nil if none is set
+func (r *DocxTemplateRepository) FindDefault(projectID uint) (*domain.DocxTemplate, error) {
+	var tmpl domain.DocxTemplate
+	result := r.db.Where("project_id = ? AND is_default = ?", projectID, true).Limit(1).Find(&tmpl)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return nil, result.Error
+	}
+	return &tmpl, nil
+}
+
+func (r *DocxTemplateRepository) FindAll() ([]domain.DocxTemplate, error) {
+	var templates []domain.DocxTemplate
+	err := r.db.Order("created_at DESC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *DocxTemplateRepository) Update(tmpl *domain.DocxTemplate) error {
+	return r.db.Save(tmpl).Error
+}
+
+func (r *DocxTemplateRepository) ClearDefault(projectID uint) error {
+	return r.db.Model(&domain.DocxTemplate{}).
+		Where("project_id = ? AND is_default = ?", projectID, true).
+		Update("is_default", false).Error
+}
+
+func (r *DocxTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.DocxTemplate{}, id).Error
+}
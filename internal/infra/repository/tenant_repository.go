@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TenantRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantRepository(db *gorm.DB) *TenantRepository {
+	return &TenantRepository{db: db}
+}
+
+func (r *TenantRepository) Create(tenant *domain.Tenant) error {
+	return r.db.Create(tenant).Error
+}
+
+func (r *TenantRepository) FindByID(id uint) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	err := r.db.First(&tenant, id).Error
+	return &tenant, err
+}
+
+func (r *TenantRepository) FindBySlug(slug string) (*domain.Tenant, error) {
+	var tenant domain.Tenant
+	err := r.db.Where("slug = ?", slug).First(&tenant).Error
+	return &tenant, err
+}
+
+func (r *TenantRepository) FindAll() ([]domain.Tenant, error) {
+	var tenants []domain.Tenant
+	err := r.db.Order("slug ASC").Find(&tenants).Error
+	return tenants, err
+}
+
+func (r *TenantRepository) Update(tenant *domain.Tenant) error {
+	return r.db.Save(tenant).Error
+}
+
+type TenantAICredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantAICredentialRepository(db *gorm.DB) *TenantAICredentialRepository {
+	return &TenantAICredentialRepository{db: db}
+}
+
+// Save upserts on (tenant_id, provider), so a tenant keeps one key per provider
+func (r *TenantAICredentialRepository) Save(credential *domain.TenantAICredential) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_id"}, {Name: "provider"}},
+		DoUpdates: clause.AssignmentColumns([]string{"encrypted_key", "model", "updated_at"}),
+	}).Create(credential).Error
+}
+
+func (r *TenantAICredentialRepository) FindByTenant(tenantID uint) ([]domain.TenantAICredential, error) {
+	var credentials []domain.TenantAICredential
+	err := r.db.Where("tenant_id = ?", tenantID).Order("provider ASC").Find(&credentials).Error
+	return credentials, err
+}
+
+func (r *TenantAICredentialRepository) Delete(tenantID uint, provider string) error {
+	return r.db.Where("tenant_id = ? AND provider = ?", tenantID, provider).Delete(&domain.TenantAICredential{}).Error
+}
+
+type TenantAIUsageRepository struct {
+	db *gorm.DB
+}
+
+func NewTenantAIUsageRepository(db *gorm.DB) *TenantAIUsageRepository {
+	return &TenantAIUsageRepository{db: db}
+}
+
+func (r *TenantAIUsageRepository) Increment(tenantID uint, period string) (int, error) {
+	usage := domain.TenantAIUsage{TenantID: tenantID, Period: period, Requests: 1}
+	err := r.db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "period"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"requests":   gorm.Expr("tenant_ai_usages.requests + 1"),
+				"updated_at": gorm.Expr("NOW()"),
+			}),
+		},
+		clause.Returning{Columns: []clause.Column{{Name: "requests"}}},
+	).Create(&usage).Error
+	return usage.Requests, err
+}
+
+func (r *TenantAIUsageRepository) FindByPeriod(tenantID uint, period string) (*domain.TenantAIUsage, error) {
+	var usage domain.TenantAIUsage
+	result := r.db.Where("tenant_id = ? AND period = ?", tenantID, period).Limit(1).Find(&usage)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	// A period without requests has no row yet
+	usage.TenantID, usage.Period = tenantID, period
+	return &usage, nil
+}
+
+func (r *TenantAIUsageRepository) FindByTenant(tenantID uint) ([]domain.TenantAIUsage, error) {
+	var usages []domain.TenantAIUsage
+	err := r.db.Where("tenant_id = ?", tenantID).Order("period DESC").Find(&usages).Error
+	return usages, err
+}
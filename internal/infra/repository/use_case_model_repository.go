@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UseCaseModelRepository struct {
+	db *gorm.DB
+}
+
+func NewUseCaseModelRepository(db *gorm.DB) *UseCaseModelRepository {
+	return &UseCaseModelRepository{db: db}
+}
+
+// Save upserts on srs_id, so each SRS keeps a single model
+func (r *UseCaseModelRepository) Save(model *domain.UseCaseModel) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "srs_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"actors", "use_cases", "entities", "diagrams", "updated_at"}),
+	}).Create(model).Error
+}
+
+func (r *UseCaseModelRepository) FindBySRSID(srsID uint) (*domain.UseCaseModel, error) {
+	var model domain.UseCaseModel
+	err := r.db.Where("srs_id = ?", srsID).First(&model).Error
+	return &model, err
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(user *domain.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *UserRepository) FindByID(id uint) (*domain.User, error) {
+	var user domain.User
+	err := r.db.First(&user, id).Error
+	return &user, err
+}
+
+func (r *UserRepository) FindByEmail(email string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.Where("LOWER(email) = LOWER(?)", email).First(&user).Error
+	return &user, err
+}
+
+func (r *UserRepository) FindAll() ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.Order("email ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *UserRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.User{}).Count(&count).Error
+	return count, err
+}
+
+func (r *UserRepository) Update(user *domain.User) error {
+	return r.db.Save(user).Error
+}
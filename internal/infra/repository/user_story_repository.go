@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+
+	"gorm.io/gorm"
+)
+
+type UserStoryRepository struct {
+	db *gorm.DB
+}
+
+func NewUserStoryRepository(db *gorm.DB) *UserStoryRepository {
+	return &UserStoryRepository{db: db}
+}
+
+func (r *UserStoryRepository) Create(story *domain.UserStory) error {
+	return r.db.Create(story).Error
+}
+
+func (r *UserStoryRepository) FindByID(id uint) (*domain.UserStory, error) {
+	var story domain.UserStory
+	err := r.db.First(&story, id).Error
+	return &story, err
+}
+
+func (r *UserStoryRepository) FindBySRSID(srsID uint) ([]domain.UserStory, error) {
+	var stories []domain.UserStory
+	err := r.db.Where("srs_id = ?", srsID).Order("id ASC").Find(&stories).Error
+	return stories, err
+}
+
+func (r *UserStoryRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.UserStory{}, id).Error
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"srs-automation/internal/core/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Create(webhook *domain.Webhook) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *WebhookRepository) FindByID(id uint) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := r.db.First(&webhook, id).Error
+	return &webhook, err
+}
+
+func (r *WebhookRepository) FindAll() ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := r.db.Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepository) FindActive(projectID uint) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+	err := r.db.Where("active = ? AND project_id IN ?", true, []uint{0, projectID}).
+		Order("id ASC").
+		Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *WebhookRepository) Update(webhook *domain.Webhook) error {
+	return r.db.Save(webhook).Error
+}
+
+func (r *WebhookRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Webhook{}, id).Error
+}
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) Create(delivery *domain.WebhookDelivery) error {
+	return r.db.Create(delivery).Error
+}
+
+func (r *WebhookDeliveryRepository) Update(delivery *domain.WebhookDelivery) error {
+	return r.db.Omit("Webhook").Save(delivery).Error
+}
+
+func (r *WebhookDeliveryRepository) FindByWebhook(webhookID uint, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// ClaimDue locks the due rows with SKIP LOCKED, so several server instances
+// can share the queue without sending a delivery twice
+func (r *WebhookDeliveryRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var ids []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.WebhookDelivery{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.DeliveryPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var deliveries []domain.WebhookDelivery
+	err = r.db.Preload("Webhook").Where("id IN ?", ids).Order("id ASC").Find(&deliveries).Error
+	return deliveries, err
+}
@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Span is a run of inline text sharing the same formatting
+type Span struct {
+	Text          string
+	Bold          bool
+	Italic        bool
+	Code          bool
+	RequirementID bool
+}
+
+// RequirementIDPattern matches requirement identifiers such as FR-001,
+// NFR-02, REQ-1.2 or UC-05.
+var RequirementIDPattern = regexp.MustCompile(`\b(?:FR|NFR|REQ|BR|UC|SR|US)[-_]?\d+(?:[.-]\d+)*\b`)
+
+var linkRe = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)]*)\)`)
+
+// ParseInline splits inline Markdown into formatted spans. Links are reduced
+// to their text and requirement IDs are split into their own spans.
+func ParseInline(text string) []Span {
+	text = linkRe.ReplaceAllString(text, "$1")
+
+	var spans []Span
+	var buf strings.Builder
+	bold, italic := false, false
+
+	flush := func() {
+		if buf.Len() > 0 {
+			spans = append(spans, Span{Text: buf.String(), Bold: bold, Italic: italic})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && strings.ContainsRune("\\`*_#|[]()", rune(text[i+1])):
+			buf.WriteByte(text[i+1])
+			i += 2
+
+		case text[i] == '`':
+			end := strings.IndexByte(text[i+1:], '`')
+			if end < 0 {
+				buf.WriteByte(text[i])
+				i++
+				continue
+			}
+			flush()
+			spans = append(spans, Span{Text: text[i+1 : i+1+end], Code: true, Bold: bold, Italic: italic})
+			i += end + 2
+
+		case strings.HasPrefix(text[i:], "**") || strings.HasPrefix(text[i:], "__"):
+			marker := text[i : i+2]
+			if !bold && !strings.Contains(text[i+2:], marker) {
+				buf.WriteString(marker)
+				i += 2
+				continue
+			}
+			flush()
+			bold = !bold
+			i += 2
+
+		case text[i] == '*' || (text[i] == '_' && (i == 0 || !isWordByte(text[i-1]) || italic)):
+			marker := text[i : i+1]
+			if !italic && (i+1 >= len(text) || text[i+1] == ' ' || !strings.Contains(text[i+1:], marker)) {
+				buf.WriteByte(text[i])
+				i++
+				continue
+			}
+			flush()
+			italic = !italic
+			i++
+
+		default:
+			buf.WriteByte(text[i])
+			i++
+		}
+	}
+	flush()
+
+	return splitRequirementIDs(spans)
+}
+
+// PlainText strips inline Markdown formatting
+func PlainText(text string) string {
+	var b strings.Builder
+	for _, span := range ParseInline(text) {
+		b.WriteString(span.Text)
+	}
+	return b.String()
+}
+
+func splitRequirementIDs(spans []Span) []Span {
+	var out []Span
+	for _, span := range spans {
+		if span.Code {
+			out = append(out, span)
+			continue
+		}
+
+		last := 0
+		for _, loc := range RequirementIDPattern.FindAllStringIndex(span.Text, -1) {
+			if loc[0] > last {
+				out = append(out, Span{Text: span.Text[last:loc[0]], Bold: span.Bold, Italic: span.Italic})
+			}
+			out = append(out, Span{Text: span.Text[loc[0]:loc[1]], Bold: span.Bold, Italic: span.Italic, RequirementID: true})
+			last = loc[1]
+		}
+		if last < len(span.Text) {
+			out = append(out, Span{Text: span.Text[last:], Bold: span.Bold, Italic: span.Italic})
+		}
+	}
+	return out
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
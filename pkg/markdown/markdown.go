@@ -0,0 +1,189 @@
+// Package markdown parses the Markdown produced by the AI providers into a
+// small block/inline model that the document renderers share.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BlockKind identifies the type of a block
+type BlockKind int
+
+const (
+	BlockParagraph BlockKind = iota
+	BlockHeading
+	BlockList
+	BlockTable
+	BlockCode
+	BlockRule
+)
+
+// Block is a top-level element of a Markdown document
+type Block struct {
+	Kind BlockKind
+
+	// Heading level (1-6) for BlockHeading
+	Level int
+	// Raw inline text for BlockHeading and BlockParagraph, source for BlockCode
+	Text string
+	// Info string of a fenced code block
+	Lang string
+
+	Items []ListItem
+
+	Header []string
+	Rows   [][]string
+}
+
+// ListItem is a single (possibly nested) list entry
+type ListItem struct {
+	Text    string
+	Depth   int
+	Ordered bool
+}
+
+var (
+	headingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listItemRe  = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	tableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-{2,}:?\s*(\|\s*:?-{2,}:?\s*)*\|?\s*$`)
+	ruleRe      = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	codeFenceRe = regexp.MustCompile("^\\s*(```|~~~)\\s*(\\S*)")
+)
+
+// Parse splits Markdown source into blocks
+func Parse(src string) []Block {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var blocks []Block
+	var para []string
+
+	flushPara := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, Block{Kind: BlockParagraph, Text: strings.Join(para, " ")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			flushPara()
+			continue
+		}
+
+		if m := codeFenceRe.FindStringSubmatch(line); m != nil {
+			flushPara()
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, Block{Kind: BlockCode, Lang: m[2], Text: strings.Join(code, "\n")})
+			continue
+		}
+
+		if m := headingRe.FindStringSubmatch(trimmed); m != nil {
+			flushPara()
+			blocks = append(blocks, Block{Kind: BlockHeading, Level: len(m[1]), Text: m[2]})
+			continue
+		}
+
+		if ruleRe.MatchString(trimmed) {
+			flushPara()
+			blocks = append(blocks, Block{Kind: BlockRule})
+			continue
+		}
+
+		if strings.Contains(trimmed, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) {
+			flushPara()
+			table := Block{Kind: BlockTable, Header: splitTableRow(trimmed)}
+			for i += 2; i < len(lines); i++ {
+				row := strings.TrimSpace(lines[i])
+				if row == "" || !strings.Contains(row, "|") {
+					i--
+					break
+				}
+				table.Rows = append(table.Rows, splitTableRow(row))
+			}
+			blocks = append(blocks, table)
+			continue
+		}
+
+		if listItemRe.MatchString(line) {
+			flushPara()
+			list := Block{Kind: BlockList}
+			baseIndent := -1
+			for ; i < len(lines); i++ {
+				cur := lines[i]
+				if strings.TrimSpace(cur) == "" {
+					// A blank line ends the list unless another item follows
+					if i+1 < len(lines) && listItemRe.MatchString(lines[i+1]) {
+						continue
+					}
+					break
+				}
+
+				m := listItemRe.FindStringSubmatch(cur)
+				if m == nil {
+					if indentWidth(cur) > 0 && len(list.Items) > 0 {
+						last := &list.Items[len(list.Items)-1]
+						last.Text += " " + strings.TrimSpace(cur)
+						continue
+					}
+					i--
+					break
+				}
+
+				indent := indentWidth(m[1])
+				if baseIndent < 0 || indent < baseIndent {
+					baseIndent = indent
+				}
+				list.Items = append(list.Items, ListItem{
+					Text:    m[3],
+					Depth:   (indent - baseIndent) / 2,
+					Ordered: m[2] != "-" && m[2] != "*" && m[2] != "+",
+				})
+			}
+			blocks = append(blocks, list)
+			continue
+		}
+
+		// Blockquotes are rendered as plain paragraphs
+		para = append(para, strings.TrimSpace(strings.TrimLeft(trimmed, ">")))
+	}
+	flushPara()
+
+	return blocks
+}
+
+func indentWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
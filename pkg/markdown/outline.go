@@ -0,0 +1,68 @@
+package markdown
+
+import "strings"
+
+// Section is a heading together with the Markdown that follows it up to the
+// next heading. Deeper headings become children.
+type Section struct {
+	Level    int
+	Title    string
+	Body     string
+	Children []Section
+}
+
+// Outline splits Markdown source into a heading tree. Text before the first
+// heading is returned as a level 0 section without a title.
+func Outline(src string) []Section {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	type node struct {
+		section  Section
+		body     []string
+		children []*node
+	}
+
+	root := &node{}
+	stack := []*node{root}
+	current := root
+	inFence := false
+
+	for _, line := range lines {
+		if codeFenceRe.MatchString(line) {
+			inFence = !inFence
+		}
+
+		if m := headingRe.FindStringSubmatch(line); m != nil && !inFence {
+			n := &node{section: Section{Level: len(m[1]), Title: PlainText(m[2])}}
+			for len(stack) > 1 && stack[len(stack)-1].section.Level >= n.section.Level {
+				stack = stack[:len(stack)-1]
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+			current = n
+			continue
+		}
+
+		current.body = append(current.body, line)
+	}
+
+	var build func(n *node) Section
+	build = func(n *node) Section {
+		s := n.section
+		s.Body = strings.TrimSpace(strings.Join(n.body, "\n"))
+		for _, child := range n.children {
+			s.Children = append(s.Children, build(child))
+		}
+		return s
+	}
+
+	var sections []Section
+	if preamble := strings.TrimSpace(strings.Join(root.body, "\n")); preamble != "" {
+		sections = append(sections, Section{Body: preamble})
+	}
+	for _, child := range root.children {
+		sections = append(sections, build(child))
+	}
+	return sections
+}